@@ -82,8 +82,16 @@ var (
 				EnableCommandLogging: viper.GetBool("enable-command-logging"),
 				LogFilePath:          viper.GetString("log-file"),
 				ContentWindowSize:    viper.GetInt("content-window-size"),
-				LockdownMode:         viper.GetBool("lockdown-mode"),
-				RepoAccessCacheTTL:   &ttl,
+				SelectionBudget:      viper.GetInt("selection-budget"),
+				MutationAttribution:  viper.GetString("mutation-attribution"),
+				DefaultPageSize:      viper.GetInt("default-page-size"),
+				GraphQLRetryPolicy: github.GraphQLRetryPolicy{
+					MaxAttempts: viper.GetInt("graphql-retry-attempts"),
+					BaseDelay:   viper.GetDuration("graphql-retry-base-delay"),
+				},
+				LockdownMode:       viper.GetBool("lockdown-mode"),
+				ProbeTokenScopes:   viper.GetBool("probe-token-scopes"),
+				RepoAccessCacheTTL: &ttl,
 			}
 			return ghmcp.RunStdioServer(stdioServerConfig)
 		},
@@ -107,7 +115,13 @@ func init() {
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
 	rootCmd.PersistentFlags().Int("content-window-size", 5000, "Specify the content window size")
+	rootCmd.PersistentFlags().Int("selection-budget", 0, "Maximum estimated number of nodes a single tool call may select when combining expensive options (0 uses the default)")
+	rootCmd.PersistentFlags().String("mutation-attribution", "", "Source label appended to discussion and comment bodies created by the server, for audit traceability")
+	rootCmd.PersistentFlags().Int("default-page-size", 0, "Page size of discussion listings when none is requested, capped at 100 (0 uses the default of 30)")
+	rootCmd.PersistentFlags().Int("graphql-retry-attempts", 0, "Number of times discussion tools make a GraphQL query that fails with a 5xx status or a secondary rate limit, including the first (0 uses the default of 3)")
+	rootCmd.PersistentFlags().Duration("graphql-retry-base-delay", 0, "Wait before the first retry of a failed GraphQL query, doubled for each further retry (0 uses the default of 1s)")
 	rootCmd.PersistentFlags().Bool("lockdown-mode", false, "Enable lockdown mode")
+	rootCmd.PersistentFlags().Bool("probe-token-scopes", false, "Check the token's scopes at startup and omit discussion write tools when it can't write to discussions")
 	rootCmd.PersistentFlags().Duration("repo-access-cache-ttl", 5*time.Minute, "Override the repo access cache TTL (e.g. 1m, 0s to disable)")
 
 	// Bind flag to viper
@@ -121,7 +135,13 @@ func init() {
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
 	_ = viper.BindPFlag("content-window-size", rootCmd.PersistentFlags().Lookup("content-window-size"))
+	_ = viper.BindPFlag("selection-budget", rootCmd.PersistentFlags().Lookup("selection-budget"))
+	_ = viper.BindPFlag("mutation-attribution", rootCmd.PersistentFlags().Lookup("mutation-attribution"))
+	_ = viper.BindPFlag("default-page-size", rootCmd.PersistentFlags().Lookup("default-page-size"))
+	_ = viper.BindPFlag("graphql-retry-attempts", rootCmd.PersistentFlags().Lookup("graphql-retry-attempts"))
+	_ = viper.BindPFlag("graphql-retry-base-delay", rootCmd.PersistentFlags().Lookup("graphql-retry-base-delay"))
 	_ = viper.BindPFlag("lockdown-mode", rootCmd.PersistentFlags().Lookup("lockdown-mode"))
+	_ = viper.BindPFlag("probe-token-scopes", rootCmd.PersistentFlags().Lookup("probe-token-scopes"))
 	_ = viper.BindPFlag("repo-access-cache-ttl", rootCmd.PersistentFlags().Lookup("repo-access-cache-ttl"))
 
 	// Add subcommands
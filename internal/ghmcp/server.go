@@ -60,9 +60,25 @@ type MCPServerConfig struct {
 	// Content window size
 	ContentWindowSize int
 
+	// SelectionBudget caps the estimated number of nodes a tool call may select, 0 for the default
+	SelectionBudget int
+
+	// MutationAttribution is a source label appended to bodies written by discussion mutations
+	MutationAttribution string
+
+	// DefaultPageSize is the page size of GraphQL list tools when none is requested, 0 for the default
+	DefaultPageSize int
+
+	// GraphQLRetryPolicy is how discussion tools retry transient GraphQL failures, zero for the default
+	GraphQLRetryPolicy github.GraphQLRetryPolicy
+
 	// LockdownMode indicates if we should enable lockdown mode
 	LockdownMode bool
 
+	// ProbeTokenScopes checks the token's scopes at startup and omits the discussion tools that
+	// write when it can't write to discussions
+	ProbeTokenScopes bool
+
 	// Logger is used for logging within the server
 	Logger *slog.Logger
 	// RepoAccessTTL overrides the default TTL for repository access cache entries.
@@ -90,7 +106,7 @@ func createGitHubClients(cfg MCPServerConfig, apiHost apiHost) (*githubClients,
 	// We use NewEnterpriseClient unconditionally since we already parsed the API host
 	gqlHTTPClient := &http.Client{
 		Transport: &bearerAuthTransport{
-			transport: http.DefaultTransport,
+			transport: github.NewRetryAfterTransport(github.NewRequestIDTransport(http.DefaultTransport)),
 			token:     cfg.Token,
 		},
 	}
@@ -201,6 +217,13 @@ func NewMCPServer(cfg MCPServerConfig) (*mcp.Server, error) {
 		cfg.Translator,
 		github.FeatureFlags{LockdownMode: cfg.LockdownMode},
 		cfg.ContentWindowSize,
+		github.DiscussionOptions{
+			SelectionBudget:         cfg.SelectionBudget,
+			MutationAttribution:     cfg.MutationAttribution,
+			DiscussionCategoryCache: github.NewDiscussionCategoryCache(github.DefaultDiscussionCategoryCacheTTL),
+			DefaultPageSize:         cfg.DefaultPageSize,
+			GraphQLRetryPolicy:      cfg.GraphQLRetryPolicy,
+		},
 	)
 
 	// Inject dependencies into context for all tool handlers
@@ -211,13 +234,24 @@ func NewMCPServer(cfg MCPServerConfig) (*mcp.Server, error) {
 	})
 
 	// Build and register the tool/resource/prompt inventory
-	inventory := github.NewInventory(cfg.Translator).
+	inventoryBuilder := github.NewInventory(cfg.Translator).
 		WithDeprecatedAliases(github.DeprecatedToolAliases).
 		WithReadOnly(cfg.ReadOnly).
 		WithToolsets(enabledToolsets).
 		WithTools(github.CleanTools(cfg.EnabledTools)).
-		WithFeatureChecker(createFeatureChecker(cfg.EnabledFeatures)).
-		Build()
+		WithFeatureChecker(createFeatureChecker(cfg.EnabledFeatures))
+
+	if cfg.ProbeTokenScopes {
+		canWrite, err := github.ProbeDiscussionWriteAccess(context.Background(), clients.rest)
+		if err != nil {
+			// Leave enforcement to the API rather than hide tools the token may be able to use
+			cfg.Logger.Warn("token scope probe failed, registering all discussion tools", "error", err)
+			canWrite = true
+		}
+		inventoryBuilder = inventoryBuilder.WithFilter(github.DiscussionWriteToolsFilter(canWrite))
+	}
+
+	inventory := inventoryBuilder.Build()
 
 	if unrecognized := inventory.UnrecognizedToolsets(); len(unrecognized) > 0 {
 		fmt.Fprintf(os.Stderr, "Warning: unrecognized toolsets ignored: %s\n", strings.Join(unrecognized, ", "))
@@ -307,9 +341,25 @@ type StdioServerConfig struct {
 	// Content window size
 	ContentWindowSize int
 
+	// SelectionBudget caps the estimated number of nodes a tool call may select, 0 for the default
+	SelectionBudget int
+
+	// MutationAttribution is a source label appended to bodies written by discussion mutations
+	MutationAttribution string
+
+	// DefaultPageSize is the page size of GraphQL list tools when none is requested, 0 for the default
+	DefaultPageSize int
+
+	// GraphQLRetryPolicy is how discussion tools retry transient GraphQL failures, zero for the default
+	GraphQLRetryPolicy github.GraphQLRetryPolicy
+
 	// LockdownMode indicates if we should enable lockdown mode
 	LockdownMode bool
 
+	// ProbeTokenScopes checks the token's scopes at startup and omits the discussion tools that
+	// write when it can't write to discussions
+	ProbeTokenScopes bool
+
 	// RepoAccessCacheTTL overrides the default TTL for repository access cache entries.
 	RepoAccessCacheTTL *time.Duration
 }
@@ -339,19 +389,24 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	logger.Info("starting server", "version", cfg.Version, "host", cfg.Host, "dynamicToolsets", cfg.DynamicToolsets, "readOnly", cfg.ReadOnly, "lockdownEnabled", cfg.LockdownMode)
 
 	ghServer, err := NewMCPServer(MCPServerConfig{
-		Version:           cfg.Version,
-		Host:              cfg.Host,
-		Token:             cfg.Token,
-		EnabledToolsets:   cfg.EnabledToolsets,
-		EnabledTools:      cfg.EnabledTools,
-		EnabledFeatures:   cfg.EnabledFeatures,
-		DynamicToolsets:   cfg.DynamicToolsets,
-		ReadOnly:          cfg.ReadOnly,
-		Translator:        t,
-		ContentWindowSize: cfg.ContentWindowSize,
-		LockdownMode:      cfg.LockdownMode,
-		Logger:            logger,
-		RepoAccessTTL:     cfg.RepoAccessCacheTTL,
+		Version:             cfg.Version,
+		Host:                cfg.Host,
+		Token:               cfg.Token,
+		EnabledToolsets:     cfg.EnabledToolsets,
+		EnabledTools:        cfg.EnabledTools,
+		EnabledFeatures:     cfg.EnabledFeatures,
+		DynamicToolsets:     cfg.DynamicToolsets,
+		ReadOnly:            cfg.ReadOnly,
+		Translator:          t,
+		ContentWindowSize:   cfg.ContentWindowSize,
+		SelectionBudget:     cfg.SelectionBudget,
+		MutationAttribution: cfg.MutationAttribution,
+		DefaultPageSize:     cfg.DefaultPageSize,
+		GraphQLRetryPolicy:  cfg.GraphQLRetryPolicy,
+		LockdownMode:        cfg.LockdownMode,
+		ProbeTokenScopes:    cfg.ProbeTokenScopes,
+		Logger:              logger,
+		RepoAccessTTL:       cfg.RepoAccessCacheTTL,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
@@ -146,12 +146,15 @@ func githubv4InputStructToMap(s any) (map[string]any, error) {
 //	  StateReason *IssueClosedStateReason `json:"stateReason,omitempty"`
 //	}
 //
+// Several matchers may share the same query as long as their variables differ, for example to mock successive
+// pages of a cursor-paginated query. The first matcher whose variables match the request is used.
+//
 // This client does not currently provide a mechanism for out-of-band errors e.g. returning a 500,
 // and errors are constrained to GQL errors returned in the response body with a 200 status code.
 func NewMockedHTTPClient(ms ...Matcher) *http.Client {
-	matchers := make(map[string]Matcher, len(ms))
+	matchers := make(map[string][]Matcher, len(ms))
 	for _, m := range ms {
-		matchers[m.Request] = m
+		matchers[m.Request] = append(matchers[m.Request], m)
 	}
 
 	mux := http.NewServeMux()
@@ -168,24 +171,25 @@ func NewMockedHTTPClient(ms ...Matcher) *http.Client {
 		}
 		defer func() { _ = r.Body.Close() }()
 
-		matcher, ok := matchers[gqlRequest.Query]
+		candidates, ok := matchers[gqlRequest.Query]
 		if !ok {
 			http.Error(w, fmt.Sprintf("no matcher found for query %s", gqlRequest.Query), http.StatusNotFound)
 			return
 		}
 
-		if len(gqlRequest.Variables) > 0 {
-			if len(gqlRequest.Variables) != len(matcher.Variables) {
-				http.Error(w, "variables do not have the same length", http.StatusBadRequest)
-				return
-			}
-
-			for k, v := range matcher.Variables {
-				if !objectsAreEqualValues(v, gqlRequest.Variables[k]) {
-					http.Error(w, "variable does not match", http.StatusBadRequest)
-					return
-				}
+		var matcher *Matcher
+		var mismatch string
+		for i := range candidates {
+			if reason := variablesMismatch(candidates[i].Variables, gqlRequest.Variables); reason != "" {
+				mismatch = reason
+				continue
 			}
+			matcher = &candidates[i]
+			break
+		}
+		if matcher == nil {
+			http.Error(w, mismatch, http.StatusBadRequest)
+			return
 		}
 
 		responseBody, err := json.Marshal(matcher.Response)
@@ -209,6 +213,23 @@ type gqlRequest struct {
 	Variables map[string]any `json:"variables,omitempty"`
 }
 
+// variablesMismatch returns a description of why the request variables don't match the expected ones,
+// or an empty string if they match.
+func variablesMismatch(expected map[string]any, actual map[string]any) string {
+	if len(actual) == 0 {
+		return ""
+	}
+	if len(actual) != len(expected) {
+		return "variables do not have the same length"
+	}
+	for k, v := range expected {
+		if !objectsAreEqualValues(v, actual[k]) {
+			return "variable does not match"
+		}
+	}
+	return ""
+}
+
 func parseBody(r io.Reader) (gqlRequest, error) {
 	var req gqlRequest
 	err := json.NewDecoder(r).Decode(&req)
@@ -0,0 +1,54 @@
+package github
+
+// Batch item statuses.
+const (
+	batchStatusOK    = "ok"
+	batchStatusError = "error"
+)
+
+// Batch item error codes, so clients can tell failures apart without parsing messages.
+const (
+	batchCodeNotFound = "NOT_FOUND"
+	batchCodeFailed   = "FAILED"
+)
+
+// batchItemResult is the result of one item of a batch tool call. Every item has the same shape
+// whether it succeeded or not, so a partial failure can be handled programmatically: code and
+// message are null for successes, and data is null for failures.
+type batchItemResult struct {
+	Number  int     `json:"number"`
+	Status  string  `json:"status"`
+	Code    *string `json:"code"`
+	Message *string `json:"message"`
+	Data    any     `json:"data"`
+}
+
+// batchItemOK returns the result of an item that succeeded.
+func batchItemOK(number int, data any) batchItemResult {
+	return batchItemResult{Number: number, Status: batchStatusOK, Data: data}
+}
+
+// batchItemError returns the result of an item that failed, classifying the error into a code.
+func batchItemError(number int, err error) batchItemResult {
+	code := batchCodeFailed
+	if classifyGraphQLError(err) == graphQLErrorNotFound {
+		code = batchCodeNotFound
+	}
+	message := err.Error()
+	return batchItemResult{Number: number, Status: batchStatusError, Code: &code, Message: &message}
+}
+
+// batchResponse builds the response of a batch tool call from its item results.
+func batchResponse(results []batchItemResult) map[string]any {
+	failed := 0
+	for _, r := range results {
+		if r.Status == batchStatusError {
+			failed++
+		}
+	}
+	return map[string]any{
+		"results":   results,
+		"succeeded": len(results) - failed,
+		"failed":    failed,
+	}
+}
@@ -76,6 +76,25 @@ type ToolDependencies interface {
 
 	// GetContentWindowSize returns the content window size for log truncation
 	GetContentWindowSize() int
+
+	// GetSelectionBudget returns the maximum estimated number of nodes a tool call may select
+	// when combining expensive options, or 0 for the default
+	GetSelectionBudget() int
+
+	// GetMutationAttribution returns the source label appended to bodies written by discussion
+	// mutations, or an empty string for none
+	GetMutationAttribution() string
+
+	// GetDiscussionCategoryCache returns the cache used to resolve discussion category names, or nil
+	// to look them up on every call
+	GetDiscussionCategoryCache() *DiscussionCategoryCache
+
+	// GetDefaultPageSize returns the page size GraphQL list tools use when none is requested, or 0
+	// for DefaultGraphQLPageSize
+	GetDefaultPageSize() int
+
+	// GetGraphQLRetryPolicy returns how discussion tools retry GraphQL queries that fail transiently
+	GetGraphQLRetryPolicy() GraphQLRetryPolicy
 }
 
 // BaseDeps is the standard implementation of ToolDependencies for the local server.
@@ -88,10 +107,25 @@ type BaseDeps struct {
 	RawClient *raw.Client
 
 	// Static dependencies
-	RepoAccessCache   *lockdown.RepoAccessCache
-	T                 translations.TranslationHelperFunc
-	Flags             FeatureFlags
-	ContentWindowSize int
+	RepoAccessCache         *lockdown.RepoAccessCache
+	T                       translations.TranslationHelperFunc
+	Flags                   FeatureFlags
+	ContentWindowSize       int
+	SelectionBudget         int
+	MutationAttribution     string
+	DiscussionCategoryCache *DiscussionCategoryCache
+	DefaultPageSize         int
+	GraphQLRetryPolicy      GraphQLRetryPolicy
+}
+
+// DiscussionOptions groups the settings the discussion tools read from ToolDependencies. The zero
+// value uses the defaults for each of them.
+type DiscussionOptions struct {
+	SelectionBudget         int
+	MutationAttribution     string
+	DiscussionCategoryCache *DiscussionCategoryCache
+	DefaultPageSize         int
+	GraphQLRetryPolicy      GraphQLRetryPolicy
 }
 
 // NewBaseDeps creates a BaseDeps with the provided clients and configuration.
@@ -103,15 +137,21 @@ func NewBaseDeps(
 	t translations.TranslationHelperFunc,
 	flags FeatureFlags,
 	contentWindowSize int,
+	discussionOpts DiscussionOptions,
 ) *BaseDeps {
 	return &BaseDeps{
-		Client:            client,
-		GQLClient:         gqlClient,
-		RawClient:         rawClient,
-		RepoAccessCache:   repoAccessCache,
-		T:                 t,
-		Flags:             flags,
-		ContentWindowSize: contentWindowSize,
+		Client:                  client,
+		GQLClient:               gqlClient,
+		RawClient:               rawClient,
+		RepoAccessCache:         repoAccessCache,
+		T:                       t,
+		Flags:                   flags,
+		ContentWindowSize:       contentWindowSize,
+		SelectionBudget:         discussionOpts.SelectionBudget,
+		MutationAttribution:     discussionOpts.MutationAttribution,
+		DiscussionCategoryCache: discussionOpts.DiscussionCategoryCache,
+		DefaultPageSize:         discussionOpts.DefaultPageSize,
+		GraphQLRetryPolicy:      discussionOpts.GraphQLRetryPolicy,
 	}
 }
 
@@ -142,6 +182,23 @@ func (d BaseDeps) GetFlags() FeatureFlags { return d.Flags }
 // GetContentWindowSize implements ToolDependencies.
 func (d BaseDeps) GetContentWindowSize() int { return d.ContentWindowSize }
 
+// GetSelectionBudget implements ToolDependencies.
+func (d BaseDeps) GetSelectionBudget() int { return d.SelectionBudget }
+
+// GetMutationAttribution implements ToolDependencies.
+func (d BaseDeps) GetMutationAttribution() string { return d.MutationAttribution }
+
+// GetDiscussionCategoryCache implements ToolDependencies.
+func (d BaseDeps) GetDiscussionCategoryCache() *DiscussionCategoryCache {
+	return d.DiscussionCategoryCache
+}
+
+// GetDefaultPageSize implements ToolDependencies.
+func (d BaseDeps) GetDefaultPageSize() int { return d.DefaultPageSize }
+
+// GetGraphQLRetryPolicy implements ToolDependencies.
+func (d BaseDeps) GetGraphQLRetryPolicy() GraphQLRetryPolicy { return d.GraphQLRetryPolicy }
+
 // NewTool creates a ServerTool that retrieves ToolDependencies from context at call time.
 // This avoids creating closures at registration time, which is important for performance
 // in servers that create a new server instance per request (like the remote server).
@@ -0,0 +1,107 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// DefaultDiscussionCategoryCacheTTL is how long a repository's discussion categories are reused
+// when resolving category names. It is short, as categories can be renamed at any time.
+const DefaultDiscussionCategoryCacheTTL = 60 * time.Second
+
+// discussionCategoryRef is a discussion category as needed to resolve a name to its ID, and to
+// know whether discussions created in it can have an answer marked.
+type discussionCategoryRef struct {
+	ID           githubv4.ID
+	Name         githubv4.String
+	IsAnswerable githubv4.Boolean
+}
+
+type discussionCategoryCacheEntry struct {
+	categories []discussionCategoryRef
+	expiresAt  time.Time
+}
+
+// DiscussionCategoryCache holds the discussion categories of repositories for a short time, so that
+// tools called repeatedly with a category name don't refetch them on every call. It is safe for
+// concurrent use. A nil cache fetches the categories every time.
+type DiscussionCategoryCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]discussionCategoryCacheEntry
+}
+
+// NewDiscussionCategoryCache returns a cache that keeps a repository's categories for ttl.
+func NewDiscussionCategoryCache(ttl time.Duration) *DiscussionCategoryCache {
+	return &DiscussionCategoryCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]discussionCategoryCacheEntry{},
+	}
+}
+
+func discussionCategoryCacheKey(owner, repo string) string {
+	return strings.ToLower(owner + "/" + repo)
+}
+
+// Invalidate drops the cached categories of owner/repo, so the next lookup refetches them.
+func (c *DiscussionCategoryCache) Invalidate(owner, repo string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, discussionCategoryCacheKey(owner, repo))
+}
+
+// categories returns the discussion categories of owner/repo, from the cache while they are fresh.
+// It also reports whether they came from the cache. Only successful fetches are cached.
+func (c *DiscussionCategoryCache) categories(ctx context.Context, client *githubv4.Client, owner, repo string) ([]discussionCategoryRef, bool, error) {
+	if c == nil {
+		categories, err := fetchDiscussionCategoryRefs(ctx, client, owner, repo)
+		return categories, false, err
+	}
+
+	key := discussionCategoryCacheKey(owner, repo)
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expiresAt) {
+		return entry.categories, true, nil
+	}
+
+	// The lock isn't held while fetching, so concurrent misses may both fetch; the last one wins
+	categories, err := fetchDiscussionCategoryRefs(ctx, client, owner, repo)
+	if err != nil {
+		return nil, false, err
+	}
+	c.mu.Lock()
+	c.entries[key] = discussionCategoryCacheEntry{categories: categories, expiresAt: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+	return categories, false, nil
+}
+
+func fetchDiscussionCategoryRefs(ctx context.Context, client *githubv4.Client, owner, repo string) ([]discussionCategoryRef, error) {
+	var q struct {
+		Repository struct {
+			DiscussionCategories struct {
+				Nodes []discussionCategoryRef
+			} `graphql:"discussionCategories(first: $first)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+		"first": githubv4.Int(100),
+	}
+	if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+		return nil, fmt.Errorf("failed to list discussion categories: %w", err)
+	}
+	return q.Repository.DiscussionCategories.Nodes, nil
+}
@@ -0,0 +1,124 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingTransport counts the requests made through it.
+type countingTransport struct {
+	transport http.RoundTripper
+	count     atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.count.Add(1)
+	return t.transport.RoundTrip(req)
+}
+
+func Test_DiscussionCategoryCache(t *testing.T) {
+	qCategories := "query($first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussionCategories(first: $first){nodes{id,name,isAnswerable}}}}"
+	newClient := func() (*githubv4.Client, *countingTransport) {
+		mockClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(qCategories,
+				map[string]any{"owner": "owner", "repo": "repo", "first": float64(100)},
+				githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"discussionCategories": map[string]any{
+							"nodes": []map[string]any{
+								{"id": "DIC_GENERAL", "name": "General"},
+								{"id": "DIC_QUESTIONS", "name": "Questions", "isAnswerable": true},
+							},
+						},
+					},
+				}),
+			),
+		)
+		transport := &countingTransport{transport: mockClient.Transport}
+		return githubv4.NewClient(&http.Client{Transport: transport}), transport
+	}
+	resolve := func(t *testing.T, cache *DiscussionCategoryCache, client *githubv4.Client, name string) githubv4.ID {
+		id, err := resolveDiscussionCategoryID(context.Background(), cache, client, "owner", "repo", "", name)
+		require.NoError(t, err)
+		return *id
+	}
+
+	t.Run("second resolution within the TTL makes no request", func(t *testing.T) {
+		client, transport := newClient()
+		cache := NewDiscussionCategoryCache(time.Minute)
+		assert.Equal(t, githubv4.ID("DIC_GENERAL"), resolve(t, cache, client, "General"))
+		assert.Equal(t, githubv4.ID("DIC_QUESTIONS"), resolve(t, cache, client, "questions"))
+		assert.Equal(t, int32(1), transport.count.Load())
+	})
+
+	t.Run("resolves whether the category is answerable", func(t *testing.T) {
+		client, _ := newClient()
+		category, err := resolveDiscussionCategory(context.Background(), nil, client, "owner", "repo", "", "Questions")
+		require.NoError(t, err)
+		assert.Equal(t, githubv4.ID("DIC_QUESTIONS"), category.ID)
+		assert.True(t, bool(category.IsAnswerable))
+	})
+
+	t.Run("refetches after the TTL", func(t *testing.T) {
+		client, transport := newClient()
+		cache := NewDiscussionCategoryCache(time.Minute)
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+		resolve(t, cache, client, "General")
+		now = now.Add(time.Minute)
+		resolve(t, cache, client, "General")
+		assert.Equal(t, int32(2), transport.count.Load())
+	})
+
+	t.Run("refetches after Invalidate", func(t *testing.T) {
+		client, transport := newClient()
+		cache := NewDiscussionCategoryCache(time.Minute)
+		resolve(t, cache, client, "General")
+		cache.Invalidate("Owner", "Repo")
+		resolve(t, cache, client, "General")
+		assert.Equal(t, int32(2), transport.count.Load())
+	})
+
+	t.Run("refetches once for a name missing from the cache", func(t *testing.T) {
+		client, transport := newClient()
+		cache := NewDiscussionCategoryCache(time.Minute)
+		resolve(t, cache, client, "General")
+		_, err := resolveDiscussionCategoryID(context.Background(), cache, client, "owner", "repo", "", "Ideas")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `discussion category "Ideas" not found`)
+		assert.Equal(t, int32(2), transport.count.Load())
+	})
+
+	t.Run("nil cache fetches every time", func(t *testing.T) {
+		client, transport := newClient()
+		resolve(t, nil, client, "General")
+		resolve(t, nil, client, "General")
+		assert.Equal(t, int32(2), transport.count.Load())
+	})
+
+	t.Run("concurrent resolutions", func(t *testing.T) {
+		client, _ := newClient()
+		cache := NewDiscussionCategoryCache(time.Minute)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				id, err := resolveDiscussionCategoryID(context.Background(), cache, client, "owner", "repo", "", "General")
+				if assert.NoError(t, err) {
+					assert.Equal(t, githubv4.ID("DIC_GENERAL"), *id)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,84 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/octicons"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yosida95/uritemplate/v3"
+)
+
+var discussionResourceBodyURITemplate = uritemplate.MustNew("discussion://{owner}/{repo}/{discussionNumber}/body")
+
+// GetDiscussionResourceBody defines the resource template for getting the Markdown body of a discussion.
+func GetDiscussionResourceBody(t translations.TranslationHelperFunc) inventory.ServerResourceTemplate {
+	return inventory.NewServerResourceTemplate(
+		ToolsetMetadataDiscussions,
+		mcp.ResourceTemplate{
+			Name:        "discussion_body",
+			URITemplate: discussionResourceBodyURITemplate.Raw(),
+			Description: t("RESOURCE_DISCUSSION_BODY_DESCRIPTION", "Discussion body"),
+			MIMEType:    "text/markdown",
+			Icons:       octicons.Icons("comment-discussion"),
+		},
+		func(deps any) mcp.ResourceHandler {
+			return DiscussionResourceBodyHandler(deps.(ToolDependencies))
+		},
+	)
+}
+
+// discussionResourceBodyURI returns the discussion_body resource URI for a discussion.
+func discussionResourceBodyURI(owner, repo string, discussionNumber int32) (string, error) {
+	return discussionResourceBodyURITemplate.Expand(uritemplate.Values{
+		"owner":            uritemplate.String(owner),
+		"repo":             uritemplate.String(repo),
+		"discussionNumber": uritemplate.String(strconv.Itoa(int(discussionNumber))),
+	})
+}
+
+// DiscussionResourceBodyHandler returns a handler function for discussion body requests.
+func DiscussionResourceBodyHandler(deps ToolDependencies) mcp.ResourceHandler {
+	return func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uriValues := discussionResourceBodyURITemplate.Match(request.Params.URI)
+		if uriValues == nil {
+			return nil, fmt.Errorf("failed to match URI: %s", request.Params.URI)
+		}
+
+		owner := uriValues.Get("owner").String()
+		repo := uriValues.Get("repo").String()
+		if owner == "" {
+			return nil, errors.New("owner is required")
+		}
+		if repo == "" {
+			return nil, errors.New("repo is required")
+		}
+		discussionNumber, err := strconv.ParseInt(uriValues.Get("discussionNumber").String(), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid discussion number: %w", err)
+		}
+
+		client, err := deps.GetGQLClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+		}
+		_, body, err := getDiscussionIDAndBody(ctx, client, owner, repo, int32(discussionNumber))
+		if err != nil {
+			return nil, err
+		}
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{
+				{
+					URI:      request.Params.URI,
+					MIMEType: "text/markdown",
+					Text:     body,
+				},
+			},
+		}, nil
+	}
+}
@@ -2,9 +2,18 @@ package github
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/github/github-mcp-server/pkg/inventory"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -18,9 +27,30 @@ import (
 
 const DefaultGraphQLPageSize = 30
 
+// maxGraphQLPageSize is the largest page GitHub's GraphQL API returns.
+const maxGraphQLPageSize = 100
+
+// defaultGraphQLPageSize returns the page size to use when perPage isn't provided: the configured
+// default, capped at maxGraphQLPageSize, or DefaultGraphQLPageSize when none is configured.
+func defaultGraphQLPageSize(deps ToolDependencies) int {
+	size := deps.GetDefaultPageSize()
+	if size <= 0 {
+		return DefaultGraphQLPageSize
+	}
+	return min(size, maxGraphQLPageSize)
+}
+
+// Tools that aggregate across many discussions scan at most discussionScanMaxPages pages
+// of discussionScanPageSize nodes, and report truncation when the limit is hit.
+const (
+	discussionScanPageSize = 100
+	discussionScanMaxPages = 10
+)
+
 // Common interface for all discussion query types
 type DiscussionQueryResult interface {
 	GetDiscussionFragment() DiscussionFragment
+	GetRateLimitCost() int
 }
 
 // Implement the interface for all query types
@@ -28,39 +58,137 @@ func (q *BasicNoOrder) GetDiscussionFragment() DiscussionFragment {
 	return q.Repository.Discussions
 }
 
+func (q *BasicNoOrder) GetRateLimitCost() int {
+	return int(q.RateLimit.Cost)
+}
+
 func (q *BasicWithOrder) GetDiscussionFragment() DiscussionFragment {
 	return q.Repository.Discussions
 }
 
+func (q *BasicWithOrder) GetRateLimitCost() int {
+	return int(q.RateLimit.Cost)
+}
+
 func (q *WithCategoryAndOrder) GetDiscussionFragment() DiscussionFragment {
 	return q.Repository.Discussions
 }
 
+func (q *WithCategoryAndOrder) GetRateLimitCost() int {
+	return int(q.RateLimit.Cost)
+}
+
 func (q *WithCategoryNoOrder) GetDiscussionFragment() DiscussionFragment {
 	return q.Repository.Discussions
 }
 
+func (q *WithCategoryNoOrder) GetRateLimitCost() int {
+	return int(q.RateLimit.Cost)
+}
+
+func (q *BasicNoOrderBackward) GetDiscussionFragment() DiscussionFragment {
+	return q.Repository.Discussions
+}
+
+func (q *BasicNoOrderBackward) GetRateLimitCost() int {
+	return int(q.RateLimit.Cost)
+}
+
+func (q *BasicWithOrderBackward) GetDiscussionFragment() DiscussionFragment {
+	return q.Repository.Discussions
+}
+
+func (q *BasicWithOrderBackward) GetRateLimitCost() int {
+	return int(q.RateLimit.Cost)
+}
+
+func (q *WithCategoryAndOrderBackward) GetDiscussionFragment() DiscussionFragment {
+	return q.Repository.Discussions
+}
+
+func (q *WithCategoryAndOrderBackward) GetRateLimitCost() int {
+	return int(q.RateLimit.Cost)
+}
+
+func (q *WithCategoryNoOrderBackward) GetDiscussionFragment() DiscussionFragment {
+	return q.Repository.Discussions
+}
+
+func (q *WithCategoryNoOrderBackward) GetRateLimitCost() int {
+	return int(q.RateLimit.Cost)
+}
+
 type DiscussionFragment struct {
 	Nodes      []NodeFragment
 	PageInfo   PageInfoFragment
 	TotalCount githubv4.Int
 }
 
+// NodeFragment is a listed discussion. Queries selecting it need an $includeBody variable, so that
+// bodies are only fetched when they are returned.
 type NodeFragment struct {
 	Number         githubv4.Int
 	Title          githubv4.String
+	Body           githubv4.String `graphql:"body @include(if: $includeBody)"`
 	CreatedAt      githubv4.DateTime
 	UpdatedAt      githubv4.DateTime
 	Closed         githubv4.Boolean
+	ClosedAt       *githubv4.DateTime
 	IsAnswered     githubv4.Boolean
 	AnswerChosenAt *githubv4.DateTime
 	Author         struct {
 		Login githubv4.String
 	}
-	Category struct {
+	// Category is nil when the discussion's category has been deleted.
+	Category *struct {
 		Name githubv4.String
 	} `graphql:"category"`
-	URL githubv4.String `graphql:"url"`
+	URL       githubv4.String `graphql:"url"`
+	Reactions struct {
+		TotalCount githubv4.Int
+	} `graphql:"reactions"`
+	Comments struct {
+		TotalCount githubv4.Int
+	} `graphql:"comments"`
+	UpvoteCount githubv4.Int
+	Labels      discussionLabelsFragment `graphql:"labels(first: 10)"`
+}
+
+// discussionLabelsFragment selects the first labels of a discussion, for list_discussions and
+// get_discussion.
+type discussionLabelsFragment struct {
+	Nodes []struct {
+		Name  githubv4.String
+		Color githubv4.String
+	}
+}
+
+type discussionLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func (f discussionLabelsFragment) toLabels() []discussionLabel {
+	labels := make([]discussionLabel, 0, len(f.Nodes))
+	for _, l := range f.Nodes {
+		labels = append(labels, discussionLabel{Name: string(l.Name), Color: string(l.Color)})
+	}
+	return labels
+}
+
+// hasAllLabels reports whether labels includes every name in names, ignoring case.
+func hasAllLabels(labels []discussionLabel, names []string) bool {
+	for _, name := range names {
+		if !slices.ContainsFunc(labels, func(l discussionLabel) bool { return strings.EqualFold(l.Name, name) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// rateLimitCost selects the rate limit cost of a query, for tools that budget it across pages.
+type rateLimitCost struct {
+	Cost githubv4.Int
 }
 
 type PageInfoFragment struct {
@@ -74,28 +202,62 @@ type BasicNoOrder struct {
 	Repository struct {
 		Discussions DiscussionFragment `graphql:"discussions(first: $first, after: $after)"`
 	} `graphql:"repository(owner: $owner, name: $repo)"`
+	RateLimit rateLimitCost
 }
 
 type BasicWithOrder struct {
 	Repository struct {
 		Discussions DiscussionFragment `graphql:"discussions(first: $first, after: $after, orderBy: { field: $orderByField, direction: $orderByDirection })"`
 	} `graphql:"repository(owner: $owner, name: $repo)"`
+	RateLimit rateLimitCost
 }
 
 type WithCategoryAndOrder struct {
 	Repository struct {
 		Discussions DiscussionFragment `graphql:"discussions(first: $first, after: $after, categoryId: $categoryId, orderBy: { field: $orderByField, direction: $orderByDirection })"`
 	} `graphql:"repository(owner: $owner, name: $repo)"`
+	RateLimit rateLimitCost
 }
 
 type WithCategoryNoOrder struct {
 	Repository struct {
 		Discussions DiscussionFragment `graphql:"discussions(first: $first, after: $after, categoryId: $categoryId)"`
 	} `graphql:"repository(owner: $owner, name: $repo)"`
+	RateLimit rateLimitCost
+}
+
+// The Backward variants page from the end of the list, with last and before.
+
+type BasicNoOrderBackward struct {
+	Repository struct {
+		Discussions DiscussionFragment `graphql:"discussions(last: $last, before: $before)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+	RateLimit rateLimitCost
+}
+
+type BasicWithOrderBackward struct {
+	Repository struct {
+		Discussions DiscussionFragment `graphql:"discussions(last: $last, before: $before, orderBy: { field: $orderByField, direction: $orderByDirection })"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+	RateLimit rateLimitCost
+}
+
+type WithCategoryAndOrderBackward struct {
+	Repository struct {
+		Discussions DiscussionFragment `graphql:"discussions(last: $last, before: $before, categoryId: $categoryId, orderBy: { field: $orderByField, direction: $orderByDirection })"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+	RateLimit rateLimitCost
+}
+
+type WithCategoryNoOrderBackward struct {
+	Repository struct {
+		Discussions DiscussionFragment `graphql:"discussions(last: $last, before: $before, categoryId: $categoryId)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+	RateLimit rateLimitCost
 }
 
 func fragmentToDiscussion(fragment NodeFragment) *github.Discussion {
-	return &github.Discussion{
+	discussion := &github.Discussion{
 		Number:    github.Ptr(int(fragment.Number)),
 		Title:     github.Ptr(string(fragment.Title)),
 		HTMLURL:   github.Ptr(string(fragment.URL)),
@@ -104,13 +266,215 @@ func fragmentToDiscussion(fragment NodeFragment) *github.Discussion {
 		User: &github.User{
 			Login: github.Ptr(string(fragment.Author.Login)),
 		},
-		DiscussionCategory: &github.DiscussionCategory{
+	}
+	if fragment.Category != nil {
+		discussion.DiscussionCategory = &github.DiscussionCategory{
 			Name: github.Ptr(string(fragment.Category.Name)),
-		},
+		}
+	}
+	return discussion
+}
+
+// listedDiscussion is a list_discussions row: go-github's Discussion plus fields that type doesn't model.
+type listedDiscussion struct {
+	*github.Discussion
+	// Category is used instead of go-github's category, which is omitted when empty, so that a deleted
+	// category is reported as null like in get_discussion.
+	Category *github.DiscussionCategory `json:"category"`
+	// AnswerChosenAt is used instead of go-github's answer_chosen_at, so that it is named as in get_discussion.
+	AnswerChosenAt *time.Time `json:"answerChosenAt,omitempty"`
+	// ClosedAt is only set for closed discussions.
+	ClosedAt *time.Time `json:"closedAt,omitempty"`
+	// BodyTruncated is only set when a body was requested and cut to bodyMaxLength.
+	BodyTruncated    *bool             `json:"bodyTruncated,omitempty"`
+	ReactionCount    int               `json:"reactionCount"`
+	CommentCount     int               `json:"commentCount"`
+	UpvoteCount      int               `json:"upvoteCount"`
+	Labels           []discussionLabel `json:"labels"`
+	ParticipantCount *int              `json:"participantCount,omitempty"`
+	// AnswerLatencySeconds is only set when requested, and then points to nil (null) for unanswered discussions.
+	AnswerLatencySeconds **int64 `json:"answerLatencySeconds,omitempty"`
+	// IsPinned is only set when requested, as the discussions connection doesn't expose it.
+	IsPinned *bool `json:"isPinned,omitempty"`
+}
+
+func fragmentToListedDiscussion(fragment NodeFragment) *listedDiscussion {
+	discussion := fragmentToDiscussion(fragment)
+	return &listedDiscussion{
+		Discussion:     discussion,
+		Category:       discussion.DiscussionCategory,
+		AnswerChosenAt: optionalDateTime(fragment.AnswerChosenAt),
+		ClosedAt:       optionalDateTime(fragment.ClosedAt),
+		ReactionCount:  int(fragment.Reactions.TotalCount),
+		CommentCount:   int(fragment.Comments.TotalCount),
+		UpvoteCount:    int(fragment.UpvoteCount),
+		Labels:         fragment.Labels.toLabels(),
+	}
+}
+
+// listedDiscussionSortKeys are the counts list_discussions can sort by client-side, with sortBy.
+var listedDiscussionSortKeys = map[string]func(*listedDiscussion) int{
+	"COMMENTS":  func(d *listedDiscussion) int { return d.CommentCount },
+	"UPVOTES":   func(d *listedDiscussion) int { return d.UpvoteCount },
+	"REACTIONS": func(d *listedDiscussion) int { return d.ReactionCount },
+}
+
+// Formats accepted by the "format" parameter of list_discussions and get_discussion.
+const (
+	discussionFormatJSON     = "json"
+	discussionFormatMarkdown = "markdown"
+)
+
+func discussionFormatSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Description: "Response format: 'json' (default) returns the discussion data as JSON, 'markdown' returns a compact readable summary of the title, author, category, url and body (when one is returned) instead.",
+		Enum:        []any{discussionFormatJSON, discussionFormatMarkdown},
+	}
+}
+
+// optionalDiscussionFormat returns the "format" parameter, defaulting to discussionFormatJSON.
+func optionalDiscussionFormat(args map[string]any) (string, error) {
+	format, err := OptionalParam[string](args, "format")
+	if err != nil {
+		return "", err
+	}
+	switch format {
+	case "":
+		return discussionFormatJSON, nil
+	case discussionFormatJSON, discussionFormatMarkdown:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid format %q: must be one of json, markdown", format)
+	}
+}
+
+// discussionSummary is what format markdown renders of a discussion. Empty fields are left out.
+type discussionSummary struct {
+	Number   int
+	Title    string
+	Author   string
+	Category string
+	URL      string
+	// Body is nil when it wasn't fetched.
+	Body *string
+}
+
+func (d discussionSummary) writeMarkdown(b *strings.Builder, heading string) {
+	fmt.Fprintf(b, "%s #%d %s\n\n", heading, d.Number, d.Title)
+	if d.Author != "" {
+		fmt.Fprintf(b, "- Author: @%s\n", d.Author)
+	}
+	if d.Category != "" {
+		fmt.Fprintf(b, "- Category: %s\n", d.Category)
+	}
+	fmt.Fprintf(b, "- URL: %s\n", d.URL)
+	if d.Body != nil && *d.Body != "" {
+		fmt.Fprintf(b, "\n%s\n", *d.Body)
+	}
+}
+
+// listedDiscussionsMarkdown renders a list_discussions page for format markdown, ending with how
+// to fetch the next page when there is one.
+func listedDiscussionsMarkdown(discussions []*listedDiscussion, nextPage string) string {
+	var b strings.Builder
+	if len(discussions) == 0 {
+		b.WriteString("No discussions found.\n")
+	}
+	for i, d := range discussions {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		discussionSummary{
+			Number:   d.GetNumber(),
+			Title:    d.GetTitle(),
+			Author:   d.GetUser().GetLogin(),
+			Category: d.GetDiscussionCategory().GetName(),
+			URL:      d.GetHTMLURL(),
+			Body:     d.Body,
+		}.writeMarkdown(&b, "##")
 	}
+	if nextPage != "" {
+		fmt.Fprintf(&b, "\nMore discussions: %s\n", nextPage)
+	}
+	return b.String()
+}
+
+// truncateRunes cuts s to at most maxLength characters, reporting whether it did. A maxLength of
+// 0 leaves s unchanged.
+func truncateRunes(s string, maxLength int) (string, bool) {
+	if maxLength == 0 || utf8.RuneCountInString(s) <= maxLength {
+		return s, false
+	}
+	return string([]rune(s)[:maxLength]), true
+}
+
+// optionalDateTime converts a nullable timestamp, such as a discussion's answerChosenAt or closedAt,
+// for output, so list_discussions and get_discussion report it the same way.
+func optionalDateTime(t *githubv4.DateTime) *time.Time {
+	if t == nil {
+		return nil
+	}
+	return &t.Time
+}
+
+// attributeMutationBody appends the configured attribution to a body written by a mutation, as an
+// HTML comment so it is kept in the audit trail without being rendered. An empty attribution
+// leaves the body unchanged.
+func attributeMutationBody(body, attribution string) string {
+	if attribution == "" {
+		return body
+	}
+	// Keep the label from closing the comment early
+	attribution = strings.NewReplacer("<", "", ">", "").Replace(attribution)
+	for strings.Contains(attribution, "--") {
+		attribution = strings.ReplaceAll(attribution, "--", "-")
+	}
+	return body + "\n\n<!-- source: " + attribution + " -->"
+}
+
+// defaultSelectionBudget is the selection budget used when none is configured. It allows any single
+// expensive option, such as fetchAll, but not stacking them.
+const defaultSelectionBudget = 1500
+
+// selectionCost is the estimated number of nodes an option adds to a request. An empty option
+// is the base cost of the request, which can't be dropped.
+type selectionCost struct {
+	option string
+	nodes  int
+}
+
+// checkSelectionBudget refuses a request whose estimated node count exceeds the budget
+// (the default when budget is 0), suggesting the most expensive option to drop.
+func checkSelectionBudget(budget int, costs ...selectionCost) error {
+	if budget <= 0 {
+		budget = defaultSelectionBudget
+	}
+	total := 0
+	var mostExpensive selectionCost
+	for _, c := range costs {
+		total += c.nodes
+		if c.option != "" && c.nodes >= mostExpensive.nodes {
+			mostExpensive = c
+		}
+	}
+	if total <= budget {
+		return nil
+	}
+	if mostExpensive.option == "" {
+		return fmt.Errorf("request would select about %d nodes, over the budget of %d; request fewer results", total, budget)
+	}
+	return fmt.Errorf("request would select about %d nodes, over the budget of %d; drop %s or request fewer results", total, budget, mostExpensive.option)
 }
 
-func getQueryType(useOrdering bool, categoryID *githubv4.ID) any {
+// maxParticipantCountDiscussions bounds how many listed discussions get a participant count,
+// since each one costs an extra query.
+const maxParticipantCountDiscussions = 10
+
+func getQueryType(useOrdering bool, categoryID *githubv4.ID, backward bool) any {
+	if backward {
+		return getBackwardQueryType(useOrdering, categoryID)
+	}
 	if categoryID != nil && useOrdering {
 		return &WithCategoryAndOrder{}
 	}
@@ -123,6 +487,60 @@ func getQueryType(useOrdering bool, categoryID *githubv4.ID) any {
 	return &BasicNoOrder{}
 }
 
+func getBackwardQueryType(useOrdering bool, categoryID *githubv4.ID) any {
+	if categoryID != nil && useOrdering {
+		return &WithCategoryAndOrderBackward{}
+	}
+	if categoryID != nil {
+		return &WithCategoryNoOrderBackward{}
+	}
+	if useOrdering {
+		return &BasicWithOrderBackward{}
+	}
+	return &BasicNoOrderBackward{}
+}
+
+// orgDiscussionsRepo is the repository organisation-level discussions are read from when no repo
+// is given. Organisations can keep their discussions in another repository, so falling back to it
+// is reported in the response.
+const orgDiscussionsRepo = ".github"
+
+// discussionLevelSchema is the level parameter of the tools that list a repository's or an
+// organisation's discussions.
+func discussionLevelSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Description: "Whether to read a repository's discussions, which requires repo, or an organisation's. Organisation discussions are read from repo, the repository the organisation keeps its discussions in, or from the .github repository when repo is not provided.",
+		Enum:        []any{"repository", "organization"},
+	}
+}
+
+// resolveDiscussionsRepo returns the repository to read discussions from for the level and repo
+// arguments, and a warning when it falls back to the .github repository.
+func resolveDiscussionsRepo(args map[string]any) (string, string, error) {
+	repo, err := OptionalParam[string](args, "repo")
+	if err != nil {
+		return "", "", err
+	}
+	level, err := OptionalParam[string](args, "level")
+	if err != nil {
+		return "", "", err
+	}
+	switch level {
+	case "", "organization":
+	case "repository":
+		if repo == "" {
+			return "", "", fmt.Errorf("repo is required when level is repository")
+		}
+	default:
+		return "", "", fmt.Errorf("invalid level %q: must be one of repository, organization", level)
+	}
+	if repo != "" {
+		return repo, "", nil
+	}
+	return orgDiscussionsRepo, fmt.Sprintf("repo was not provided, so the organisation's discussions were read from its %s repository; pass repo if the organisation keeps its discussions in another repository", orgDiscussionsRepo), nil
+}
+
 func ListDiscussions(t translations.TranslationHelperFunc) inventory.ServerTool {
 	return NewTool(
 		ToolsetMetadataDiscussions,
@@ -133,7 +551,7 @@ func ListDiscussions(t translations.TranslationHelperFunc) inventory.ServerTool
 				Title:        t("TOOL_LIST_DISCUSSIONS_USER_TITLE", "List discussions"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithCursorPagination(&jsonschema.Schema{
+			InputSchema: WithOutputFormat(WithPageTokens(WithBackwardCursorPagination(WithCursorPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -144,44 +562,123 @@ func ListDiscussions(t translations.TranslationHelperFunc) inventory.ServerTool
 						Type:        "string",
 						Description: "Repository name. If not provided, discussions will be queried at the organisation level.",
 					},
+					"level": discussionLevelSchema(),
 					"category": {
 						Type:        "string",
 						Description: "Optional filter by discussion category ID. If provided, only discussions with this category are listed.",
 					},
+					"categoryName": {
+						Type:        "string",
+						Description: "Optional filter by discussion category name (case-insensitive), as an alternative to 'category'. Ignored if 'category' is provided.",
+					},
 					"orderBy": {
 						Type:        "string",
-						Description: "Order discussions by field. If provided, the 'direction' also needs to be provided.",
+						Description: "Order discussions by field on the server, which decides which discussions are on each page. If provided, the 'direction' also needs to be provided.",
 						Enum:        []any{"CREATED_AT", "UPDATED_AT"},
 					},
+					"sortBy": {
+						Type:        "string",
+						Description: "Sort the fetched discussions by count, highest first, after they are fetched. Unlike orderBy this only reorders the current page (or all discussions with fetchAll, before maxResults is applied), with ties kept in orderBy order.",
+						Enum:        []any{"COMMENTS", "UPVOTES", "REACTIONS"},
+					},
 					"direction": {
 						Type:        "string",
 						Description: "Order direction.",
 						Enum:        []any{"ASC", "DESC"},
 					},
+					"includeParticipants": {
+						Type:        "boolean",
+						Description: fmt.Sprintf("Include a participantCount (unique authors of the discussion and its first 100 comments) for each discussion. This costs one extra query per discussion and is only applied to the first %d discussions.", maxParticipantCountDiscussions),
+					},
+					"includeAnswerLatency": {
+						Type:        "boolean",
+						Description: "Include answerLatencySeconds, the time from a discussion's creation to its answer being chosen, for each discussion (null for unanswered discussions).",
+					},
+					"includePinned": {
+						Type:        "boolean",
+						Description: "Include isPinned for each discussion. This makes one extra query for the repository's pinned discussions.",
+					},
+					"includeBody": {
+						Type:        "boolean",
+						Description: "Include each discussion's body, to avoid a get_discussion call per discussion. Off by default, as bodies can make large listings much bigger.",
+					},
+					"bodyMaxLength": {
+						Type:        "number",
+						Description: "With includeBody, cut bodies longer than this many characters and set bodyTruncated on them. By default bodies are returned in full.",
+						Minimum:     jsonschema.Ptr(1.0),
+					},
+					"answered": {
+						Type:        "boolean",
+						Description: "Only return answered (true) or unanswered (false) discussions. The filter is applied to each fetched page, so a page can have fewer discussions than requested; filteredCount is the number returned, while totalCount and the pagination cursors still describe the unfiltered discussions.",
+					},
+					"state": {
+						Type:        "string",
+						Description: "Only return open or closed discussions (default all). Like answered, the filter is applied to each fetched page.",
+						Enum:        []any{"open", "closed", "all"},
+					},
+					"labels": {
+						Type:        "array",
+						Description: "Only return discussions with all of these labels (case-insensitive). Only the first 10 labels of each discussion are checked, and like answered the filter is applied to each fetched page, so a page can have fewer discussions than requested.",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+					"createdAfter": {
+						Type:        "string",
+						Description: "Only return discussions created at or after this RFC3339 timestamp. Like answered, it filters each fetched page, so filteredCount is the number returned while totalCount and the pagination cursors describe the unfiltered discussions; page on until hasNextPage is false, or use fetchAll.",
+					},
+					"createdBefore": {
+						Type:        "string",
+						Description: "Only return discussions created before this RFC3339 timestamp. Filters each fetched page like createdAfter.",
+					},
+					"groupByCategory": {
+						Type:        "boolean",
+						Description: "Return discussionsByCategory, a map of category name to the discussions in that category, instead of the discussions array. Only the fetched discussions are grouped, so a category can continue on the next page. Discussions whose category was deleted are grouped under an empty name.",
+					},
+					"fetchAll": {
+						Type:        "boolean",
+						Description: fmt.Sprintf("Page through all discussions instead of returning a single page, up to %d discussions. Discussions fetched twice, as edits can move them between pages, are returned once and counted in duplicatesSkipped. Cannot be combined with 'after'.", discussionScanPageSize*discussionScanMaxPages),
+					},
+					"maxCost": {
+						Type:        "number",
+						Description: "With fetchAll, stop paging once the GraphQL rate limit cost of the fetched pages reaches this budget. The response then has truncated set, and costUsed reports the cost spent.",
+						Minimum:     jsonschema.Ptr(1.0),
+					},
+					"maxResults": {
+						Type:        "number",
+						Description: "With fetchAll, stop paging once this many discussions have been collected. The response then has truncated set if more discussions remained. With sortBy, all discussions are fetched and sorted first, and the top maxResults of them are returned.",
+						Minimum:     jsonschema.Ptr(1.0),
+					},
+					"countOnly": {
+						Type:        "boolean",
+						Description: "Only return totalCount, the number of discussions (in the category, if one is given), without fetching any. Cannot be combined with answered, state, labels, createdAfter or createdBefore, which filter fetched discussions.",
+					},
+					"timeoutSeconds": queryTimeoutSchema(),
+					"format":         discussionFormatSchema(),
 				},
 				Required: []string{"owner"},
-			}),
+			})))),
 		},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
-			repo, err := OptionalParam[string](args, "repo")
+			repo, repoWarning, err := resolveDiscussionsRepo(args)
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
-			// when not provided, default to the .github repository
-			// this will query discussions at the organisation level
-			if repo == "" {
-				repo = ".github"
-			}
 
 			category, err := OptionalParam[string](args, "category")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
+			categoryName, err := OptionalParam[string](args, "categoryName")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
 			orderBy, err := OptionalParam[string](args, "orderBy")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -192,102 +689,4973 @@ func ListDiscussions(t translations.TranslationHelperFunc) inventory.ServerTool
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			// Get pagination parameters and convert to GraphQL format
-			pagination, err := OptionalCursorPaginationParams(args)
+			sortBy, err := OptionalParam[string](args, "sortBy")
 			if err != nil {
-				return nil, nil, err
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
-			paginationParams, err := pagination.ToGraphQLParams()
+			sortKey, ok := listedDiscussionSortKeys[sortBy]
+			if sortBy != "" && !ok {
+				return utils.NewToolResultError(fmt.Sprintf("invalid sortBy %q: must be one of COMMENTS, UPVOTES, REACTIONS", sortBy)), nil, nil
+			}
+
+			includeParticipants, err := OptionalParam[bool](args, "includeParticipants")
 			if err != nil {
-				return nil, nil, err
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			client, err := deps.GetGQLClient(ctx)
+			includeAnswerLatency, err := OptionalParam[bool](args, "includeAnswerLatency")
 			if err != nil {
-				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			var categoryID *githubv4.ID
-			if category != "" {
-				id := githubv4.ID(category)
-				categoryID = &id
+			includePinned, err := OptionalParam[bool](args, "includePinned")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			vars := map[string]interface{}{
-				"owner": githubv4.String(owner),
-				"repo":  githubv4.String(repo),
-				"first": githubv4.Int(*paginationParams.First),
+			includeBody, err := OptionalParam[bool](args, "includeBody")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
-			if paginationParams.After != nil {
-				vars["after"] = githubv4.String(*paginationParams.After)
-			} else {
-				vars["after"] = (*githubv4.String)(nil)
+
+			bodyMaxLength, err := OptionalIntParam(args, "bodyMaxLength")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if bodyMaxLength < 0 {
+				return utils.NewToolResultError("bodyMaxLength must be positive"), nil, nil
 			}
 
-			// this is an extra check in case the tool description is misinterpreted, because
-			// we shouldn't use ordering unless both a 'field' and 'direction' are provided
-			useOrdering := orderBy != "" && direction != ""
-			if useOrdering {
-				vars["orderByField"] = githubv4.DiscussionOrderField(orderBy)
-				vars["orderByDirection"] = githubv4.OrderDirection(direction)
+			answered, filterAnswered, err := OptionalParamOK[bool](args, "answered")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			if categoryID != nil {
-				vars["categoryId"] = *categoryID
+			state, err := OptionalParam[string](args, "state")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			switch state {
+			case "", "all", "open", "closed":
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("invalid state %q: must be one of open, closed, all", state)), nil, nil
 			}
+			filterState := state == "open" || state == "closed"
 
-			discussionQuery := getQueryType(useOrdering, categoryID)
-			if err := client.Query(ctx, discussionQuery, vars); err != nil {
+			createdAfter, err := optionalTimeParam(args, "createdAfter")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			createdBefore, err := optionalTimeParam(args, "createdBefore")
+			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
+			if !createdAfter.IsZero() && !createdBefore.IsZero() && !createdAfter.Before(createdBefore) {
+				return utils.NewToolResultError("createdAfter must be before createdBefore"), nil, nil
+			}
+			filterCreated := !createdAfter.IsZero() || !createdBefore.IsZero()
 
-			// Extract and convert all discussion nodes using the common interface
-			var discussions []*github.Discussion
-			var pageInfo PageInfoFragment
-			var totalCount githubv4.Int
-			if queryResult, ok := discussionQuery.(DiscussionQueryResult); ok {
-				fragment := queryResult.GetDiscussionFragment()
-				for _, node := range fragment.Nodes {
-					discussions = append(discussions, fragmentToDiscussion(node))
-				}
-				pageInfo = fragment.PageInfo
-				totalCount = fragment.TotalCount
+			labels, err := OptionalStringArrayParam(args, "labels")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			// Create response with pagination info
-			response := map[string]interface{}{
-				"discussions": discussions,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     pageInfo.HasNextPage,
-					"hasPreviousPage": pageInfo.HasPreviousPage,
-					"startCursor":     string(pageInfo.StartCursor),
-					"endCursor":       string(pageInfo.EndCursor),
-				},
-				"totalCount": totalCount,
+			groupByCategory, err := OptionalParam[bool](args, "groupByCategory")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			out, err := json.Marshal(response)
+			fetchAll, err := OptionalParam[bool](args, "fetchAll")
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal discussions: %w", err)
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
-			return utils.NewToolResultText(string(out)), nil, nil
-		},
-	)
-}
 
-func GetDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
-	return NewTool(
-		ToolsetMetadataDiscussions,
-		mcp.Tool{
-			Name:        "get_discussion",
-			Description: t("TOOL_GET_DISCUSSION_DESCRIPTION", "Get a specific discussion by ID"),
-			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_DISCUSSION_USER_TITLE", "Get discussion"),
-				ReadOnlyHint: true,
-			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
+			maxCost, err := OptionalIntParam(args, "maxCost")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if maxCost != 0 && !fetchAll {
+				return utils.NewToolResultError("maxCost requires fetchAll: a single page can't be cut short"), nil, nil
+			}
+			if maxCost < 0 {
+				return utils.NewToolResultError("maxCost must be positive"), nil, nil
+			}
+
+			maxResults, err := OptionalIntParam(args, "maxResults")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if maxResults != 0 && !fetchAll {
+				return utils.NewToolResultError("maxResults requires fetchAll: use perPage to size a single page"), nil, nil
+			}
+			if maxResults < 0 {
+				return utils.NewToolResultError("maxResults must be positive"), nil, nil
+			}
+
+			countOnly, err := OptionalParam[bool](args, "countOnly")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if countOnly && (filterAnswered || filterState || filterCreated || len(labels) > 0) {
+				return utils.NewToolResultError("countOnly cannot be combined with answered, state, labels, createdAfter or createdBefore: they filter fetched discussions, so use fetchAll and filteredCount instead"), nil, nil
+			}
+
+			timeout, err := optionalQueryTimeout(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			output, err := OptionalOutputFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			format, err := optionalDiscussionFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			usePageTokens, err := OptionalPageTokenParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			// Get pagination parameters and convert to GraphQL format
+			pagination, err := OptionalCursorPaginationParams(args)
+			if err != nil {
+				return nil, nil, err
+			}
+			if fetchAll && pagination.After != "" {
+				return utils.NewToolResultError("fetchAll and after are mutually exclusive: fetchAll pages through all discussions from the start"), nil, nil
+			}
+			// Checked here because a pageToken is read into after, which ToGraphQLParams rejects with
+			// last or before without naming the token
+			if usePageTokens && (pagination.Last > 0 || pagination.Before != "") {
+				return utils.NewToolResultError("page tokens only page forward and cannot be combined with last or before"), nil, nil
+			}
+			if _, ok := args["perPage"]; !ok {
+				pagination.PerPage = defaultGraphQLPageSize(deps)
+			}
+			if fetchAll {
+				pagination.PerPage = discussionScanPageSize
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			backward := paginationParams.Backward()
+			if backward && fetchAll {
+				return utils.NewToolResultError("fetchAll pages forward from the start and cannot be combined with last or before"), nil, nil
+			}
+
+			pageSize := int(*paginationParams.First)
+			if backward {
+				pageSize = int(*paginationParams.Last)
+			}
+			pages := 1
+			if fetchAll {
+				pages = discussionScanMaxPages
+			}
+			costs := []selectionCost{{nodes: pageSize}, {option: "fetchAll", nodes: pageSize * (pages - 1)}}
+			if includeParticipants {
+				costs = append(costs, selectionCost{option: "includeParticipants", nodes: min(pageSize*pages, maxParticipantCountDiscussions) * 100})
+			}
+			if err := checkSelectionBudget(deps.GetSelectionBudget(), costs...); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			ctx, cancel := withQueryTimeout(ctx, timeout)
+			defer cancel()
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var categoryID *githubv4.ID
+			if category != "" {
+				id := githubv4.ID(category)
+				categoryID = &id
+			} else if categoryName != "" {
+				categoryID, err = resolveDiscussionCategoryID(ctx, deps.GetDiscussionCategoryCache(), client, owner, repo, "", categoryName)
+				if err != nil {
+					return utils.NewToolResultError(queryTimeoutError(ctx, timeout, err).Error()), nil, nil
+				}
+			}
+
+			if countOnly {
+				totalCount, err := countRepositoryDiscussions(ctx, client, deps.GetGraphQLRetryPolicy(), owner, repo, categoryID)
+				if err != nil {
+					return utils.NewToolResultError(queryTimeoutError(ctx, timeout, err).Error()), nil, nil
+				}
+				if format == discussionFormatMarkdown {
+					return utils.NewToolResultText(fmt.Sprintf("Total discussions: %d\n", totalCount)), nil, nil
+				}
+				response := map[string]any{"totalCount": totalCount}
+				if repoWarning != "" {
+					response["warning"] = repoWarning
+				}
+				out, err := MarshalOutput(response, output)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal discussion count: %w", err)
+				}
+				return utils.NewToolResultText(string(out)), nil, nil
+			}
+
+			vars := map[string]interface{}{
+				"owner":       githubv4.String(owner),
+				"repo":        githubv4.String(repo),
+				"includeBody": githubv4.Boolean(includeBody),
+			}
+			if backward {
+				vars["last"] = githubv4.Int(*paginationParams.Last)
+			} else {
+				vars["first"] = githubv4.Int(*paginationParams.First)
+			}
+
+			// this is an extra check in case the tool description is misinterpreted, because
+			// we shouldn't use ordering unless both a 'field' and 'direction' are provided
+			useOrdering := orderBy != "" && direction != ""
+			if useOrdering {
+				vars["orderByField"] = githubv4.DiscussionOrderField(orderBy)
+				vars["orderByDirection"] = githubv4.OrderDirection(direction)
+			}
+
+			if categoryID != nil {
+				vars["categoryId"] = *categoryID
+			}
+
+			var discussions []*listedDiscussion
+			var pageInfo PageInfoFragment
+			var totalCount githubv4.Int
+			var costUsed int
+			var overBudget, overMaxResults bool
+			// Discussions edited while fetchAll pages can move onto a later page and be fetched twice
+			seen := map[githubv4.Int]bool{}
+			duplicatesSkipped := 0
+			fetch := func(cursor *githubv4.String) (PageInfoFragment, bool, error) {
+				// Only a single page is fetched backward, as fetchAll pages forward
+				if backward {
+					vars["before"] = cursor
+				} else {
+					vars["after"] = cursor
+				}
+				discussionQuery := getQueryType(useOrdering, categoryID, backward)
+				if err := queryWithRetry(ctx, client, deps.GetGraphQLRetryPolicy(), discussionQuery, vars); err != nil {
+					return PageInfoFragment{}, false, err
+				}
+
+				// Extract and convert all discussion nodes using the common interface
+				if queryResult, ok := discussionQuery.(DiscussionQueryResult); ok {
+					fragment := queryResult.GetDiscussionFragment()
+					for _, node := range fragment.Nodes {
+						if fetchAll {
+							if seen[node.Number] {
+								duplicatesSkipped++
+								continue
+							}
+							seen[node.Number] = true
+						}
+						// The discussions connection has no answered filter, so it is applied here.
+						if filterAnswered && bool(node.IsAnswered) != answered {
+							continue
+						}
+						// Nor a state filter
+						if filterState && bool(node.Closed) != (state == "closed") {
+							continue
+						}
+						// Nor has it a date filter
+						if !createdAfter.IsZero() && node.CreatedAt.Before(createdAfter) {
+							continue
+						}
+						if !createdBefore.IsZero() && !node.CreatedAt.Before(createdBefore) {
+							continue
+						}
+						d := fragmentToListedDiscussion(node)
+						// Nor a label filter
+						if !hasAllLabels(d.Labels, labels) {
+							continue
+						}
+						if includeBody {
+							body, truncated := truncateRunes(string(node.Body), bodyMaxLength)
+							d.Body = &body
+							if truncated {
+								d.BodyTruncated = &truncated
+							}
+						}
+						discussions = append(discussions, d)
+					}
+					pageInfo = fragment.PageInfo
+					totalCount = fragment.TotalCount
+					costUsed += queryResult.GetRateLimitCost()
+				}
+				// With sortBy every discussion is collected first, so that the cap keeps the top ones
+				if maxResults > 0 && sortKey == nil && len(discussions) >= maxResults {
+					overMaxResults = len(discussions) > maxResults || bool(pageInfo.HasNextPage)
+					discussions = discussions[:maxResults]
+					return pageInfo, true, nil
+				}
+				if maxCost > 0 && costUsed >= maxCost && pageInfo.HasNextPage {
+					overBudget = true
+					return pageInfo, true, nil
+				}
+				return pageInfo, false, nil
+			}
+
+			var truncated bool
+			if fetchAll {
+				truncated, err = scanPages(ctx, discussionScanMaxPages, fetch)
+			} else {
+				cursorParam := paginationParams.After
+				if backward {
+					cursorParam = paginationParams.Before
+				}
+				var cursor *githubv4.String
+				if cursorParam != nil {
+					cursor = githubv4.NewString(githubv4.String(*cursorParam))
+				}
+				_, _, err = fetch(cursor)
+			}
+			if err != nil {
+				return utils.NewToolResultError(queryTimeoutError(ctx, timeout, err).Error()), nil, nil
+			}
+
+			if sortKey != nil {
+				sort.SliceStable(discussions, func(i, j int) bool {
+					return sortKey(discussions[i]) > sortKey(discussions[j])
+				})
+				if maxResults > 0 && len(discussions) > maxResults {
+					overMaxResults = true
+					discussions = discussions[:maxResults]
+				}
+			}
+
+			if includeParticipants {
+				for i, d := range discussions {
+					if i == maxParticipantCountDiscussions {
+						break
+					}
+					count, err := countDiscussionParticipants(ctx, client, owner, repo, int32(d.GetNumber()), d.GetUser().GetLogin()) //nolint:gosec // discussion numbers come from the API and fit in int32
+					if err != nil {
+						return utils.NewToolResultError(queryTimeoutError(ctx, timeout, err).Error()), nil, nil
+					}
+					d.ParticipantCount = &count
+				}
+			}
+
+			if includeAnswerLatency {
+				for _, d := range discussions {
+					var latency *int64
+					if d.AnswerChosenAt != nil {
+						seconds := int64(d.AnswerChosenAt.Sub(d.GetCreatedAt().Time).Seconds())
+						latency = &seconds
+					}
+					d.AnswerLatencySeconds = &latency
+				}
+			}
+
+			if includePinned {
+				pinned, err := pinnedDiscussionNumbers(ctx, client, owner, repo)
+				if err != nil {
+					return utils.NewToolResultError(queryTimeoutError(ctx, timeout, err).Error()), nil, nil
+				}
+				for _, d := range discussions {
+					d.IsPinned = github.Ptr(pinned[d.GetNumber()])
+				}
+			}
+
+			// Create response with pagination info
+			response := map[string]interface{}{
+				"discussions": discussions,
+				"pageInfo": map[string]interface{}{
+					"hasNextPage":     pageInfo.HasNextPage,
+					"hasPreviousPage": pageInfo.HasPreviousPage,
+					"startCursor":     string(pageInfo.StartCursor),
+					"endCursor":       string(pageInfo.EndCursor),
+				},
+				"totalCount": totalCount,
+			}
+			if fetchAll {
+				response["truncated"] = truncated || overBudget || overMaxResults
+				response["duplicatesSkipped"] = duplicatesSkipped
+			}
+			if maxCost > 0 {
+				response["costUsed"] = costUsed
+			}
+			if filterAnswered || filterState || filterCreated || len(labels) > 0 {
+				response["filteredCount"] = len(discussions)
+			}
+			if groupByCategory {
+				grouped := map[string][]*listedDiscussion{}
+				for _, d := range discussions {
+					name := d.GetDiscussionCategory().GetName()
+					grouped[name] = append(grouped[name], d)
+				}
+				delete(response, "discussions")
+				response["discussionsByCategory"] = grouped
+			}
+			if usePageTokens {
+				replacePageInfoWithToken(response, pageInfo.HasNextPage, string(pageInfo.EndCursor))
+			}
+			if repoWarning != "" {
+				response["warning"] = repoWarning
+			}
+
+			if format == discussionFormatMarkdown {
+				var nextPage string
+				switch {
+				case usePageTokens && pageInfo.HasNextPage:
+					nextPage = fmt.Sprintf("call again with pageToken %q", response["nextPageToken"])
+				case backward && pageInfo.HasPreviousPage:
+					nextPage = fmt.Sprintf("call again with before %q", string(pageInfo.StartCursor))
+				case !backward && !fetchAll && pageInfo.HasNextPage:
+					nextPage = fmt.Sprintf("call again with after %q", string(pageInfo.EndCursor))
+				}
+				text := listedDiscussionsMarkdown(discussions, nextPage)
+				if repoWarning != "" {
+					text = fmt.Sprintf("Warning: %s\n\n%s", repoWarning, text)
+				}
+				return utils.NewToolResultText(text), nil, nil
+			}
+
+			out, err := MarshalOutput(response, output)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal discussions: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func GetDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "get_discussion",
+			Description: t("TOOL_GET_DISCUSSION_DESCRIPTION", "Get a specific discussion by ID"),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_DISCUSSION_USER_TITLE", "Get discussion"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithOutputFormat(WithDiscussionURL(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+					"bodyAsResourceLink": {
+						Type:        "boolean",
+						Description: "Return the body as a resource link that can be read on demand instead of inline, for large discussions. The body is still inlined for clients that don't support resource links.",
+					},
+					"includeConvertedFrom": {
+						Type:        "boolean",
+						Description: "Include convertedFrom, the issue the discussion was converted from. This is a best-effort extra lookup: convertedFrom is null when the discussion wasn't converted from an issue or that can't be determined.",
+					},
+					"includePoll": {
+						Type:        "boolean",
+						Description: "Include poll, the discussion's poll with its question, options and vote counts, or null when the discussion has no poll. This makes one extra query.",
+					},
+					"format": discussionFormatSchema(),
+				},
+			})),
+		},
+		func(ctx context.Context, deps ToolDependencies, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			if err := applyDiscussionURL(args); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			// Decode params
+			var params struct {
+				Owner                string
+				Repo                 string
+				DiscussionNumber     int32
+				BodyAsResourceLink   bool
+				IncludeConvertedFrom bool
+				IncludePoll          bool
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			output, err := OptionalOutputFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			format, err := optionalDiscussionFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var q struct {
+				Repository struct {
+					Discussion struct {
+						Number    githubv4.Int
+						Title     githubv4.String
+						Body      githubv4.String
+						CreatedAt githubv4.DateTime
+						Author    *struct {
+							Login githubv4.String
+						}
+						Closed         githubv4.Boolean
+						ClosedAt       *githubv4.DateTime
+						IsAnswered     githubv4.Boolean
+						AnswerChosenAt *githubv4.DateTime
+						URL            githubv4.String `graphql:"url"`
+						Category       *struct {
+							Name githubv4.String
+						} `graphql:"category"`
+						UpvoteCount      githubv4.Int
+						ViewerHasUpvoted githubv4.Boolean
+						Labels           discussionLabelsFragment `graphql:"labels(first: 10)"`
+						Comments         struct {
+							TotalCount githubv4.Int
+						}
+						ReactionGroups []struct {
+							Content githubv4.String
+							Users   struct {
+								TotalCount githubv4.Int
+							}
+							ViewerHasReacted githubv4.Boolean
+						}
+					} `graphql:"discussion(number: $discussionNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]interface{}{
+				"owner":            githubv4.String(params.Owner),
+				"repo":             githubv4.String(params.Repo),
+				"discussionNumber": githubv4.Int(params.DiscussionNumber),
+			}
+			if err := queryWithRetry(ctx, client, deps.GetGraphQLRetryPolicy(), &q, vars); err != nil {
+				return utils.NewToolResultError(discussionQueryError(err, params.Owner, params.Repo, params.DiscussionNumber).Error()), nil, nil
+			}
+			d := q.Repository.Discussion
+
+			reactions := map[string]int{}
+			viewerHasReacted := map[string]bool{}
+			for _, group := range d.ReactionGroups {
+				reactions[string(group.Content)] = int(group.Users.TotalCount)
+				viewerHasReacted[string(group.Content)] = bool(group.ViewerHasReacted)
+			}
+
+			// Build response as map to include fields not present in go-github's Discussion struct.
+			// The go-github library's Discussion type lacks isAnswered and answerChosenAt fields,
+			// so we use map[string]interface{} for the response (consistent with other functions
+			// like ListDiscussions and GetDiscussionComments).
+			response := map[string]interface{}{
+				"number":           int(d.Number),
+				"title":            string(d.Title),
+				"body":             string(d.Body),
+				"url":              string(d.URL),
+				"closed":           bool(d.Closed),
+				"isAnswered":       bool(d.IsAnswered),
+				"createdAt":        d.CreatedAt.Time,
+				"author":           nil,
+				"category":         nil,
+				"upvoteCount":      int(d.UpvoteCount),
+				"viewerHasUpvoted": bool(d.ViewerHasUpvoted),
+				"labels":           d.Labels.toLabels(),
+				"commentCount":     int(d.Comments.TotalCount),
+				"reactions":        reactions,
+				"viewerHasReacted": viewerHasReacted,
+			}
+			// The author is null when the account was deleted
+			if d.Author != nil {
+				response["author"] = string(d.Author.Login)
+			}
+			// The category is null when it has been deleted
+			if d.Category != nil {
+				response["category"] = map[string]interface{}{
+					"name": string(d.Category.Name),
+				}
+			}
+
+			// Add optional timestamp fields if present
+			if chosenAt := optionalDateTime(d.AnswerChosenAt); chosenAt != nil {
+				response["answerChosenAt"] = *chosenAt
+			}
+			if closedAt := optionalDateTime(d.ClosedAt); closedAt != nil {
+				response["closedAt"] = *closedAt
+			}
+
+			if params.IncludeConvertedFrom {
+				response["convertedFrom"] = discussionConvertedFrom(ctx, client, params.Owner, params.Repo, params.DiscussionNumber)
+			}
+
+			if params.IncludePoll {
+				poll, err := discussionPoll(ctx, client, params.Owner, params.Repo, params.DiscussionNumber)
+				if err != nil {
+					return utils.NewToolResultError(discussionQueryError(err, params.Owner, params.Repo, params.DiscussionNumber).Error()), nil, nil
+				}
+				response["poll"] = poll
+			}
+
+			var bodyLink *mcp.ResourceLink
+			if params.BodyAsResourceLink && ClientSupportsResourceLinks(req) {
+				uri, err := discussionResourceBodyURI(params.Owner, params.Repo, params.DiscussionNumber)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to build discussion body URI: %w", err)
+				}
+				size := int64(len(d.Body))
+				bodyLink = &mcp.ResourceLink{
+					URI:      uri,
+					Name:     fmt.Sprintf("discussion-%d-body", params.DiscussionNumber),
+					Title:    string(d.Title),
+					MIMEType: "text/markdown",
+					Size:     &size,
+				}
+				delete(response, "body")
+				response["bodyUri"] = uri
+			}
+
+			var result *mcp.CallToolResult
+			if format == discussionFormatMarkdown {
+				summary := discussionSummary{
+					Number: int(d.Number),
+					Title:  string(d.Title),
+					URL:    string(d.URL),
+				}
+				if d.Author != nil {
+					summary.Author = string(d.Author.Login)
+				}
+				if d.Category != nil {
+					summary.Category = string(d.Category.Name)
+				}
+				// A body sent as a resource link isn't inlined
+				if bodyLink == nil {
+					body := string(d.Body)
+					summary.Body = &body
+				}
+				var b strings.Builder
+				summary.writeMarkdown(&b, "#")
+				result = utils.NewToolResultText(b.String())
+			} else {
+				out, err := MarshalOutput(response, output)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal discussion: %w", err)
+				}
+				result = utils.NewToolResultText(string(out))
+			}
+			if bodyLink != nil {
+				result.Content = append(result.Content, bodyLink)
+			}
+			return result, nil, nil
+		},
+	)
+}
+
+// discussionConvertedFrom returns the issue a discussion was converted from, or nil. A converted
+// discussion keeps the issue's number, so the issue with the same number is checked for a
+// conversion event pointing back at the discussion. Any error is treated as not converted, as the
+// issue may not resolve at all.
+func discussionConvertedFrom(ctx context.Context, client *githubv4.Client, owner, repo string, discussionNumber int32) map[string]any {
+	var q struct {
+		Repository struct {
+			Issue *struct {
+				Number        githubv4.Int
+				URL           githubv4.String `graphql:"url"`
+				TimelineItems struct {
+					Nodes []struct {
+						ConvertedToDiscussionEvent struct {
+							Discussion *struct {
+								Number githubv4.Int
+							}
+						} `graphql:"... on ConvertedToDiscussionEvent"`
+					}
+				} `graphql:"timelineItems(itemTypes: [CONVERTED_TO_DISCUSSION_EVENT], first: 1)"`
+			} `graphql:"issue(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner":            githubv4.String(owner),
+		"repo":             githubv4.String(repo),
+		"discussionNumber": githubv4.Int(discussionNumber),
+	}
+	if err := queryWithRequestID(ctx, client, &q, vars); err != nil || q.Repository.Issue == nil {
+		return nil
+	}
+	issue := q.Repository.Issue
+	for _, node := range issue.TimelineItems.Nodes {
+		if d := node.ConvertedToDiscussionEvent.Discussion; d != nil && int32(d.Number) == discussionNumber {
+			return map[string]any{
+				"issueNumber": int(issue.Number),
+				"url":         string(issue.URL),
+			}
+		}
+	}
+	return nil
+}
+
+// discussionPollFragment selects a poll with its vote counts, for get_discussion and
+// vote_on_discussion_poll. A poll has at most 8 options, so the first 10 are all of them.
+type discussionPollFragment struct {
+	Question       githubv4.String
+	TotalVoteCount githubv4.Int
+	ViewerHasVoted githubv4.Boolean
+	Options        struct {
+		Nodes []struct {
+			ID             githubv4.ID
+			Option         githubv4.String
+			TotalVoteCount githubv4.Int
+			ViewerHasVoted githubv4.Boolean
+		}
+	} `graphql:"options(first: 10)"`
+}
+
+func (p discussionPollFragment) toMap() map[string]any {
+	options := make([]map[string]any, 0, len(p.Options.Nodes))
+	for _, o := range p.Options.Nodes {
+		options = append(options, map[string]any{
+			"id":             fmt.Sprint(o.ID),
+			"option":         string(o.Option),
+			"totalVoteCount": int(o.TotalVoteCount),
+			"viewerHasVoted": bool(o.ViewerHasVoted),
+		})
+	}
+	return map[string]any{
+		"question":       string(p.Question),
+		"totalVoteCount": int(p.TotalVoteCount),
+		"viewerHasVoted": bool(p.ViewerHasVoted),
+		"options":        options,
+	}
+}
+
+// discussionPoll returns the poll of a discussion, or nil when the discussion has no poll.
+func discussionPoll(ctx context.Context, client *githubv4.Client, owner, repo string, discussionNumber int32) (map[string]any, error) {
+	var q struct {
+		Repository struct {
+			Discussion struct {
+				Poll *discussionPollFragment
+			} `graphql:"discussion(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner":            githubv4.String(owner),
+		"repo":             githubv4.String(repo),
+		"discussionNumber": githubv4.Int(discussionNumber),
+	}
+	if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+		return nil, err
+	}
+	if q.Repository.Discussion.Poll == nil {
+		return nil, nil
+	}
+	return q.Repository.Discussion.Poll.toMap(), nil
+}
+
+// maxBatchDiscussions caps the number of discussions a batch tool call may act on.
+const maxBatchDiscussions = 25
+
+func GetDiscussions(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "get_discussions",
+			Description: t("TOOL_GET_DISCUSSIONS_DESCRIPTION", "Get several discussions by number in one call. Each discussion has its own result with a status, so some can fail without failing the others."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_DISCUSSIONS_USER_TITLE", "Get discussions"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithOutputFormat(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumbers": {
+						Type:        "array",
+						Description: fmt.Sprintf("Discussion numbers (at most %d)", maxBatchDiscussions),
+						Items: &jsonschema.Schema{
+							Type: "number",
+						},
+						MinItems: jsonschema.Ptr(1),
+						MaxItems: jsonschema.Ptr(maxBatchDiscussions),
+					},
+				},
+				Required: []string{"owner", "repo", "discussionNumbers"},
+			}),
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				Owner             string
+				Repo              string
+				DiscussionNumbers []int32
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if len(params.DiscussionNumbers) == 0 {
+				return utils.NewToolResultError("discussionNumbers must not be empty"), nil, nil
+			}
+			if len(params.DiscussionNumbers) > maxBatchDiscussions {
+				return utils.NewToolResultError(fmt.Sprintf("at most %d discussions can be fetched at once", maxBatchDiscussions)), nil, nil
+			}
+			output, err := OptionalOutputFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			results := make([]batchItemResult, 0, len(params.DiscussionNumbers))
+			for _, number := range params.DiscussionNumbers {
+				var q struct {
+					Repository struct {
+						Discussion struct {
+							Number     githubv4.Int
+							Title      githubv4.String
+							URL        githubv4.String `graphql:"url"`
+							Closed     githubv4.Boolean
+							IsAnswered githubv4.Boolean
+						} `graphql:"discussion(number: $discussionNumber)"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}
+				vars := map[string]any{
+					"owner":            githubv4.String(params.Owner),
+					"repo":             githubv4.String(params.Repo),
+					"discussionNumber": githubv4.Int(number),
+				}
+				if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+					results = append(results, batchItemError(int(number), err))
+					continue
+				}
+				d := q.Repository.Discussion
+				results = append(results, batchItemOK(int(number), map[string]any{
+					"number":     int(d.Number),
+					"title":      string(d.Title),
+					"url":        string(d.URL),
+					"closed":     bool(d.Closed),
+					"isAnswered": bool(d.IsAnswered),
+				}))
+			}
+
+			out, err := MarshalOutput(batchResponse(results), output)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal discussions: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+// defaultDiscussionReplyCount is the number of replies get_discussion_comments includes per comment
+// when includeReplies is set without a replyCount.
+const defaultDiscussionReplyCount = 5
+
+func GetDiscussionComments(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "get_discussion_comments",
+			Description: t("TOOL_GET_DISCUSSION_COMMENTS_DESCRIPTION", "Get comments from a discussion. answerCommentId is the id of the accepted answer when it is among the returned comments, and null otherwise. Minimized (hidden) comments are marked with isMinimized and minimizedReason."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_DISCUSSION_COMMENTS_USER_TITLE", "Get discussion comments"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithOutputFormat(WithPageTokens(WithCursorPagination(WithDiscussionURL(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+					"includeReplyContext": {
+						Type:        "boolean",
+						Description: "Include the id and author of the comment each reply responds to, as replyTo (null for top-level comments).",
+					},
+					"answerFirst": {
+						Type:        "boolean",
+						Description: "Move the accepted answer to the top of the returned comments, keeping the rest in chronological order. Only reorders the current page.",
+					},
+					"includeReplies": {
+						Type:        "boolean",
+						Description: "Include the first replies to each comment, as replies with their id, body and url, and hasMoreReplies when a comment has more than replyCount.",
+					},
+					"replyCount": {
+						Type:        "number",
+						Description: fmt.Sprintf("Maximum number of replies to include per comment with includeReplies (default %d, max 100)", defaultDiscussionReplyCount),
+						Minimum:     jsonschema.Ptr(1.0),
+						Maximum:     jsonschema.Ptr(100.0),
+					},
+					"bodyMaxLength": {
+						Type:        "number",
+						Description: "Cut comment bodies longer than this many characters, ending them with an ellipsis and setting bodyTruncated on them. By default bodies are returned in full.",
+						Minimum:     jsonschema.Ptr(1.0),
+					},
+					"sinceCursor": {
+						Type:        "string",
+						Description: "For polling: only return comments after this cursor, which is the latestCursor of a previous poll. The response then reports hasNew and the latestCursor to poll with next. Cannot be combined with 'after' or 'pageToken'.",
+					},
+					"includeMinimized": {
+						Type:        "boolean",
+						Description: "Include minimized (hidden) comments, such as spam or off-topic ones (default true). When false they are left out of the returned comments.",
+					},
+					"timeoutSeconds": queryTimeoutSchema(),
+				},
+			})))),
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			if err := applyDiscussionURL(args); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			// Decode params
+			var params struct {
+				Owner               string
+				Repo                string
+				DiscussionNumber    int32
+				IncludeReplyContext bool
+				AnswerFirst         bool
+				IncludeReplies      bool
+				SinceCursor         string
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			replyCount, err := OptionalIntParamWithDefault(args, "replyCount", defaultDiscussionReplyCount)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if replyCount < 1 || replyCount > 100 {
+				return utils.NewToolResultError("replyCount must be between 1 and 100"), nil, nil
+			}
+			bodyMaxLength, err := OptionalIntParam(args, "bodyMaxLength")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if bodyMaxLength < 0 {
+				return utils.NewToolResultError("bodyMaxLength must be positive"), nil, nil
+			}
+			includeMinimized, err := OptionalBoolParamWithDefault(args, "includeMinimized", true)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			timeout, err := optionalQueryTimeout(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if params.SinceCursor != "" {
+				after, _ := args["after"].(string)
+				pageToken, _ := args["pageToken"].(string)
+				if after != "" || pageToken != "" {
+					return utils.NewToolResultError("sinceCursor cannot be combined with after or pageToken"), nil, nil
+				}
+				args["after"] = params.SinceCursor
+			}
+			output, err := OptionalOutputFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			usePageTokens, err := OptionalPageTokenParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			// Get pagination parameters and convert to GraphQL format
+			pagination, err := OptionalCursorPaginationParams(args)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Check if pagination parameters were explicitly provided
+			_, perPageProvided := args["perPage"]
+			paginationExplicit := perPageProvided
+
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			// Use the default page size if pagination was not explicitly provided
+			if !paginationExplicit {
+				defaultFirst := int32(defaultGraphQLPageSize(deps))
+				paginationParams.First = &defaultFirst
+			}
+
+			pageSize := int(*paginationParams.First)
+			costs := []selectionCost{{nodes: pageSize}}
+			if params.IncludeReplyContext {
+				costs = append(costs, selectionCost{option: "includeReplyContext", nodes: pageSize})
+			}
+			if params.IncludeReplies {
+				costs = append(costs, selectionCost{option: "includeReplies", nodes: pageSize * replyCount})
+			}
+			if err := checkSelectionBudget(deps.GetSelectionBudget(), costs...); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			ctx, cancel := withQueryTimeout(ctx, timeout)
+			defer cancel()
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var q struct {
+				Repository struct {
+					Discussion struct {
+						Comments struct {
+							Nodes []struct {
+								ID       githubv4.ID
+								Body     githubv4.String
+								URL      githubv4.String `graphql:"url"`
+								IsAnswer githubv4.Boolean
+								Author   *struct {
+									Login githubv4.String
+								}
+								CreatedAt       githubv4.DateTime
+								UpdatedAt       githubv4.DateTime
+								IsMinimized     githubv4.Boolean
+								MinimizedReason githubv4.String
+								ReactionGroups  []struct {
+									Content githubv4.String
+									Users   struct {
+										TotalCount githubv4.Int
+									}
+								}
+								ReplyTo *struct {
+									ID     githubv4.ID
+									Author *struct {
+										Login githubv4.String
+									}
+								} `graphql:"replyTo @include(if: $includeReplyContext)"`
+								Replies struct {
+									Nodes []struct {
+										ID   githubv4.ID
+										Body githubv4.String
+										URL  githubv4.String `graphql:"url"`
+									}
+									PageInfo struct {
+										HasNextPage githubv4.Boolean
+									}
+								} `graphql:"replies(first: $replyCount) @include(if: $includeReplies)"`
+							}
+							PageInfo struct {
+								HasNextPage     githubv4.Boolean
+								HasPreviousPage githubv4.Boolean
+								StartCursor     githubv4.String
+								EndCursor       githubv4.String
+							}
+							TotalCount int
+						} `graphql:"comments(first: $first, after: $after)"`
+						AnswerChosenAt *githubv4.DateTime
+						AnswerChosenBy *struct {
+							Login githubv4.String
+						}
+					} `graphql:"discussion(number: $discussionNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]interface{}{
+				"owner":               githubv4.String(params.Owner),
+				"repo":                githubv4.String(params.Repo),
+				"discussionNumber":    githubv4.Int(params.DiscussionNumber),
+				"first":               githubv4.Int(*paginationParams.First),
+				"includeReplyContext": githubv4.Boolean(params.IncludeReplyContext),
+				"includeReplies":      githubv4.Boolean(params.IncludeReplies),
+				"replyCount":          githubv4.Int(replyCount),
+			}
+			if paginationParams.After != nil {
+				vars["after"] = githubv4.String(*paginationParams.After)
+			} else {
+				vars["after"] = (*githubv4.String)(nil)
+			}
+			if err := queryWithRetry(ctx, client, deps.GetGraphQLRetryPolicy(), &q, vars); err != nil {
+				return utils.NewToolResultError(discussionQueryError(queryTimeoutError(ctx, timeout, err), params.Owner, params.Repo, params.DiscussionNumber).Error()), nil, nil
+			}
+
+			discussion := q.Repository.Discussion
+			var comments []map[string]any
+			for _, c := range discussion.Comments.Nodes {
+				if bool(c.IsMinimized) && !includeMinimized {
+					continue
+				}
+				reactions := map[string]int{}
+				for _, group := range c.ReactionGroups {
+					reactions[string(group.Content)] = int(group.Users.TotalCount)
+				}
+				comment := map[string]any{
+					"id":              fmt.Sprint(c.ID),
+					"body":            string(c.Body),
+					"url":             string(c.URL),
+					"isAnswer":        bool(c.IsAnswer),
+					"author":          nil,
+					"createdAt":       c.CreatedAt.Format(time.RFC3339),
+					"updatedAt":       c.UpdatedAt.Format(time.RFC3339),
+					"reactions":       reactions,
+					"isMinimized":     bool(c.IsMinimized),
+					"minimizedReason": nil,
+				}
+				// The author is null when the account was deleted
+				if c.Author != nil {
+					comment["author"] = string(c.Author.Login)
+				}
+				if c.IsMinimized {
+					comment["minimizedReason"] = string(c.MinimizedReason)
+				}
+				if body, truncated := truncateRunes(string(c.Body), bodyMaxLength); truncated {
+					comment["body"] = body + "…"
+					comment["bodyTruncated"] = true
+				}
+				if c.IsAnswer {
+					if discussion.AnswerChosenAt != nil {
+						comment["answerChosenAt"] = discussion.AnswerChosenAt.Format(time.RFC3339)
+					}
+					// answerChosenBy is null when the account that chose the answer was deleted
+					if discussion.AnswerChosenBy != nil {
+						comment["answerChosenBy"] = string(discussion.AnswerChosenBy.Login)
+					}
+				}
+				if params.IncludeReplyContext {
+					comment["replyTo"] = nil
+					if c.ReplyTo != nil {
+						replyTo := map[string]any{"id": fmt.Sprint(c.ReplyTo.ID), "author": nil}
+						// The author is null when the account was deleted
+						if c.ReplyTo.Author != nil {
+							replyTo["author"] = string(c.ReplyTo.Author.Login)
+						}
+						comment["replyTo"] = replyTo
+					}
+				}
+				if params.IncludeReplies {
+					replies := make([]map[string]any, 0, len(c.Replies.Nodes))
+					for _, r := range c.Replies.Nodes {
+						replies = append(replies, map[string]any{
+							"id":   fmt.Sprint(r.ID),
+							"body": string(r.Body),
+							"url":  string(r.URL),
+						})
+					}
+					comment["replies"] = replies
+					comment["hasMoreReplies"] = bool(c.Replies.PageInfo.HasNextPage)
+				}
+				comments = append(comments, comment)
+			}
+			// The answer may be on another page, so answerCommentId is only set when it's on this one
+			var answerCommentID any
+			for _, c := range comments {
+				if c["isAnswer"] == true {
+					answerCommentID = c["id"]
+					break
+				}
+			}
+			if params.AnswerFirst {
+				for i, c := range comments {
+					if c["isAnswer"] == true {
+						comments = append(append([]map[string]any{c}, comments[:i]...), comments[i+1:]...)
+						break
+					}
+				}
+			}
+
+			// Create response with pagination info
+			response := map[string]interface{}{
+				"comments": comments,
+				"pageInfo": map[string]interface{}{
+					"hasNextPage":     q.Repository.Discussion.Comments.PageInfo.HasNextPage,
+					"hasPreviousPage": q.Repository.Discussion.Comments.PageInfo.HasPreviousPage,
+					"startCursor":     string(q.Repository.Discussion.Comments.PageInfo.StartCursor),
+					"endCursor":       string(q.Repository.Discussion.Comments.PageInfo.EndCursor),
+				},
+				"totalCount":      q.Repository.Discussion.Comments.TotalCount,
+				"answerCommentId": answerCommentID,
+			}
+			if params.SinceCursor != "" {
+				// With no new comments there is no end cursor, so the next poll starts from the same place.
+				// Comments left out by includeMinimized still move the cursor on, so they aren't re-read.
+				latestCursor := params.SinceCursor
+				if len(discussion.Comments.Nodes) > 0 {
+					latestCursor = string(q.Repository.Discussion.Comments.PageInfo.EndCursor)
+				}
+				response["hasNew"] = len(comments) > 0
+				response["latestCursor"] = latestCursor
+			}
+			if usePageTokens {
+				replacePageInfoWithToken(response, bool(q.Repository.Discussion.Comments.PageInfo.HasNextPage), string(q.Repository.Discussion.Comments.PageInfo.EndCursor))
+			}
+
+			out, err := MarshalOutput(response, output)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal comments: %w", err)
+			}
+
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func ListDiscussionCategories(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "list_discussion_categories",
+			Description: t("TOOL_LIST_DISCUSSION_CATEGORIES_DESCRIPTION", "List discussion categories with their id, name, emoji, description, slug and whether they are answerable (Q&A), for a repository or organisation."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_DISCUSSION_CATEGORIES_USER_TITLE", "List discussion categories"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithOutputFormat(WithCursorPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name. If not provided, discussion categories will be queried at the organisation level.",
+					},
+					"level": discussionLevelSchema(),
+					"answerableOnly": {
+						Type:        "boolean",
+						Description: "Only return categories that accept answers (Q&A categories). Filtering is applied to the fetched categories after the query.",
+					},
+					"nameContains": {
+						Type:        "string",
+						Description: fmt.Sprintf("Only return categories whose name contains this text (case-insensitive). All categories, up to %d, are fetched and filtered, so the response has no pageInfo.", discussionScanPageSize*discussionScanMaxPages),
+					},
+					"fetchAll": {
+						Type:        "boolean",
+						Description: fmt.Sprintf("Page through all categories, up to %d, instead of returning a single page. The response then has no pageInfo. Cannot be combined with 'after'.", discussionScanPageSize*discussionScanMaxPages),
+					},
+				},
+				Required: []string{"owner"},
+			})),
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, repoWarning, err := resolveDiscussionsRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			answerableOnly, err := OptionalParam[bool](args, "answerableOnly")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			nameContains, err := OptionalParam[string](args, "nameContains")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			fetchAll, err := OptionalParam[bool](args, "fetchAll")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			output, err := OptionalOutputFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			pagination, err := OptionalCursorPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if _, ok := args["perPage"]; !ok {
+				pagination.PerPage = 25
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if paginationParams.After != nil && (fetchAll || nameContains != "") {
+				return utils.NewToolResultError("after cannot be combined with fetchAll or nameContains, which fetch all categories from the start"), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			if fetchAll || nameContains != "" {
+				// A name match can be on any page, so every category is fetched before filtering; an
+				// empty needle keeps them all
+				all, truncated, err := listAllDiscussionCategories(ctx, client, owner, repo)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				needle := strings.ToLower(nameContains)
+				categories := []map[string]any{}
+				for _, c := range all {
+					if answerableOnly && !c.isAnswerable {
+						continue
+					}
+					if !strings.Contains(strings.ToLower(c.name), needle) {
+						continue
+					}
+					categories = append(categories, c.toMap())
+				}
+
+				response := map[string]any{
+					"categories": categories,
+					"totalCount": len(categories),
+				}
+				if truncated {
+					response["truncated"] = true
+				}
+				if repoWarning != "" {
+					response["warning"] = repoWarning
+				}
+				out, err := MarshalOutput(response, output)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal discussion categories: %w", err)
+				}
+				return utils.NewToolResultText(string(out)), nil, nil
+			}
+
+			var after *githubv4.String
+			if paginationParams.After != nil {
+				after = githubv4.NewString(githubv4.String(*paginationParams.After))
+			}
+			page, err := queryDiscussionCategoriesPage(ctx, client, owner, repo, int(*paginationParams.First), after)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			var categories []map[string]any
+			for _, c := range page.categories {
+				// The discussionCategories connection has no answerable filter, so it is applied here.
+				if answerableOnly && !c.isAnswerable {
+					continue
+				}
+				categories = append(categories, c.toMap())
+			}
+
+			// Create response with pagination info
+			response := map[string]interface{}{
+				"categories": categories,
+				"pageInfo": map[string]interface{}{
+					"hasNextPage":     page.pageInfo.HasNextPage,
+					"hasPreviousPage": page.pageInfo.HasPreviousPage,
+					"startCursor":     string(page.pageInfo.StartCursor),
+					"endCursor":       string(page.pageInfo.EndCursor),
+				},
+				"totalCount": page.totalCount,
+			}
+			if repoWarning != "" {
+				response["warning"] = repoWarning
+			}
+
+			out, err := MarshalOutput(response, output)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal discussion categories: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func GetDiscussionCategory(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "get_discussion_category",
+			Description: t("TOOL_GET_DISCUSSION_CATEGORY_DESCRIPTION", "Get a discussion category by its node ID, such as the categoryId returned by create_discussion, with its name, description, emoji and whether it is answerable."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_DISCUSSION_CATEGORY_USER_TITLE", "Get discussion category"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithOutputFormat(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"category_id": {
+						Type:        "string",
+						Description: "Node ID of the discussion category",
+					},
+				},
+				Required: []string{"category_id"},
+			}),
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			categoryID, err := RequiredParam[string](args, "category_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			output, err := OptionalOutputFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var q struct {
+				Node struct {
+					TypeName githubv4.String        `graphql:"__typename"`
+					Category discussionCategoryNode `graphql:"... on DiscussionCategory"`
+				} `graphql:"node(id: $categoryId)"`
+			}
+			if err := queryWithRequestID(ctx, client, &q, map[string]any{"categoryId": githubv4.ID(categoryID)}); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if q.Node.TypeName != "DiscussionCategory" {
+				return utils.NewToolResultError(fmt.Sprintf("%s is not a discussion category", categoryID)), nil, nil
+			}
+
+			out, err := MarshalOutput(q.Node.Category.toCategory().toMap(), output)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal discussion category: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+// repositoryDiscussionSettingsCategories is how many categories get_repository_discussion_settings
+// returns. Repositories rarely have more, and truncated is set when they do.
+const repositoryDiscussionSettingsCategories = 50
+
+func GetRepositoryDiscussionSettings(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "get_repository_discussion_settings",
+			Description: t("TOOL_GET_REPOSITORY_DISCUSSION_SETTINGS_DESCRIPTION", "Get whether a repository has discussions enabled, with its first 50 discussion categories (id, name and whether they are answerable). Use it before create_discussion to check that the repository accepts discussions and to pick a category."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_REPOSITORY_DISCUSSION_SETTINGS_USER_TITLE", "Get repository discussion settings"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithOutputFormat(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+				},
+				Required: []string{"owner", "repo"},
+			}),
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			output, err := OptionalOutputFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var q struct {
+				Repository struct {
+					HasDiscussionsEnabled githubv4.Boolean
+					DiscussionCategories  struct {
+						Nodes      []discussionCategoryRef
+						TotalCount githubv4.Int
+					} `graphql:"discussionCategories(first: $first)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"first": githubv4.Int(repositoryDiscussionSettingsCategories),
+			}
+			if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			categories := make([]map[string]any, 0, len(q.Repository.DiscussionCategories.Nodes))
+			for _, c := range q.Repository.DiscussionCategories.Nodes {
+				categories = append(categories, map[string]any{
+					"id":           fmt.Sprint(c.ID),
+					"name":         string(c.Name),
+					"isAnswerable": bool(c.IsAnswerable),
+				})
+			}
+			response := map[string]any{
+				"hasDiscussionsEnabled": bool(q.Repository.HasDiscussionsEnabled),
+				"categories":            categories,
+			}
+			if int(q.Repository.DiscussionCategories.TotalCount) > len(categories) {
+				response["truncated"] = true
+			}
+
+			out, err := MarshalOutput(response, output)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal repository discussion settings: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+// discussionCategory is a discussion category read by queryDiscussionCategoriesPage.
+type discussionCategory struct {
+	id           string
+	name         string
+	emoji        string
+	description  string
+	isAnswerable bool
+	// slug is empty on GHES versions without category slugs
+	slug string
+}
+
+// toMap returns the category as it appears in list_discussion_categories responses.
+func (c discussionCategory) toMap() map[string]any {
+	m := map[string]any{
+		"id":           c.id,
+		"name":         c.name,
+		"emoji":        c.emoji,
+		"description":  c.description,
+		"isAnswerable": c.isAnswerable,
+	}
+	if c.slug != "" {
+		m["slug"] = c.slug
+	}
+	return m
+}
+
+type discussionCategoryNode struct {
+	ID           githubv4.ID
+	Name         githubv4.String
+	Emoji        githubv4.String
+	Description  githubv4.String
+	IsAnswerable githubv4.Boolean
+}
+
+type discussionCategoryNodeWithSlug struct {
+	discussionCategoryNode
+	Slug githubv4.String
+}
+
+type discussionCategoriesQuery[N any] struct {
+	Repository struct {
+		DiscussionCategories struct {
+			Nodes      []N
+			PageInfo   PageInfoFragment
+			TotalCount int
+		} `graphql:"discussionCategories(first: $first, after: $after)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// discussionCategoriesPage is one page of a repository's discussion categories.
+type discussionCategoriesPage struct {
+	categories []discussionCategory
+	pageInfo   PageInfoFragment
+	totalCount int
+}
+
+// queryDiscussionCategoriesPage fetches a page of discussion categories with their slugs. Older
+// GHES versions reject the slug field, in which case the page is fetched again without it.
+func queryDiscussionCategoriesPage(ctx context.Context, client *githubv4.Client, owner, repo string, first int, after *githubv4.String) (discussionCategoriesPage, error) {
+	vars := map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+		"first": githubv4.Int(first), // #nosec G115 - page sizes are small constants
+		"after": after,
+	}
+
+	var q discussionCategoriesQuery[discussionCategoryNodeWithSlug]
+	err := queryWithRequestID(ctx, client, &q, vars)
+	if err == nil {
+		page := discussionCategoriesPage{
+			pageInfo:   q.Repository.DiscussionCategories.PageInfo,
+			totalCount: q.Repository.DiscussionCategories.TotalCount,
+		}
+		for _, c := range q.Repository.DiscussionCategories.Nodes {
+			category := c.toCategory()
+			category.slug = string(c.Slug)
+			page.categories = append(page.categories, category)
+		}
+		return page, nil
+	}
+	if !strings.Contains(err.Error(), "Field 'slug' doesn't exist") {
+		return discussionCategoriesPage{}, err
+	}
+
+	var fallback discussionCategoriesQuery[discussionCategoryNode]
+	if err := queryWithRequestID(ctx, client, &fallback, vars); err != nil {
+		return discussionCategoriesPage{}, err
+	}
+	page := discussionCategoriesPage{
+		pageInfo:   fallback.Repository.DiscussionCategories.PageInfo,
+		totalCount: fallback.Repository.DiscussionCategories.TotalCount,
+	}
+	for _, c := range fallback.Repository.DiscussionCategories.Nodes {
+		page.categories = append(page.categories, c.toCategory())
+	}
+	return page, nil
+}
+
+func (n discussionCategoryNode) toCategory() discussionCategory {
+	return discussionCategory{
+		id:           fmt.Sprint(n.ID),
+		name:         string(n.Name),
+		emoji:        string(n.Emoji),
+		description:  string(n.Description),
+		isAnswerable: bool(n.IsAnswerable),
+	}
+}
+
+// listAllDiscussionCategories pages through a repository's discussion categories, up to the scan
+// limit. It reports whether the limit stopped it before the last page.
+func listAllDiscussionCategories(ctx context.Context, client *githubv4.Client, owner, repo string) ([]discussionCategory, bool, error) {
+	var categories []discussionCategory
+	truncated, err := scanPages(ctx, discussionScanMaxPages, func(after *githubv4.String) (PageInfoFragment, bool, error) {
+		page, err := queryDiscussionCategoriesPage(ctx, client, owner, repo, discussionScanPageSize, after)
+		if err != nil {
+			return PageInfoFragment{}, false, err
+		}
+		categories = append(categories, page.categories...)
+		return page.pageInfo, false, nil
+	})
+	return categories, truncated, err
+}
+
+func CreateDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "create_discussion",
+			Description: t("TOOL_CREATE_DISCUSSION_DESCRIPTION", "Create a new discussion in a repository."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_CREATE_DISCUSSION_USER_TITLE", "Create discussion"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"title": {
+						Type:        "string",
+						Description: "Discussion title",
+					},
+					"body": {
+						Type:        "string",
+						Description: "Discussion body (Markdown)",
+					},
+					"category_id": {
+						Type:        "string",
+						Description: "Discussion category node ID. If provided, this is used directly.",
+					},
+					"category_name": {
+						Type:        "string",
+						Description: "Discussion category name. If provided, it will be resolved to a category ID.",
+					},
+					"first_comment": {
+						Type:        "string",
+						Description: "Optional comment body (Markdown) to post on the discussion once it is created. If posting it fails, the discussion is still returned, with a warning.",
+					},
+				},
+				Required: []string{"owner", "repo", "title", "body"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				Owner        string
+				Repo         string
+				Title        string
+				Body         string
+				CategoryID   string `mapstructure:"category_id"`
+				CategoryName string `mapstructure:"category_name"`
+				FirstComment string `mapstructure:"first_comment"`
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			repoID, err := getRepositoryID(ctx, client, params.Owner, params.Repo)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			categoryID, err := resolveDiscussionCategoryID(ctx, deps.GetDiscussionCategoryCache(), client, params.Owner, params.Repo, params.CategoryID, params.CategoryName)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			var mutation struct {
+				CreateDiscussion struct {
+					Discussion struct {
+						ID       githubv4.ID
+						Number   githubv4.Int
+						URL      githubv4.String `graphql:"url"`
+						Category *struct {
+							ID           githubv4.ID
+							Name         githubv4.String
+							IsAnswerable githubv4.Boolean
+						}
+					}
+				} `graphql:"createDiscussion(input: $input)"`
+			}
+
+			if err := mutateWithRequestID(ctx, client, &mutation, githubv4.CreateDiscussionInput{
+				RepositoryID: repoID,
+				Title:        githubv4.String(params.Title),
+				Body:         githubv4.String(attributeMutationBody(params.Body, deps.GetMutationAttribution())),
+				CategoryID:   *categoryID,
+			}, nil); err != nil {
+				return utils.NewToolResultError(createDiscussionError(ctx, client, *categoryID, err).Error()), nil, nil
+			}
+
+			discussion := mutation.CreateDiscussion.Discussion
+			response := map[string]any{
+				"id":     fmt.Sprint(discussion.ID),
+				"number": int(discussion.Number),
+				"url":    string(discussion.URL),
+			}
+			// Echo the category actually used, which matters most when it was resolved by name
+			if discussion.Category != nil {
+				response["categoryId"] = fmt.Sprint(discussion.Category.ID)
+				response["categoryName"] = string(discussion.Category.Name)
+				// Only discussions in answerable (Q&A) categories can later have an answer marked
+				response["categoryIsAnswerable"] = bool(discussion.Category.IsAnswerable)
+			}
+			if params.FirstComment != "" {
+				// The discussion exists either way, so a failed comment must not hide it from the caller
+				comment, err := addDiscussionCommentToID(ctx, client, discussion.ID, attributeMutationBody(params.FirstComment, deps.GetMutationAttribution()), "")
+				if err != nil {
+					response["warning"] = fmt.Sprintf("the discussion was created, but posting first_comment failed: %v", err)
+				} else {
+					response["firstComment"] = comment
+				}
+			}
+			out, err := json.Marshal(response)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal create discussion response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+// categoryRequirementErrors are the messages, lowercased, GitHub fails createDiscussion with when
+// the category's requirements aren't met, and the requirement each one means.
+var categoryRequirementErrors = []struct {
+	message     string
+	requirement string
+}{
+	{"a poll is required to create a discussion in this category", "discussions in it must have a poll, and polls can't be created through the API; create the discussion on GitHub or use another category"},
+	{"body can't be blank", "discussions in it need a non-empty body"},
+	{"only maintainers can create discussions in this category", "only maintainers can start discussions in it, as in announcement categories"},
+}
+
+// createDiscussionError explains a createDiscussion error caused by a requirement of the category,
+// which GitHub reports without naming the category or saying how to comply. Other errors, such as
+// token scope or repository access failures, are returned unchanged.
+func createDiscussionError(ctx context.Context, client *githubv4.Client, categoryID githubv4.ID, err error) error {
+	msg := strings.ToLower(err.Error())
+	var requirement string
+	for _, e := range categoryRequirementErrors {
+		if strings.Contains(msg, e.message) {
+			requirement = e.requirement
+			break
+		}
+	}
+	if requirement == "" {
+		return err
+	}
+
+	category := "the category"
+	var q struct {
+		Node struct {
+			DiscussionCategory struct {
+				Name         githubv4.String
+				IsAnswerable githubv4.Boolean
+			} `graphql:"... on DiscussionCategory"`
+		} `graphql:"node(id: $id)"`
+	}
+	// The hint is still useful without the category's name, so a failed lookup is ignored
+	if lookupErr := queryWithRequestID(ctx, client, &q, map[string]any{"id": categoryID}); lookupErr == nil && q.Node.DiscussionCategory.Name != "" {
+		category = fmt.Sprintf("category %q", string(q.Node.DiscussionCategory.Name))
+		if q.Node.DiscussionCategory.IsAnswerable {
+			category += " (Q&A)"
+		}
+	}
+	return fmt.Errorf("%w: %s can't be used as given, because %s", err, category, requirement)
+}
+
+func UpdateDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "update_discussion",
+			Description: t("TOOL_UPDATE_DISCUSSION_DESCRIPTION", "Update a discussion (title/body/category) in a repository."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_UPDATE_DISCUSSION_USER_TITLE", "Update discussion"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: WithDiscussionURL(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+					"title": {
+						Type:        "string",
+						Description: "New discussion title (optional)",
+					},
+					"body": {
+						Type:        "string",
+						Description: "New discussion body (optional)",
+					},
+					"appendBody": {
+						Type:        "string",
+						Description: "Text to append to the existing discussion body (optional). Cannot be combined with 'body'.",
+					},
+					"category_id": {
+						Type:        "string",
+						Description: "New discussion category node ID (optional). If provided, this is used directly.",
+					},
+					"category_name": {
+						Type:        "string",
+						Description: "New discussion category name (optional). If provided, it will be resolved to a category ID.",
+					},
+				},
+			}),
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			if err := applyDiscussionURL(args); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			var params struct {
+				Owner            string
+				Repo             string
+				DiscussionNumber int32
+				Title            string
+				Body             string
+				AppendBody       string
+				CategoryID       string `mapstructure:"category_id"`
+				CategoryName     string `mapstructure:"category_name"`
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			if params.Title == "" && params.Body == "" && params.AppendBody == "" && params.CategoryID == "" && params.CategoryName == "" {
+				return utils.NewToolResultError("at least one of title, body, appendBody, category_id, or category_name must be provided"), nil, nil
+			}
+			if params.Body != "" && params.AppendBody != "" {
+				return utils.NewToolResultError("body and appendBody are mutually exclusive"), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var discussionID githubv4.ID
+			if params.AppendBody != "" {
+				// Fetch the current body alongside the ID so the new text is appended rather than replacing it.
+				var existing string
+				discussionID, existing, err = getDiscussionIDAndBody(ctx, client, params.Owner, params.Repo, params.DiscussionNumber)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				params.Body = appendDiscussionBody(existing, params.AppendBody)
+			} else {
+				discussionID, err = getDiscussionID(ctx, client, params.Owner, params.Repo, params.DiscussionNumber)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+			}
+
+			var title *githubv4.String
+			if params.Title != "" {
+				s := githubv4.String(params.Title)
+				title = &s
+			}
+			var body *githubv4.String
+			if params.Body != "" {
+				s := githubv4.String(params.Body)
+				body = &s
+			}
+
+			var categoryID *githubv4.ID
+			if params.CategoryID != "" || params.CategoryName != "" {
+				resolved, err := resolveDiscussionCategoryID(ctx, deps.GetDiscussionCategoryCache(), client, params.Owner, params.Repo, params.CategoryID, params.CategoryName)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				categoryID = resolved
+			}
+
+			var mutation struct {
+				UpdateDiscussion struct {
+					Discussion struct {
+						ID       githubv4.ID
+						Number   githubv4.Int
+						URL      githubv4.String `graphql:"url"`
+						Category *struct {
+							ID   githubv4.ID
+							Name githubv4.String
+						}
+					}
+				} `graphql:"updateDiscussion(input: $input)"`
+			}
+
+			input := githubv4.UpdateDiscussionInput{
+				DiscussionID: discussionID,
+				Title:        title,
+				Body:         body,
+				CategoryID:   categoryID,
+			}
+
+			if err := mutateWithRequestID(ctx, client, &mutation, input, nil); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			discussion := mutation.UpdateDiscussion.Discussion
+			response := map[string]any{
+				"id":     fmt.Sprint(discussion.ID),
+				"number": int(discussion.Number),
+				"url":    string(discussion.URL),
+			}
+			// Echo the category actually used, which matters most when it was resolved by name
+			if discussion.Category != nil {
+				response["categoryId"] = fmt.Sprint(discussion.Category.ID)
+				response["categoryName"] = string(discussion.Category.Name)
+			}
+			out, err := json.Marshal(response)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal update discussion response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func AddDiscussionComment(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "add_discussion_comment",
+			Description: t("TOOL_ADD_DISCUSSION_COMMENT_DESCRIPTION", "Add a comment to a discussion."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_ADD_DISCUSSION_COMMENT_USER_TITLE", "Add discussion comment"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: WithDiscussionURL(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+					"body": {
+						Type:        "string",
+						Description: "Comment body (Markdown)",
+					},
+					"reply_to_id": {
+						Type:        "string",
+						Description: "Optional discussion comment node ID to reply to.",
+					},
+				},
+				Required: []string{"body"},
+			}),
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			if err := applyDiscussionURL(args); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			var params struct {
+				Owner            string
+				Repo             string
+				DiscussionNumber int32
+				Body             string
+				ReplyToID        string `mapstructure:"reply_to_id"`
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			comment, err := addDiscussionComment(ctx, client, params.Owner, params.Repo, params.DiscussionNumber, attributeMutationBody(params.Body, deps.GetMutationAttribution()), params.ReplyToID)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			out, err := json.Marshal(comment)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal add discussion comment response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func AddDiscussionComments(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "add_discussion_comments",
+			Description: t("TOOL_ADD_DISCUSSION_COMMENTS_DESCRIPTION", "Add the same comment to several discussions. Each discussion has its own result with a status, so some can fail without failing the others."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_ADD_DISCUSSION_COMMENTS_USER_TITLE", "Add discussion comments"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumbers": {
+						Type:        "array",
+						Description: fmt.Sprintf("Discussion numbers (at most %d)", maxBatchDiscussions),
+						Items: &jsonschema.Schema{
+							Type: "number",
+						},
+						MinItems: jsonschema.Ptr(1),
+						MaxItems: jsonschema.Ptr(maxBatchDiscussions),
+					},
+					"body": {
+						Type:        "string",
+						Description: "Comment body (Markdown)",
+					},
+					"reply_to_id": {
+						Type:        "string",
+						Description: "Optional discussion comment node ID to reply to. Only valid with a single discussion number.",
+					},
+				},
+				Required: []string{"owner", "repo", "discussionNumbers", "body"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				Owner             string
+				Repo              string
+				DiscussionNumbers []int32
+				Body              string
+				ReplyToID         string `mapstructure:"reply_to_id"`
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if len(params.DiscussionNumbers) == 0 {
+				return utils.NewToolResultError("discussionNumbers must not be empty"), nil, nil
+			}
+			if len(params.DiscussionNumbers) > maxBatchDiscussions {
+				return utils.NewToolResultError(fmt.Sprintf("at most %d discussions can be commented on at once", maxBatchDiscussions)), nil, nil
+			}
+			if params.ReplyToID != "" && len(params.DiscussionNumbers) > 1 {
+				return utils.NewToolResultError(fmt.Sprintf("reply_to_id can only be used with a single discussion number, since the comment it replies to belongs to one discussion; got %d discussion numbers", len(params.DiscussionNumbers))), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			body := attributeMutationBody(params.Body, deps.GetMutationAttribution())
+			results := make([]batchItemResult, 0, len(params.DiscussionNumbers))
+			for _, number := range params.DiscussionNumbers {
+				comment, err := addDiscussionComment(ctx, client, params.Owner, params.Repo, number, body, params.ReplyToID)
+				if err != nil {
+					results = append(results, batchItemError(int(number), err))
+					continue
+				}
+				results = append(results, batchItemOK(int(number), comment))
+			}
+
+			out, err := json.Marshal(batchResponse(results))
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal add discussion comments response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+// addDiscussionComment adds a comment to a discussion, as a reply to replyToID if it is set, and
+// returns the new comment's id and url.
+func addDiscussionComment(ctx context.Context, client *githubv4.Client, owner, repo string, discussionNumber int32, body, replyToID string) (map[string]any, error) {
+	discussionID, err := getDiscussionID(ctx, client, owner, repo, discussionNumber)
+	if err != nil {
+		return nil, err
+	}
+	return addDiscussionCommentToID(ctx, client, discussionID, body, replyToID)
+}
+
+// addDiscussionCommentToID is addDiscussionComment for a discussion whose node ID is already known.
+func addDiscussionCommentToID(ctx context.Context, client *githubv4.Client, discussionID githubv4.ID, body, replyToID string) (map[string]any, error) {
+	var replyTo *githubv4.ID
+	if replyToID != "" {
+		id := githubv4.ID(replyToID)
+		replyTo = &id
+	}
+
+	var mutation struct {
+		AddDiscussionComment struct {
+			Comment struct {
+				ID  githubv4.ID
+				URL githubv4.String `graphql:"url"`
+			}
+		} `graphql:"addDiscussionComment(input: $input)"`
+	}
+	if err := mutateWithRequestID(ctx, client, &mutation, githubv4.AddDiscussionCommentInput{
+		DiscussionID: discussionID,
+		Body:         githubv4.String(body),
+		ReplyToID:    replyTo,
+	}, nil); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"id":  fmt.Sprint(mutation.AddDiscussionComment.Comment.ID),
+		"url": string(mutation.AddDiscussionComment.Comment.URL),
+	}, nil
+}
+
+func UpdateDiscussionComment(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "update_discussion_comment",
+			Description: t("TOOL_UPDATE_DISCUSSION_COMMENT_DESCRIPTION", "Update an existing discussion comment."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_UPDATE_DISCUSSION_COMMENT_USER_TITLE", "Update discussion comment"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"comment_id": {
+						Type:        "string",
+						Description: "Discussion comment node ID",
+					},
+					"body": {
+						Type:        "string",
+						Description: "New comment body (Markdown)",
+					},
+				},
+				Required: []string{"comment_id", "body"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				CommentID string `mapstructure:"comment_id"`
+				Body      string
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var mutation struct {
+				UpdateDiscussionComment struct {
+					Comment struct {
+						ID  githubv4.ID
+						URL githubv4.String `graphql:"url"`
+					}
+				} `graphql:"updateDiscussionComment(input: $input)"`
+			}
+
+			if err := mutateWithRequestID(ctx, client, &mutation, githubv4.UpdateDiscussionCommentInput{
+				CommentID: githubv4.ID(params.CommentID),
+				Body:      githubv4.String(params.Body),
+			}, nil); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			out, err := json.Marshal(map[string]any{
+				"id":  fmt.Sprint(mutation.UpdateDiscussionComment.Comment.ID),
+				"url": string(mutation.UpdateDiscussionComment.Comment.URL),
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal update discussion comment response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func DeleteDiscussionComment(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "delete_discussion_comment",
+			Description: t("TOOL_DELETE_DISCUSSION_COMMENT_DESCRIPTION", "Delete an existing discussion comment."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_DELETE_DISCUSSION_COMMENT_USER_TITLE", "Delete discussion comment"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"comment_id": {
+						Type:        "string",
+						Description: "Discussion comment node ID",
+					},
+				},
+				Required: []string{"comment_id"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				CommentID string `mapstructure:"comment_id"`
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var mutation struct {
+				DeleteDiscussionComment struct {
+					ClientMutationID githubv4.String
+				} `graphql:"deleteDiscussionComment(input: $input)"`
+			}
+
+			if err := mutateWithRequestID(ctx, client, &mutation, githubv4.DeleteDiscussionCommentInput{
+				ID: githubv4.ID(params.CommentID),
+			}, nil); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			return utils.NewToolResultText("discussion comment deleted successfully"), nil, nil
+		},
+	)
+}
+
+func MarkDiscussionCommentAsAnswer(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "mark_discussion_comment_as_answer",
+			Description: t("TOOL_MARK_DISCUSSION_COMMENT_AS_ANSWER_DESCRIPTION", "Mark a discussion comment as the accepted answer of its discussion. Only discussions in a Q&A (answerable) category can have an answer."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_MARK_DISCUSSION_COMMENT_AS_ANSWER_USER_TITLE", "Mark discussion comment as answer"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"comment_id": {
+						Type:        "string",
+						Description: "Discussion comment node ID",
+					},
+				},
+				Required: []string{"comment_id"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				CommentID string `mapstructure:"comment_id"`
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if params.CommentID == "" {
+				return utils.NewToolResultError("missing required parameter: comment_id"), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			result, err := markDiscussionCommentAsAnswer(ctx, client, githubv4.ID(params.CommentID))
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			out, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal answer: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func UnmarkDiscussionCommentAsAnswer(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "unmark_discussion_comment_as_answer",
+			Description: t("TOOL_UNMARK_DISCUSSION_COMMENT_AS_ANSWER_DESCRIPTION", "Unmark a discussion comment as the accepted answer of its discussion."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_UNMARK_DISCUSSION_COMMENT_AS_ANSWER_USER_TITLE", "Unmark discussion comment as answer"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"comment_id": {
+						Type:        "string",
+						Description: "Discussion comment node ID",
+					},
+				},
+				Required: []string{"comment_id"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				CommentID string `mapstructure:"comment_id"`
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if params.CommentID == "" {
+				return utils.NewToolResultError("missing required parameter: comment_id"), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var mutation struct {
+				UnmarkDiscussionCommentAsAnswer struct {
+					Discussion struct {
+						Number     githubv4.Int
+						IsAnswered githubv4.Boolean
+					}
+				} `graphql:"unmarkDiscussionCommentAsAnswer(input: $input)"`
+			}
+			if err := mutateWithRequestID(ctx, client, &mutation, githubv4.UnmarkDiscussionCommentAsAnswerInput{
+				ID: githubv4.ID(params.CommentID),
+			}, nil); err != nil {
+				return utils.NewToolResultError(discussionAnswerError(err).Error()), nil, nil
+			}
+
+			discussion := mutation.UnmarkDiscussionCommentAsAnswer.Discussion
+			out, err := json.Marshal(map[string]any{
+				"discussionNumber": int(discussion.Number),
+				"isAnswered":       bool(discussion.IsAnswered),
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal answer: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+// discussionCommentMinimizeClassifiers are the reasons minimize_discussion_comment accepts.
+var discussionCommentMinimizeClassifiers = []githubv4.ReportedContentClassifiers{
+	githubv4.ReportedContentClassifiersAbuse,
+	githubv4.ReportedContentClassifiersOffTopic,
+	githubv4.ReportedContentClassifiersOutdated,
+	githubv4.ReportedContentClassifiersResolved,
+	githubv4.ReportedContentClassifiersDuplicate,
+	githubv4.ReportedContentClassifiersSpam,
+}
+
+// minimizedComment is the state of a comment after it was minimized or unminimized.
+type minimizedComment struct {
+	IsMinimized     githubv4.Boolean
+	MinimizedReason *githubv4.String
+}
+
+func (c minimizedComment) toMap(commentID string) map[string]any {
+	m := map[string]any{
+		"commentId":       commentID,
+		"isMinimized":     bool(c.IsMinimized),
+		"minimizedReason": nil,
+	}
+	if c.MinimizedReason != nil {
+		m["minimizedReason"] = string(*c.MinimizedReason)
+	}
+	return m
+}
+
+func MinimizeDiscussionComment(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "minimize_discussion_comment",
+			Description: t("TOOL_MINIMIZE_DISCUSSION_COMMENT_DESCRIPTION", "Minimize (hide) a discussion comment, such as an off-topic or spam comment, with the reason it is hidden."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_MINIMIZE_DISCUSSION_COMMENT_USER_TITLE", "Minimize discussion comment"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"comment_id": {
+						Type:        "string",
+						Description: "Discussion comment node ID",
+					},
+					"classifier": {
+						Type:        "string",
+						Description: "Reason for minimizing the comment",
+						Enum:        []any{"ABUSE", "OFF_TOPIC", "OUTDATED", "RESOLVED", "DUPLICATE", "SPAM"},
+					},
+				},
+				Required: []string{"comment_id", "classifier"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				CommentID  string `mapstructure:"comment_id"`
+				Classifier string
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if params.CommentID == "" {
+				return utils.NewToolResultError("missing required parameter: comment_id"), nil, nil
+			}
+			classifier := githubv4.ReportedContentClassifiers(params.Classifier)
+			if !slices.Contains(discussionCommentMinimizeClassifiers, classifier) {
+				return utils.NewToolResultError(fmt.Sprintf("invalid classifier %q: must be one of ABUSE, OFF_TOPIC, OUTDATED, RESOLVED, DUPLICATE, SPAM", params.Classifier)), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var mutation struct {
+				MinimizeComment struct {
+					MinimizedComment minimizedComment
+				} `graphql:"minimizeComment(input: $input)"`
+			}
+			if err := mutateWithRequestID(ctx, client, &mutation, githubv4.MinimizeCommentInput{
+				SubjectID:  githubv4.ID(params.CommentID),
+				Classifier: classifier,
+			}, nil); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			out, err := json.Marshal(mutation.MinimizeComment.MinimizedComment.toMap(params.CommentID))
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal minimize discussion comment response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func UnminimizeDiscussionComment(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "unminimize_discussion_comment",
+			Description: t("TOOL_UNMINIMIZE_DISCUSSION_COMMENT_DESCRIPTION", "Unminimize a minimized discussion comment, so it is shown again."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_UNMINIMIZE_DISCUSSION_COMMENT_USER_TITLE", "Unminimize discussion comment"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"comment_id": {
+						Type:        "string",
+						Description: "Discussion comment node ID",
+					},
+				},
+				Required: []string{"comment_id"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				CommentID string `mapstructure:"comment_id"`
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if params.CommentID == "" {
+				return utils.NewToolResultError("missing required parameter: comment_id"), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var mutation struct {
+				UnminimizeComment struct {
+					UnminimizedComment minimizedComment
+				} `graphql:"unminimizeComment(input: $input)"`
+			}
+			if err := mutateWithRequestID(ctx, client, &mutation, githubv4.UnminimizeCommentInput{
+				SubjectID: githubv4.ID(params.CommentID),
+			}, nil); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			out, err := json.Marshal(mutation.UnminimizeComment.UnminimizedComment.toMap(params.CommentID))
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal unminimize discussion comment response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+// pollVoteErrorMessage turns GitHub's error for voting on a poll more than once into a readable
+// message. Other errors are returned as they are.
+func pollVoteErrorMessage(err error) string {
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, "already voted") || (strings.Contains(msg, "vote") && strings.Contains(msg, "already")) {
+		return "you have already voted on this poll: GitHub doesn't allow changing or adding a vote"
+	}
+	return err.Error()
+}
+
+func VoteOnDiscussionPoll(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "vote_on_discussion_poll",
+			Description: t("TOOL_VOTE_ON_DISCUSSION_POLL_DESCRIPTION", "Vote for an option of a discussion poll, returning the poll's updated vote counts. Option IDs are listed by get_discussion with includePoll. A poll can only be voted on once."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_VOTE_ON_DISCUSSION_POLL_USER_TITLE", "Vote on discussion poll"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"poll_option_id": {
+						Type:        "string",
+						Description: "Node ID of the poll option to vote for",
+					},
+				},
+				Required: []string{"poll_option_id"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			optionID, err := RequiredParam[string](args, "poll_option_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var mutation struct {
+				AddDiscussionPollVote struct {
+					PollOption struct {
+						ID   githubv4.ID
+						Poll discussionPollFragment
+					}
+				} `graphql:"addDiscussionPollVote(input: $input)"`
+			}
+			if err := mutateWithRequestID(ctx, client, &mutation, githubv4.AddDiscussionPollVoteInput{
+				PollOptionID: githubv4.ID(optionID),
+			}, nil); err != nil {
+				return utils.NewToolResultError(pollVoteErrorMessage(err)), nil, nil
+			}
+
+			out, err := json.Marshal(map[string]any{
+				"pollOptionId": fmt.Sprint(mutation.AddDiscussionPollVote.PollOption.ID),
+				"poll":         mutation.AddDiscussionPollVote.PollOption.Poll.toMap(),
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal poll vote response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func MarkDiscussionAnswerByText(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "mark_discussion_answer_by_text",
+			Description: t("TOOL_MARK_DISCUSSION_ANSWER_BY_TEXT_DESCRIPTION", "Mark the discussion comment whose body contains bodyMatch as the accepted answer, for when the comment node ID isn't known. Fails unless exactly one top-level comment matches, so it also fails for discussions with more than 1000 comments, as the rest can't be checked."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_MARK_DISCUSSION_ANSWER_BY_TEXT_USER_TITLE", "Mark discussion answer by text"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+					"bodyMatch": {
+						Type:        "string",
+						Description: "Text that appears in the body of the comment to mark as the answer, matched case-insensitively like search_discussion_comments",
+					},
+				},
+				Required: []string{"owner", "repo", "discussionNumber", "bodyMatch"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				Owner            string
+				Repo             string
+				DiscussionNumber int32
+				BodyMatch        string
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if params.BodyMatch == "" {
+				return utils.NewToolResultError("missing required parameter: bodyMatch"), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			type matchedComment struct {
+				ID  githubv4.ID
+				URL string
+			}
+			needle := strings.ToLower(params.BodyMatch)
+			var matches []matchedComment
+			truncated, err := scanPages(ctx, discussionScanMaxPages, func(after *githubv4.String) (PageInfoFragment, bool, error) {
+				var q struct {
+					Repository struct {
+						Discussion struct {
+							Comments struct {
+								Nodes []struct {
+									ID   githubv4.ID
+									Body githubv4.String
+									URL  githubv4.String `graphql:"url"`
+								}
+								PageInfo PageInfoFragment
+							} `graphql:"comments(first: $first, after: $after)"`
+						} `graphql:"discussion(number: $discussionNumber)"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}
+				vars := map[string]any{
+					"owner":            githubv4.String(params.Owner),
+					"repo":             githubv4.String(params.Repo),
+					"discussionNumber": githubv4.Int(params.DiscussionNumber),
+					"first":            githubv4.Int(discussionScanPageSize),
+					"after":            after,
+				}
+				if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+					return PageInfoFragment{}, false, err
+				}
+				for _, c := range q.Repository.Discussion.Comments.Nodes {
+					if strings.Contains(strings.ToLower(string(c.Body)), needle) {
+						matches = append(matches, matchedComment{ID: c.ID, URL: string(c.URL)})
+					}
+				}
+				return q.Repository.Discussion.Comments.PageInfo, false, nil
+			})
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			// A match is only known to be unique when every comment was checked
+			if truncated {
+				scanned := discussionScanMaxPages * discussionScanPageSize
+				return utils.NewToolResultError(fmt.Sprintf("discussion #%d in %s/%s has more than %d comments, and comments after the first %d weren't checked, so a match can't be known to be unique; use mark_discussion_comment_as_answer with the comment ID", params.DiscussionNumber, params.Owner, params.Repo, scanned, scanned)), nil, nil
+			}
+			if len(matches) == 0 {
+				return utils.NewToolResultError(fmt.Sprintf("no comment on discussion #%d in %s/%s contains %q", params.DiscussionNumber, params.Owner, params.Repo, params.BodyMatch)), nil, nil
+			}
+			if len(matches) > 1 {
+				urls := make([]string, 0, len(matches))
+				for _, m := range matches {
+					urls = append(urls, m.URL)
+				}
+				return utils.NewToolResultError(fmt.Sprintf("%d comments on discussion #%d in %s/%s contain %q; use a more specific bodyMatch or mark_discussion_comment_as_answer with the comment ID: %s", len(matches), params.DiscussionNumber, params.Owner, params.Repo, params.BodyMatch, strings.Join(urls, ", "))), nil, nil
+			}
+
+			result, err := markDiscussionCommentAsAnswer(ctx, client, matches[0].ID)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			result["commentId"] = fmt.Sprint(matches[0].ID)
+			result["commentUrl"] = matches[0].URL
+
+			out, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal answer: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+// markDiscussionCommentAsAnswer marks a comment as the answer of its discussion, returning the
+// discussion number and when the answer was chosen.
+func markDiscussionCommentAsAnswer(ctx context.Context, client *githubv4.Client, commentID githubv4.ID) (map[string]any, error) {
+	var mutation struct {
+		MarkDiscussionCommentAsAnswer struct {
+			Discussion struct {
+				Number         githubv4.Int
+				AnswerChosenAt *githubv4.DateTime
+			}
+		} `graphql:"markDiscussionCommentAsAnswer(input: $input)"`
+	}
+	if err := mutateWithRequestID(ctx, client, &mutation, githubv4.MarkDiscussionCommentAsAnswerInput{
+		ID: commentID,
+	}, nil); err != nil {
+		return nil, discussionAnswerError(err)
+	}
+
+	discussion := mutation.MarkDiscussionCommentAsAnswer.Discussion
+	return map[string]any{
+		"discussionNumber": int(discussion.Number),
+		"answerChosenAt":   optionalDateTime(discussion.AnswerChosenAt),
+	}, nil
+}
+
+// discussionAnswerError explains an answer mutation error caused by the discussion not being in
+// a Q&A category, which GitHub reports without saying how to fix it.
+func discussionAnswerError(err error) error {
+	if strings.Contains(strings.ToLower(err.Error()), "answerable") {
+		return fmt.Errorf("%w; only discussions in a Q&A category can have an answer, use list_discussion_categories to find one with isAnswerable true", err)
+	}
+	return err
+}
+
+// discussionCloseReasons are the close reasons close_discussion accepts.
+var discussionCloseReasons = []githubv4.DiscussionCloseReason{
+	githubv4.DiscussionCloseReasonResolved,
+	githubv4.DiscussionCloseReasonOutdated,
+	githubv4.DiscussionCloseReasonDuplicate,
+}
+
+func CloseDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "close_discussion",
+			Description: t("TOOL_CLOSE_DISCUSSION_DESCRIPTION", "Close a discussion with a reason."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_CLOSE_DISCUSSION_USER_TITLE", "Close discussion"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+					"reason": {
+						Type:        "string",
+						Description: "Reason for closing the discussion. Defaults to RESOLVED.",
+						Enum:        []any{"RESOLVED", "OUTDATED", "DUPLICATE"},
+					},
+				},
+				Required: []string{"owner", "repo", "discussionNumber"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				Owner            string
+				Repo             string
+				DiscussionNumber int32
+				Reason           string
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if params.Reason == "" {
+				params.Reason = string(githubv4.DiscussionCloseReasonResolved)
+			}
+			reason := githubv4.DiscussionCloseReason(params.Reason)
+			if !slices.Contains(discussionCloseReasons, reason) {
+				return utils.NewToolResultError(fmt.Sprintf("invalid reason %q: must be one of RESOLVED, OUTDATED, DUPLICATE", params.Reason)), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			discussionID, err := getDiscussionID(ctx, client, params.Owner, params.Repo, params.DiscussionNumber)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			var mutation struct {
+				CloseDiscussion struct {
+					Discussion struct {
+						Number githubv4.Int
+						URL    githubv4.String `graphql:"url"`
+						Closed githubv4.Boolean
+					}
+				} `graphql:"closeDiscussion(input: $input)"`
+			}
+			if err := mutateWithRequestID(ctx, client, &mutation, githubv4.CloseDiscussionInput{
+				DiscussionID: discussionID,
+				Reason:       &reason,
+			}, nil); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			discussion := mutation.CloseDiscussion.Discussion
+			out, err := json.Marshal(map[string]any{
+				"number": int(discussion.Number),
+				"url":    string(discussion.URL),
+				"closed": bool(discussion.Closed),
+				"reason": params.Reason,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal close discussion response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+// discussionLockReasons are the lock reasons lock_discussion accepts.
+var discussionLockReasons = []githubv4.LockReason{
+	githubv4.LockReasonOffTopic,
+	githubv4.LockReasonTooHeated,
+	githubv4.LockReasonResolved,
+	githubv4.LockReasonSpam,
+}
+
+func LockDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "lock_discussion",
+			Description: t("TOOL_LOCK_DISCUSSION_DESCRIPTION", "Lock a discussion, so only collaborators can comment on it."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LOCK_DISCUSSION_USER_TITLE", "Lock discussion"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+					"lockReason": {
+						Type:        "string",
+						Description: "Reason for locking the discussion",
+						Enum:        []any{"OFF_TOPIC", "TOO_HEATED", "RESOLVED", "SPAM"},
+					},
+				},
+				Required: []string{"owner", "repo", "discussionNumber"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				Owner            string
+				Repo             string
+				DiscussionNumber int32
+				LockReason       string
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			var lockReason *githubv4.LockReason
+			if params.LockReason != "" {
+				reason := githubv4.LockReason(params.LockReason)
+				if !slices.Contains(discussionLockReasons, reason) {
+					return utils.NewToolResultError(fmt.Sprintf("invalid lockReason %q: must be one of OFF_TOPIC, TOO_HEATED, RESOLVED, SPAM", params.LockReason)), nil, nil
+				}
+				lockReason = &reason
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			discussionID, err := getDiscussionID(ctx, client, params.Owner, params.Repo, params.DiscussionNumber)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			var mutation struct {
+				LockLockable struct {
+					LockedRecord struct {
+						Locked githubv4.Boolean
+					}
+				} `graphql:"lockLockable(input: $input)"`
+			}
+			if err := mutateWithRequestID(ctx, client, &mutation, githubv4.LockLockableInput{
+				LockableID: discussionID,
+				LockReason: lockReason,
+			}, nil); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			out, err := json.Marshal(map[string]any{
+				"number": int(params.DiscussionNumber),
+				"locked": bool(mutation.LockLockable.LockedRecord.Locked),
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal lock discussion response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func UnlockDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "unlock_discussion",
+			Description: t("TOOL_UNLOCK_DISCUSSION_DESCRIPTION", "Unlock a locked discussion, so anyone can comment on it again."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_UNLOCK_DISCUSSION_USER_TITLE", "Unlock discussion"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+				},
+				Required: []string{"owner", "repo", "discussionNumber"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				Owner            string
+				Repo             string
+				DiscussionNumber int32
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			discussionID, err := getDiscussionID(ctx, client, params.Owner, params.Repo, params.DiscussionNumber)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			var mutation struct {
+				UnlockLockable struct {
+					UnlockedRecord struct {
+						Locked githubv4.Boolean
+					}
+				} `graphql:"unlockLockable(input: $input)"`
+			}
+			if err := mutateWithRequestID(ctx, client, &mutation, githubv4.UnlockLockableInput{
+				LockableID: discussionID,
+			}, nil); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			out, err := json.Marshal(map[string]any{
+				"number": int(params.DiscussionNumber),
+				"locked": bool(mutation.UnlockLockable.UnlockedRecord.Locked),
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal unlock discussion response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+// discussionDeleteConfirmationToken returns the token delete_discussion issues for a discussion
+// and requires back before deleting it. It guards against accidental deletion, not forgery.
+func discussionDeleteConfirmationToken(owner, repo string, discussionNumber int32) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(fmt.Sprintf("%s/%s#%d", owner, repo, discussionNumber))))
+	return hex.EncodeToString(sum[:16])
+}
+
+func DeleteDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "delete_discussion",
+			Description: t("TOOL_DELETE_DISCUSSION_DESCRIPTION", "Delete a discussion. This is irreversible, so it takes two calls: the first, without confirmationToken, deletes nothing and returns a confirmationToken; the second, passing that token back, deletes the discussion."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_DELETE_DISCUSSION_USER_TITLE", "Delete discussion"),
+				ReadOnlyHint:    false,
+				DestructiveHint: ToBoolPtr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+					"confirmationToken": {
+						Type:        "string",
+						Description: "The confirmationToken returned by a previous call for this discussion. Omit it to get one.",
+					},
+				},
+				Required: []string{"owner", "repo", "discussionNumber"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				Owner             string
+				Repo              string
+				DiscussionNumber  int32
+				ConfirmationToken string
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			token := discussionDeleteConfirmationToken(params.Owner, params.Repo, params.DiscussionNumber)
+			if params.ConfirmationToken == "" {
+				// Check the discussion exists, so a token is only issued for something that can be deleted
+				if _, err := getDiscussionID(ctx, client, params.Owner, params.Repo, params.DiscussionNumber); err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				out, err := json.Marshal(map[string]any{
+					"deleted":           false,
+					"confirmationToken": token,
+					"message":           fmt.Sprintf("nothing was deleted; call delete_discussion again with this confirmationToken to permanently delete discussion #%d in %s/%s", params.DiscussionNumber, params.Owner, params.Repo),
+				})
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal delete discussion response: %w", err)
+				}
+				return utils.NewToolResultText(string(out)), nil, nil
+			}
+			if params.ConfirmationToken != token {
+				return utils.NewToolResultError(fmt.Sprintf("confirmationToken does not match discussion #%d in %s/%s; call without confirmationToken to get one", params.DiscussionNumber, params.Owner, params.Repo)), nil, nil
+			}
+
+			discussionID, err := getDiscussionID(ctx, client, params.Owner, params.Repo, params.DiscussionNumber)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			var mutation struct {
+				DeleteDiscussion struct {
+					ClientMutationID githubv4.String
+				} `graphql:"deleteDiscussion(input: $input)"`
+			}
+			if err := mutateWithRequestID(ctx, client, &mutation, githubv4.DeleteDiscussionInput{
+				ID: discussionID,
+			}, nil); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			out, err := json.Marshal(map[string]any{
+				"deleted":          true,
+				"discussionNumber": params.DiscussionNumber,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal delete discussion response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+// discussionReactionContents is the set of reaction contents accepted by GitHub's addReaction mutation.
+var discussionReactionContents = []any{"THUMBS_UP", "THUMBS_DOWN", "LAUGH", "HOORAY", "CONFUSED", "HEART", "ROCKET", "EYES"}
+
+func ReactToDiscussionAnswer(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "react_to_discussion_answer",
+			Description: t("TOOL_REACT_TO_DISCUSSION_ANSWER_DESCRIPTION", "Add a reaction to the accepted answer of a discussion."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_REACT_TO_DISCUSSION_ANSWER_USER_TITLE", "React to discussion answer"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+					"content": {
+						Type:        "string",
+						Description: "Reaction content",
+						Enum:        discussionReactionContents,
+					},
+				},
+				Required: []string{"owner", "repo", "discussionNumber", "content"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				Owner            string
+				Repo             string
+				DiscussionNumber int32
+				Content          string
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var q struct {
+				Repository struct {
+					Discussion struct {
+						Answer *struct {
+							ID  githubv4.ID
+							URL githubv4.String `graphql:"url"`
+						}
+					} `graphql:"discussion(number: $discussionNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner":            githubv4.String(params.Owner),
+				"repo":             githubv4.String(params.Repo),
+				"discussionNumber": githubv4.Int(params.DiscussionNumber),
+			}
+			if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			answer := q.Repository.Discussion.Answer
+			if answer == nil {
+				return utils.NewToolResultError(fmt.Sprintf("discussion #%d in %s/%s has no accepted answer to react to", params.DiscussionNumber, params.Owner, params.Repo)), nil, nil
+			}
+
+			var mutation struct {
+				AddReaction struct {
+					Reaction struct {
+						ID      githubv4.ID
+						Content githubv4.ReactionContent
+					}
+				} `graphql:"addReaction(input: $input)"`
+			}
+			if err := mutateWithRequestID(ctx, client, &mutation, githubv4.AddReactionInput{
+				SubjectID: answer.ID,
+				Content:   githubv4.ReactionContent(params.Content),
+			}, nil); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			out, err := json.Marshal(map[string]any{
+				"reactionId": fmt.Sprint(mutation.AddReaction.Reaction.ID),
+				"content":    string(mutation.AddReaction.Reaction.Content),
+				"commentId":  fmt.Sprint(answer.ID),
+				"url":        string(answer.URL),
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal react to discussion answer response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func AddDiscussionReaction(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "add_discussion_reaction",
+			Description: t("TOOL_ADD_DISCUSSION_REACTION_DESCRIPTION", "Add a reaction to a discussion or discussion comment, by its node ID. Use remove_discussion_reaction with the same subject_id to undo it."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_ADD_DISCUSSION_REACTION_USER_TITLE", "Add discussion reaction"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"subject_id": {
+						Type:        "string",
+						Description: "Node ID of the discussion or discussion comment to react to",
+					},
+					"content": {
+						Type:        "string",
+						Description: "Reaction content",
+						Enum:        discussionReactionContents,
+					},
+				},
+				Required: []string{"subject_id", "content"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			subjectID, err := RequiredParam[string](args, "subject_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			content, err := RequiredParam[string](args, "content")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var mutation struct {
+				AddReaction struct {
+					Reaction struct {
+						ID      githubv4.ID
+						Content githubv4.ReactionContent
+					}
+					Subject struct {
+						Reactions struct {
+							TotalCount githubv4.Int
+						}
+					}
+				} `graphql:"addReaction(input: $input)"`
+			}
+			if err := mutateWithRequestID(ctx, client, &mutation, githubv4.AddReactionInput{
+				SubjectID: githubv4.ID(subjectID),
+				Content:   githubv4.ReactionContent(content),
+			}, nil); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			out, err := json.Marshal(map[string]any{
+				"reactionId":    fmt.Sprint(mutation.AddReaction.Reaction.ID),
+				"content":       string(mutation.AddReaction.Reaction.Content),
+				"subjectId":     subjectID,
+				"reactionCount": int(mutation.AddReaction.Subject.Reactions.TotalCount),
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal add discussion reaction response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+// discussionReactionGroup is a reaction content and whether the viewer has reacted with it.
+type discussionReactionGroup struct {
+	Content          githubv4.ReactionContent
+	ViewerHasReacted githubv4.Boolean
+}
+
+func RemoveDiscussionReaction(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "remove_discussion_reaction",
+			Description: t("TOOL_REMOVE_DISCUSSION_REACTION_DESCRIPTION", "Remove the authenticated user's reaction from a discussion, given by owner, repo and discussionNumber, or from a discussion or discussion comment given by subject_id. This is a no-op, reported as removed: false, if the user hasn't reacted with that content."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_REMOVE_DISCUSSION_REACTION_USER_TITLE", "Remove discussion reaction"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+					"subject_id": {
+						Type:        "string",
+						Description: "Node ID of the discussion or discussion comment to remove the reaction from, instead of owner, repo and discussionNumber",
+					},
+					"content": {
+						Type:        "string",
+						Description: "Reaction content",
+						Enum:        discussionReactionContents,
+					},
+				},
+				Required: []string{"content"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				Owner            string
+				Repo             string
+				DiscussionNumber int32
+				SubjectID        string `mapstructure:"subject_id"`
+				Content          string
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if params.Content == "" {
+				return utils.NewToolResultError("missing required parameter: content"), nil, nil
+			}
+			if params.SubjectID == "" && (params.Owner == "" || params.Repo == "" || params.DiscussionNumber == 0) {
+				return utils.NewToolResultError("either subject_id or owner, repo and discussionNumber are required"), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			// removeReaction errors when there is no reaction to remove, so check the viewer's reactions first
+			var subjectID githubv4.ID
+			var groups []discussionReactionGroup
+			if params.SubjectID != "" {
+				var q struct {
+					Node struct {
+						Reactable struct {
+							ReactionGroups []discussionReactionGroup
+						} `graphql:"... on Reactable"`
+					} `graphql:"node(id: $id)"`
+				}
+				if err := queryWithRequestID(ctx, client, &q, map[string]any{"id": githubv4.ID(params.SubjectID)}); err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				subjectID = githubv4.ID(params.SubjectID)
+				groups = q.Node.Reactable.ReactionGroups
+			} else {
+				var q struct {
+					Repository struct {
+						Discussion struct {
+							ID             githubv4.ID
+							ReactionGroups []discussionReactionGroup
+						} `graphql:"discussion(number: $discussionNumber)"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}
+				vars := map[string]any{
+					"owner":            githubv4.String(params.Owner),
+					"repo":             githubv4.String(params.Repo),
+					"discussionNumber": githubv4.Int(params.DiscussionNumber),
+				}
+				if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				subjectID = q.Repository.Discussion.ID
+				groups = q.Repository.Discussion.ReactionGroups
+			}
+			hasReacted := false
+			for _, group := range groups {
+				if string(group.Content) == params.Content && bool(group.ViewerHasReacted) {
+					hasReacted = true
+					break
+				}
+			}
+
+			if hasReacted {
+				var mutation struct {
+					RemoveReaction struct {
+						Reaction struct {
+							Content githubv4.ReactionContent
+						}
+					} `graphql:"removeReaction(input: $input)"`
+				}
+				if err := mutateWithRequestID(ctx, client, &mutation, githubv4.RemoveReactionInput{
+					SubjectID: subjectID,
+					Content:   githubv4.ReactionContent(params.Content),
+				}, nil); err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+			}
+
+			response := map[string]any{
+				"removed": hasReacted,
+				"content": params.Content,
+			}
+			if params.SubjectID != "" {
+				response["subjectId"] = params.SubjectID
+			} else {
+				response["discussionNumber"] = params.DiscussionNumber
+			}
+			out, err := json.Marshal(response)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal remove discussion reaction response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func AddDiscussionLabels(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "add_discussion_labels",
+			Description: t("TOOL_ADD_DISCUSSION_LABELS_DESCRIPTION", "Add labels to a discussion. Labels already applied to the discussion are skipped and reported, and the discussion's resulting labels are returned."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_ADD_DISCUSSION_LABELS_USER_TITLE", "Add labels to discussion"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+					"labels": {
+						Type:        "array",
+						Description: "Label names to add",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+				},
+				Required: []string{"owner", "repo", "discussionNumber", "labels"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				Owner            string
+				Repo             string
+				DiscussionNumber int32
+				Labels           []string
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if len(params.Labels) == 0 {
+				return utils.NewToolResultError("at least one label must be provided"), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			discussionID, current, err := getDiscussionLabels(ctx, client, params.Owner, params.Repo, params.DiscussionNumber)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			// Only labels that aren't already applied are sent, so re-applying is a no-op.
+			added := []string{}
+			alreadyPresent := []string{}
+			var labelIDs []githubv4.ID
+			for _, name := range params.Labels {
+				if containsFold(current, name) || containsFold(added, name) {
+					alreadyPresent = append(alreadyPresent, name)
+					continue
+				}
+				labelID, err := getLabelID(ctx, client, params.Owner, params.Repo, name)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				labelIDs = append(labelIDs, labelID)
+				added = append(added, name)
+			}
+
+			if len(labelIDs) > 0 {
+				var mutation struct {
+					AddLabelsToLabelable struct {
+						ClientMutationID githubv4.String
+					} `graphql:"addLabelsToLabelable(input: $input)"`
+				}
+				if err := mutateWithRequestID(ctx, client, &mutation, githubv4.AddLabelsToLabelableInput{
+					LabelableID: discussionID,
+					LabelIDs:    labelIDs,
+				}, nil); err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+			}
+
+			out, err := json.Marshal(map[string]any{
+				"added":          added,
+				"alreadyPresent": alreadyPresent,
+				"labels":         append(current, added...),
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal add discussion labels response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func RemoveDiscussionLabels(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "remove_discussion_labels",
+			Description: t("TOOL_REMOVE_DISCUSSION_LABELS_DESCRIPTION", "Remove labels from a discussion. Labels the discussion doesn't have are skipped and reported, and the discussion's resulting labels are returned."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_REMOVE_DISCUSSION_LABELS_USER_TITLE", "Remove labels from discussion"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+					"labels": {
+						Type:        "array",
+						Description: "Label names to remove",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+				},
+				Required: []string{"owner", "repo", "discussionNumber", "labels"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				Owner            string
+				Repo             string
+				DiscussionNumber int32
+				Labels           []string
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if len(params.Labels) == 0 {
+				return utils.NewToolResultError("at least one label must be provided"), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			discussionID, current, err := getDiscussionLabels(ctx, client, params.Owner, params.Repo, params.DiscussionNumber)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			// Every label is resolved, so a misspelt name is an error rather than reported as not present
+			removed := []string{}
+			notPresent := []string{}
+			var labelIDs []githubv4.ID
+			for _, name := range params.Labels {
+				labelID, err := getLabelID(ctx, client, params.Owner, params.Repo, name)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if !containsFold(current, name) || containsFold(removed, name) {
+					notPresent = append(notPresent, name)
+					continue
+				}
+				labelIDs = append(labelIDs, labelID)
+				removed = append(removed, name)
+			}
+
+			if len(labelIDs) > 0 {
+				var mutation struct {
+					RemoveLabelsFromLabelable struct {
+						ClientMutationID githubv4.String
+					} `graphql:"removeLabelsFromLabelable(input: $input)"`
+				}
+				if err := mutateWithRequestID(ctx, client, &mutation, githubv4.RemoveLabelsFromLabelableInput{
+					LabelableID: discussionID,
+					LabelIDs:    labelIDs,
+				}, nil); err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+			}
+
+			labels := []string{}
+			for _, name := range current {
+				if !containsFold(removed, name) {
+					labels = append(labels, name)
+				}
+			}
+
+			out, err := json.Marshal(map[string]any{
+				"removed":    removed,
+				"notPresent": notPresent,
+				"labels":     labels,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal remove discussion labels response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func RemoveDiscussionLabelBulk(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "remove_discussion_label_bulk",
+			Description: t("TOOL_REMOVE_DISCUSSION_LABEL_BULK_DESCRIPTION", "Remove a label from several discussions. Discussions that don't have the label are skipped, and each discussion's outcome is reported."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_REMOVE_DISCUSSION_LABEL_BULK_USER_TITLE", "Remove label from discussions"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumbers": {
+						Type:        "array",
+						Description: fmt.Sprintf("Discussion numbers (at most %d)", maxBatchDiscussions),
+						Items: &jsonschema.Schema{
+							Type: "number",
+						},
+						MinItems: jsonschema.Ptr(1),
+						MaxItems: jsonschema.Ptr(maxBatchDiscussions),
+					},
+					"label": {
+						Type:        "string",
+						Description: "Name of the label to remove",
+					},
+				},
+				Required: []string{"owner", "repo", "discussionNumbers", "label"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				Owner             string
+				Repo              string
+				DiscussionNumbers []int32
+				Label             string
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if len(params.DiscussionNumbers) == 0 {
+				return utils.NewToolResultError("discussionNumbers must not be empty"), nil, nil
+			}
+			if len(params.DiscussionNumbers) > maxBatchDiscussions {
+				return utils.NewToolResultError(fmt.Sprintf("at most %d discussions can be unlabeled at once", maxBatchDiscussions)), nil, nil
+			}
+			if params.Label == "" {
+				return utils.NewToolResultError("missing required parameter: label"), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			// The label is resolved once for the whole batch
+			labelID, err := getLabelID(ctx, client, params.Owner, params.Repo, params.Label)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			results := make([]batchItemResult, 0, len(params.DiscussionNumbers))
+			for _, number := range params.DiscussionNumbers {
+				removed, err := removeDiscussionLabel(ctx, client, params.Owner, params.Repo, number, params.Label, labelID)
+				if err != nil {
+					results = append(results, batchItemError(int(number), err))
+					continue
+				}
+				results = append(results, batchItemOK(int(number), map[string]any{"removed": removed}))
+			}
+
+			out, err := json.Marshal(batchResponse(results))
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal remove discussion label response: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+// removeDiscussionLabel removes a label from a discussion, reporting false without a mutation when
+// the discussion doesn't have it.
+func removeDiscussionLabel(ctx context.Context, client *githubv4.Client, owner, repo string, discussionNumber int32, label string, labelID githubv4.ID) (bool, error) {
+	discussionID, current, err := getDiscussionLabels(ctx, client, owner, repo, discussionNumber)
+	if err != nil {
+		return false, err
+	}
+	if !containsFold(current, label) {
+		return false, nil
+	}
+
+	var mutation struct {
+		RemoveLabelsFromLabelable struct {
+			ClientMutationID githubv4.String
+		} `graphql:"removeLabelsFromLabelable(input: $input)"`
+	}
+	if err := mutateWithRequestID(ctx, client, &mutation, githubv4.RemoveLabelsFromLabelableInput{
+		LabelableID: discussionID,
+		LabelIDs:    []githubv4.ID{labelID},
+	}, nil); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func DiscussionActivityHistogram(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "discussion_activity_histogram",
+			Description: t("TOOL_DISCUSSION_ACTIVITY_HISTOGRAM_DESCRIPTION", "Count discussions created in a repository per day, week, or month within an optional date range."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_DISCUSSION_ACTIVITY_HISTOGRAM_USER_TITLE", "Discussion activity histogram"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"interval": {
+						Type:        "string",
+						Description: "Bucket size. Weeks start on Monday. Defaults to 'week'.",
+						Enum:        []any{"day", "week", "month"},
+					},
+					"since": {
+						Type:        "string",
+						Description: "Only count discussions created at or after this time (RFC3339)",
+					},
+					"until": {
+						Type:        "string",
+						Description: "Only count discussions created before this time (RFC3339)",
+					},
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			interval, err := OptionalParam[string](args, "interval")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if interval == "" {
+				interval = "week"
+			}
+			if interval != "day" && interval != "week" && interval != "month" {
+				return utils.NewToolResultError(fmt.Sprintf("invalid interval %q: must be one of day, week, month", interval)), nil, nil
+			}
+			since, err := optionalTimeParam(args, "since")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			until, err := optionalTimeParam(args, "until")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			// Newest first, so the scan can stop as soon as it passes the start of the range.
+			counts := map[time.Time]int{}
+			scanned := 0
+			truncated, err := scanPages(ctx, discussionScanMaxPages, func(after *githubv4.String) (PageInfoFragment, bool, error) {
+				var q struct {
+					Repository struct {
+						Discussions struct {
+							Nodes []struct {
+								CreatedAt githubv4.DateTime
+							}
+							PageInfo PageInfoFragment
+						} `graphql:"discussions(first: $first, after: $after, orderBy: {field: CREATED_AT, direction: DESC})"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}
+				vars := map[string]any{
+					"owner": githubv4.String(owner),
+					"repo":  githubv4.String(repo),
+					"first": githubv4.Int(discussionScanPageSize),
+					"after": after,
+				}
+				if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+					return PageInfoFragment{}, false, err
+				}
+				for _, node := range q.Repository.Discussions.Nodes {
+					created := node.CreatedAt.UTC()
+					if !since.IsZero() && created.Before(since) {
+						return q.Repository.Discussions.PageInfo, true, nil
+					}
+					scanned++
+					if !until.IsZero() && !created.Before(until) {
+						continue
+					}
+					counts[bucketStart(created, interval)]++
+				}
+				return q.Repository.Discussions.PageInfo, false, nil
+			})
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			starts := make([]time.Time, 0, len(counts))
+			for start := range counts {
+				starts = append(starts, start)
+			}
+			sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+			buckets := make([]map[string]any, 0, len(starts))
+			for _, start := range starts {
+				buckets = append(buckets, map[string]any{
+					"bucket": start.Format(time.DateOnly),
+					"count":  counts[start],
+				})
+			}
+
+			out, err := json.Marshal(map[string]any{
+				"interval":  interval,
+				"buckets":   buckets,
+				"scanned":   scanned,
+				"truncated": truncated,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal discussion activity histogram: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func ExportDiscussionCategories(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "export_discussion_categories",
+			Description: t("TOOL_EXPORT_DISCUSSION_CATEGORIES_DESCRIPTION", "Export all discussion categories of a repository or organisation as a flat map of category name to category ID, for scripting."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_EXPORT_DISCUSSION_CATEGORIES_USER_TITLE", "Export discussion categories"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name. If not provided, discussion categories will be queried at the organisation level.",
+					},
+					"level": discussionLevelSchema(),
+				},
+				Required: []string{"owner"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, repoWarning, err := resolveDiscussionsRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			categories := map[string]string{}
+			namesByFold := map[string][]string{}
+			truncated, err := scanPages(ctx, discussionScanMaxPages, func(after *githubv4.String) (PageInfoFragment, bool, error) {
+				var q struct {
+					Repository struct {
+						DiscussionCategories struct {
+							Nodes []struct {
+								ID   githubv4.ID
+								Name githubv4.String
+							}
+							PageInfo PageInfoFragment
+						} `graphql:"discussionCategories(first: $first, after: $after)"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}
+				vars := map[string]any{
+					"owner": githubv4.String(owner),
+					"repo":  githubv4.String(repo),
+					"first": githubv4.Int(discussionScanPageSize),
+					"after": after,
+				}
+				if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+					return PageInfoFragment{}, false, err
+				}
+				for _, c := range q.Repository.DiscussionCategories.Nodes {
+					name := string(c.Name)
+					categories[name] = fmt.Sprint(c.ID)
+					folded := strings.ToLower(name)
+					namesByFold[folded] = append(namesByFold[folded], name)
+				}
+				return q.Repository.DiscussionCategories.PageInfo, false, nil
+			})
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			// Names that only differ by case are distinct keys here, but are easy to mix up
+			// when category_name lookups elsewhere match case-insensitively.
+			var collisions []string
+			for _, names := range namesByFold {
+				if len(names) > 1 {
+					sort.Strings(names)
+					collisions = append(collisions, strings.Join(names, ", "))
+				}
+			}
+			sort.Strings(collisions)
+
+			response := map[string]any{
+				"categories": categories,
+			}
+			if len(collisions) > 0 {
+				response["note"] = fmt.Sprintf("some category names collide case-insensitively: %s", strings.Join(collisions, "; "))
+			}
+			if truncated {
+				response["truncated"] = true
+			}
+			if repoWarning != "" {
+				response["warning"] = repoWarning
+			}
+
+			out, err := json.Marshal(response)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal discussion categories: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func ListDiscussionsWithRecentCommentBy(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "list_discussions_with_recent_comment_by",
+			Description: t("TOOL_LIST_DISCUSSIONS_WITH_RECENT_COMMENT_BY_DESCRIPTION", "List discussions in a repository whose most recent top-level comment was written by the given user. Only the 1000 most recently updated discussions are scanned."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_DISCUSSIONS_WITH_RECENT_COMMENT_BY_USER_TITLE", "List discussions with recent comment by user"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"login": {
+						Type:        "string",
+						Description: "Login of the user who wrote the most recent comment",
+					},
+				},
+				Required: []string{"owner", "repo", "login"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			login, err := RequiredParam[string](args, "login")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			matches := []map[string]any{}
+			scanned := 0
+			truncated, err := scanPages(ctx, discussionScanMaxPages, func(after *githubv4.String) (PageInfoFragment, bool, error) {
+				var q struct {
+					Repository struct {
+						Discussions struct {
+							Nodes []struct {
+								Number   githubv4.Int
+								Title    githubv4.String
+								URL      githubv4.String `graphql:"url"`
+								Comments struct {
+									Nodes []struct {
+										Author struct {
+											Login githubv4.String
+										}
+										CreatedAt githubv4.DateTime
+									}
+								} `graphql:"comments(last: 1)"`
+							}
+							PageInfo PageInfoFragment
+						} `graphql:"discussions(first: $first, after: $after, orderBy: {field: UPDATED_AT, direction: DESC})"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}
+				vars := map[string]any{
+					"owner": githubv4.String(owner),
+					"repo":  githubv4.String(repo),
+					"first": githubv4.Int(discussionScanPageSize),
+					"after": after,
+				}
+				if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+					return PageInfoFragment{}, false, err
+				}
+				for _, node := range q.Repository.Discussions.Nodes {
+					scanned++
+					if len(node.Comments.Nodes) == 0 {
+						continue
+					}
+					latest := node.Comments.Nodes[0]
+					if !strings.EqualFold(string(latest.Author.Login), login) {
+						continue
+					}
+					matches = append(matches, map[string]any{
+						"number":      int(node.Number),
+						"title":       string(node.Title),
+						"url":         string(node.URL),
+						"commentedAt": latest.CreatedAt.Time,
+					})
+				}
+				return q.Repository.Discussions.PageInfo, false, nil
+			})
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			out, err := json.Marshal(map[string]any{
+				"discussions": matches,
+				"scanned":     scanned,
+				"truncated":   truncated,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal discussions: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func ListLockedDiscussions(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "list_locked_discussions",
+			Description: t("TOOL_LIST_LOCKED_DISCUSSIONS_DESCRIPTION", "List locked discussions in a repository with their lock reasons, for moderation review. Only the 1000 most recently updated discussions are scanned."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_LOCKED_DISCUSSIONS_USER_TITLE", "List locked discussions"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			locked := []map[string]any{}
+			scanned := 0
+			truncated, err := scanPages(ctx, discussionScanMaxPages, func(after *githubv4.String) (PageInfoFragment, bool, error) {
+				var q struct {
+					Repository struct {
+						Discussions struct {
+							Nodes []struct {
+								Number           githubv4.Int
+								Title            githubv4.String
+								URL              githubv4.String `graphql:"url"`
+								Locked           githubv4.Boolean
+								ActiveLockReason *githubv4.LockReason
+							}
+							PageInfo PageInfoFragment
+						} `graphql:"discussions(first: $first, after: $after, orderBy: {field: UPDATED_AT, direction: DESC})"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}
+				vars := map[string]any{
+					"owner": githubv4.String(owner),
+					"repo":  githubv4.String(repo),
+					"first": githubv4.Int(discussionScanPageSize),
+					"after": after,
+				}
+				if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+					return PageInfoFragment{}, false, err
+				}
+				for _, node := range q.Repository.Discussions.Nodes {
+					scanned++
+					if !node.Locked {
+						continue
+					}
+					// The lock reason is null when the discussion was locked without one
+					var reason any
+					if node.ActiveLockReason != nil {
+						reason = string(*node.ActiveLockReason)
+					}
+					locked = append(locked, map[string]any{
+						"number":     int(node.Number),
+						"title":      string(node.Title),
+						"url":        string(node.URL),
+						"lockReason": reason,
+					})
+				}
+				return q.Repository.Discussions.PageInfo, false, nil
+			})
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			out, err := json.Marshal(map[string]any{
+				"discussions": locked,
+				"scanned":     scanned,
+				"truncated":   truncated,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal locked discussions: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func ListUncategorizedDiscussions(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "list_uncategorized_discussions",
+			Description: t("TOOL_LIST_UNCATEGORIZED_DISCUSSIONS_DESCRIPTION", "List discussions that have no category, which can happen for imported or legacy discussions, so they can be fixed. Only the 1000 most recently updated discussions are scanned."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_UNCATEGORIZED_DISCUSSIONS_USER_TITLE", "List uncategorized discussions"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			uncategorized := []map[string]any{}
+			scanned := 0
+			truncated, err := scanPages(ctx, discussionScanMaxPages, func(after *githubv4.String) (PageInfoFragment, bool, error) {
+				var q struct {
+					Repository struct {
+						Discussions struct {
+							Nodes []struct {
+								Number   githubv4.Int
+								Title    githubv4.String
+								URL      githubv4.String `graphql:"url"`
+								Category *struct {
+									ID githubv4.ID
+								}
+							}
+							PageInfo PageInfoFragment
+						} `graphql:"discussions(first: $first, after: $after, orderBy: {field: UPDATED_AT, direction: DESC})"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}
+				vars := map[string]any{
+					"owner": githubv4.String(owner),
+					"repo":  githubv4.String(repo),
+					"first": githubv4.Int(discussionScanPageSize),
+					"after": after,
+				}
+				if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+					return PageInfoFragment{}, false, err
+				}
+				for _, node := range q.Repository.Discussions.Nodes {
+					scanned++
+					if node.Category != nil {
+						continue
+					}
+					uncategorized = append(uncategorized, map[string]any{
+						"number": int(node.Number),
+						"title":  string(node.Title),
+						"url":    string(node.URL),
+					})
+				}
+				return q.Repository.Discussions.PageInfo, false, nil
+			})
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			out, err := json.Marshal(map[string]any{
+				"discussions": uncategorized,
+				"scanned":     scanned,
+				"truncated":   truncated,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal uncategorized discussions: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+// countRepositoryDiscussions returns the number of discussions in a repository, or in one of its
+// categories when categoryID is not nil, without fetching any of them.
+func countRepositoryDiscussions(ctx context.Context, client *githubv4.Client, policy GraphQLRetryPolicy, owner, repo string, categoryID *githubv4.ID) (int, error) {
+	var q struct {
+		Repository struct {
+			Discussions struct {
+				TotalCount githubv4.Int
+			} `graphql:"discussions(first: 1, categoryId: $categoryId)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner":      githubv4.String(owner),
+		"repo":       githubv4.String(repo),
+		"categoryId": categoryID,
+	}
+	if err := queryWithRetry(ctx, client, policy, &q, vars); err != nil {
+		return 0, err
+	}
+	return int(q.Repository.Discussions.TotalCount), nil
+}
+
+func CountDiscussions(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "count_discussions",
+			Description: t("TOOL_COUNT_DISCUSSIONS_DESCRIPTION", "Count the discussions in a repository."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_COUNT_DISCUSSIONS_USER_TITLE", "Count discussions"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"includeNumberRange": {
+						Type:        "boolean",
+						Description: "Also return minNumber and maxNumber, the numbers of the oldest and newest discussions, to size range-based batch calls. Both are null when there are no discussions.",
+					},
+					"timeoutSeconds": queryTimeoutSchema(),
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			includeNumberRange, err := OptionalParam[bool](args, "includeNumberRange")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			timeout, err := optionalQueryTimeout(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			ctx, cancel := withQueryTimeout(ctx, timeout)
+			defer cancel()
+
+			count, err := countRepositoryDiscussions(ctx, client, deps.GetGraphQLRetryPolicy(), owner, repo, nil)
+			if err != nil {
+				return utils.NewToolResultError(queryTimeoutError(ctx, timeout, err).Error()), nil, nil
+			}
+			response := map[string]any{
+				"count": count,
+			}
+			if !includeNumberRange {
+				out, err := json.Marshal(response)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal discussion count: %w", err)
+				}
+				return utils.NewToolResultText(string(out)), nil, nil
+			}
+
+			// Numbers are assigned in creation order, so the oldest and newest discussions have the
+			// lowest and highest numbers
+			var q struct {
+				Repository struct {
+					Oldest struct {
+						Nodes []struct {
+							Number githubv4.Int
+						}
+					} `graphql:"oldest: discussions(first: 1, orderBy: {field: CREATED_AT, direction: ASC})"`
+					Newest struct {
+						Nodes []struct {
+							Number githubv4.Int
+						}
+					} `graphql:"newest: discussions(first: 1, orderBy: {field: CREATED_AT, direction: DESC})"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+			}
+			if err := queryWithRetry(ctx, client, deps.GetGraphQLRetryPolicy(), &q, vars); err != nil {
+				return utils.NewToolResultError(queryTimeoutError(ctx, timeout, err).Error()), nil, nil
+			}
+			var minNumber, maxNumber *int
+			if nodes := q.Repository.Oldest.Nodes; len(nodes) > 0 {
+				number := int(nodes[0].Number)
+				minNumber = &number
+			}
+			if nodes := q.Repository.Newest.Nodes; len(nodes) > 0 {
+				number := int(nodes[0].Number)
+				maxNumber = &number
+			}
+			response["minNumber"] = minNumber
+			response["maxNumber"] = maxNumber
+
+			out, err := json.Marshal(response)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal discussion count: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func ListRecentlyAnsweredDiscussions(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "list_recently_answered_discussions",
+			Description: t("TOOL_LIST_RECENTLY_ANSWERED_DISCUSSIONS_DESCRIPTION", "List the most recently answered discussions, newest answer first, with their answer URLs and authors, for a solved questions feed. Only the 1000 most recently updated discussions are scanned."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_RECENTLY_ANSWERED_DISCUSSIONS_USER_TITLE", "List recently answered discussions"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"limit": {
+						Type:        "number",
+						Description: "Maximum number of discussions to return (default 10)",
+						Minimum:     jsonschema.Ptr(1.0),
+						Maximum:     jsonschema.Ptr(100.0),
+					},
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			limit, err := OptionalIntParamWithDefault(args, "limit", 10)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if limit < 1 || limit > 100 {
+				return utils.NewToolResultError(fmt.Sprintf("limit must be between 1 and 100, got %d", limit)), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			type answeredDiscussion struct {
+				number         int
+				title          string
+				url            string
+				answerChosenAt time.Time
+				answerURL      string
+				answerAuthor   any
+			}
+			var answered []answeredDiscussion
+			truncated, err := scanPages(ctx, discussionScanMaxPages, func(after *githubv4.String) (PageInfoFragment, bool, error) {
+				var q struct {
+					Repository struct {
+						Discussions struct {
+							Nodes []struct {
+								Number         githubv4.Int
+								Title          githubv4.String
+								URL            githubv4.String `graphql:"url"`
+								IsAnswered     githubv4.Boolean
+								AnswerChosenAt *githubv4.DateTime
+								Answer         *struct {
+									URL    githubv4.String `graphql:"url"`
+									Author *struct {
+										Login githubv4.String
+									}
+								}
+							}
+							PageInfo PageInfoFragment
+						} `graphql:"discussions(first: $first, after: $after, orderBy: {field: UPDATED_AT, direction: DESC})"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}
+				vars := map[string]any{
+					"owner": githubv4.String(owner),
+					"repo":  githubv4.String(repo),
+					"first": githubv4.Int(discussionScanPageSize),
+					"after": after,
+				}
+				if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+					return PageInfoFragment{}, false, err
+				}
+				for _, node := range q.Repository.Discussions.Nodes {
+					if !node.IsAnswered || node.AnswerChosenAt == nil || node.Answer == nil {
+						continue
+					}
+					d := answeredDiscussion{
+						number:         int(node.Number),
+						title:          string(node.Title),
+						url:            string(node.URL),
+						answerChosenAt: node.AnswerChosenAt.Time,
+						answerURL:      string(node.Answer.URL),
+					}
+					// The author is null when their account has been deleted
+					if node.Answer.Author != nil {
+						d.answerAuthor = string(node.Answer.Author.Login)
+					}
+					answered = append(answered, d)
+				}
+				return q.Repository.Discussions.PageInfo, false, nil
+			})
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			// Discussions are paged by update time, which an answer doesn't always determine
+			sort.SliceStable(answered, func(i, j int) bool { return answered[i].answerChosenAt.After(answered[j].answerChosenAt) })
+			if len(answered) > limit {
+				answered = answered[:limit]
+			}
+
+			discussions := make([]map[string]any, 0, len(answered))
+			for _, d := range answered {
+				discussions = append(discussions, map[string]any{
+					"number":         d.number,
+					"title":          d.title,
+					"url":            d.url,
+					"answerChosenAt": d.answerChosenAt,
+					"answerUrl":      d.answerURL,
+					"answerAuthor":   d.answerAuthor,
+				})
+			}
+
+			out, err := json.Marshal(map[string]any{
+				"discussions": discussions,
+				"truncated":   truncated,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal recently answered discussions: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func GetOldestUnansweredDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "get_oldest_unanswered_discussion",
+			Description: t("TOOL_GET_OLDEST_UNANSWERED_DISCUSSION_DESCRIPTION", "Get the oldest open, unanswered discussion in a category, for support triage. Only the 1000 oldest discussions in the category are scanned."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_OLDEST_UNANSWERED_DISCUSSION_USER_TITLE", "Get oldest unanswered discussion"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"category": {
+						Type:        "string",
+						Description: "Discussion category ID",
+					},
+				},
+				Required: []string{"owner", "repo", "category"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			category, err := RequiredParam[string](args, "category")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var found map[string]any
+			scanned := 0
+			truncated, err := scanPages(ctx, discussionScanMaxPages, func(after *githubv4.String) (PageInfoFragment, bool, error) {
+				var q struct {
+					Repository struct {
+						Discussions struct {
+							Nodes []struct {
+								Number     githubv4.Int
+								Title      githubv4.String
+								URL        githubv4.String `graphql:"url"`
+								CreatedAt  githubv4.DateTime
+								Closed     githubv4.Boolean
+								IsAnswered githubv4.Boolean
+							}
+							PageInfo PageInfoFragment
+						} `graphql:"discussions(first: $first, after: $after, categoryId: $categoryId, orderBy: {field: CREATED_AT, direction: ASC})"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}
+				vars := map[string]any{
+					"owner":      githubv4.String(owner),
+					"repo":       githubv4.String(repo),
+					"categoryId": githubv4.ID(category),
+					"first":      githubv4.Int(discussionScanPageSize),
+					"after":      after,
+				}
+				if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+					return PageInfoFragment{}, false, err
+				}
+				for _, node := range q.Repository.Discussions.Nodes {
+					scanned++
+					if node.Closed || node.IsAnswered {
+						continue
+					}
+					found = map[string]any{
+						"number":    int(node.Number),
+						"title":     string(node.Title),
+						"url":       string(node.URL),
+						"createdAt": node.CreatedAt.Time,
+					}
+					return PageInfoFragment{}, true, nil
+				}
+				return q.Repository.Discussions.PageInfo, false, nil
+			})
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			if found == nil {
+				msg := fmt.Sprintf("no open unanswered discussions in category %s of %s/%s", category, owner, repo)
+				if truncated {
+					msg = fmt.Sprintf("no open unanswered discussions among the %d oldest in category %s of %s/%s", scanned, category, owner, repo)
+				}
+				return utils.NewToolResultText(msg), nil, nil
+			}
+
+			out, err := json.Marshal(map[string]any{
+				"discussion": found,
+				"scanned":    scanned,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal discussion: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+func GetDiscussionSignals(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "get_discussion_signals",
+			Description: t("TOOL_GET_DISCUSSION_SIGNALS_DESCRIPTION", "Get a compact summary of where a discussion thread stands: reactions on the discussion, the top-upvoted comment, whether it has an answer, and participant count. Comment-based signals cover the first 100 comments."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_DISCUSSION_SIGNALS_USER_TITLE", "Get discussion signals"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+				},
+				Required: []string{"owner", "repo", "discussionNumber"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				Owner            string
+				Repo             string
+				DiscussionNumber int32
+			}
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var q struct {
+				Repository struct {
+					Discussion struct {
+						IsAnswered githubv4.Boolean
+						Author     *struct {
+							Login githubv4.String
+						}
+						Reactions struct {
+							TotalCount githubv4.Int
+						}
+						Comments struct {
+							TotalCount githubv4.Int
+							Nodes      []struct {
+								ID          githubv4.ID
+								URL         githubv4.String `graphql:"url"`
+								UpvoteCount githubv4.Int
+								Author      *struct {
+									Login githubv4.String
+								}
+							}
+							PageInfo struct {
+								HasNextPage githubv4.Boolean
+							}
+						} `graphql:"comments(first: 100)"`
+					} `graphql:"discussion(number: $discussionNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner":            githubv4.String(params.Owner),
+				"repo":             githubv4.String(params.Repo),
+				"discussionNumber": githubv4.Int(params.DiscussionNumber),
+			}
+			if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+				return utils.NewToolResultError(discussionQueryError(err, params.Owner, params.Repo, params.DiscussionNumber).Error()), nil, nil
+			}
+			d := q.Repository.Discussion
+
+			// Authors are null for deleted accounts, which aren't counted as participants
+			participants := map[string]bool{}
+			if d.Author != nil {
+				participants[string(d.Author.Login)] = true
+			}
+			var topComment map[string]any
+			topUpvotes := 0
+			for _, c := range d.Comments.Nodes {
+				if c.Author != nil {
+					participants[string(c.Author.Login)] = true
+				}
+				// Prefer the earliest comment among ties, and ignore comments without upvotes
+				if int(c.UpvoteCount) <= topUpvotes {
+					continue
+				}
+				topUpvotes = int(c.UpvoteCount)
+				topComment = map[string]any{
+					"id":          fmt.Sprint(c.ID),
+					"url":         string(c.URL),
+					"upvoteCount": topUpvotes,
+					"author":      nil,
+				}
+				if c.Author != nil {
+					topComment["author"] = string(c.Author.Login)
+				}
+			}
+
+			out, err := json.Marshal(map[string]any{
+				"signals": map[string]any{
+					"totalReactions":    int(d.Reactions.TotalCount),
+					"topUpvotedComment": topComment,
+					"isAnswered":        bool(d.IsAnswered),
+					"participantCount":  len(participants),
+					"commentCount":      int(d.Comments.TotalCount),
+				},
+				"truncated": bool(d.Comments.PageInfo.HasNextPage),
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal discussion signals: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+// maxPinnedDiscussions covers every discussion a repository can pin, which is currently 4.
+const maxPinnedDiscussions = 10
+
+// pinnedDiscussion is a list_pinned_discussions row: a list_discussions row plus how the pin is styled.
+type pinnedDiscussion struct {
+	*listedDiscussion
+	// PreconfiguredGradient is nil when the pin uses a custom color instead of a gradient.
+	PreconfiguredGradient *string `json:"preconfiguredGradient,omitempty"`
+}
+
+// pinnedDiscussionNumbers returns the numbers of the discussions pinned on a repository.
+func pinnedDiscussionNumbers(ctx context.Context, client *githubv4.Client, owner, repo string) (map[int]bool, error) {
+	var q struct {
+		Repository struct {
+			PinnedDiscussions struct {
+				Nodes []struct {
+					Discussion struct {
+						Number githubv4.Int
+					}
+				}
+			} `graphql:"pinnedDiscussions(first: $first)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+		"first": githubv4.Int(maxPinnedDiscussions),
+	}
+	if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+		return nil, fmt.Errorf("failed to list pinned discussions: %w", err)
+	}
+	numbers := make(map[int]bool, len(q.Repository.PinnedDiscussions.Nodes))
+	for _, node := range q.Repository.PinnedDiscussions.Nodes {
+		numbers[int(node.Discussion.Number)] = true
+	}
+	return numbers, nil
+}
+
+func ListPinnedDiscussions(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "list_pinned_discussions",
+			Description: t("TOOL_LIST_PINNED_DISCUSSIONS_DESCRIPTION", "List the discussions pinned on a repository's discussions homepage, in pin order."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_PINNED_DISCUSSIONS_USER_TITLE", "List pinned discussions"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
 					"owner": {
 						Type:        "string",
 						Description: "Repository owner",
@@ -296,24 +5664,20 @@ func GetDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
 						Type:        "string",
 						Description: "Repository name",
 					},
-					"discussionNumber": {
-						Type:        "number",
-						Description: "Discussion Number",
-					},
 				},
-				Required: []string{"owner", "repo", "discussionNumber"},
+				Required: []string{"owner", "repo"},
 			},
 		},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-			// Decode params
-			var params struct {
-				Owner            string
-				Repo             string
-				DiscussionNumber int32
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
-			if err := mapstructure.Decode(args, &params); err != nil {
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
+
 			client, err := deps.GetGQLClient(ctx)
 			if err != nil {
 				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
@@ -321,74 +5685,84 @@ func GetDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
 
 			var q struct {
 				Repository struct {
-					Discussion struct {
-						Number         githubv4.Int
-						Title          githubv4.String
-						Body           githubv4.String
-						CreatedAt      githubv4.DateTime
-						Closed         githubv4.Boolean
-						IsAnswered     githubv4.Boolean
-						AnswerChosenAt *githubv4.DateTime
-						URL            githubv4.String `graphql:"url"`
-						Category       struct {
-							Name githubv4.String
-						} `graphql:"category"`
-					} `graphql:"discussion(number: $discussionNumber)"`
+					PinnedDiscussions struct {
+						Nodes []struct {
+							Discussion            NodeFragment
+							PreconfiguredGradient *githubv4.PinnedDiscussionGradient
+						}
+					} `graphql:"pinnedDiscussions(first: $first)"`
 				} `graphql:"repository(owner: $owner, name: $repo)"`
 			}
-			vars := map[string]interface{}{
-				"owner":            githubv4.String(params.Owner),
-				"repo":             githubv4.String(params.Repo),
-				"discussionNumber": githubv4.Int(params.DiscussionNumber),
+			vars := map[string]any{
+				"owner":       githubv4.String(owner),
+				"repo":        githubv4.String(repo),
+				"first":       githubv4.Int(maxPinnedDiscussions),
+				"includeBody": githubv4.Boolean(false),
 			}
-			if err := client.Query(ctx, &q, vars); err != nil {
+			if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
-			d := q.Repository.Discussion
-
-			// Build response as map to include fields not present in go-github's Discussion struct.
-			// The go-github library's Discussion type lacks isAnswered and answerChosenAt fields,
-			// so we use map[string]interface{} for the response (consistent with other functions
-			// like ListDiscussions and GetDiscussionComments).
-			response := map[string]interface{}{
-				"number":     int(d.Number),
-				"title":      string(d.Title),
-				"body":       string(d.Body),
-				"url":        string(d.URL),
-				"closed":     bool(d.Closed),
-				"isAnswered": bool(d.IsAnswered),
-				"createdAt":  d.CreatedAt.Time,
-				"category": map[string]interface{}{
-					"name": string(d.Category.Name),
-				},
-			}
 
-			// Add optional timestamp fields if present
-			if d.AnswerChosenAt != nil {
-				response["answerChosenAt"] = d.AnswerChosenAt.Time
+			// The connection is returned in pin order, which is kept
+			pinned := make([]pinnedDiscussion, 0, len(q.Repository.PinnedDiscussions.Nodes))
+			for _, node := range q.Repository.PinnedDiscussions.Nodes {
+				p := pinnedDiscussion{listedDiscussion: fragmentToListedDiscussion(node.Discussion)}
+				if node.PreconfiguredGradient != nil {
+					p.PreconfiguredGradient = github.Ptr(string(*node.PreconfiguredGradient))
+				}
+				pinned = append(pinned, p)
 			}
 
-			out, err := json.Marshal(response)
+			out, err := json.Marshal(map[string]any{
+				"discussions": pinned,
+			})
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal discussion: %w", err)
+				return nil, nil, fmt.Errorf("failed to marshal pinned discussions: %w", err)
 			}
-
 			return utils.NewToolResultText(string(out)), nil, nil
 		},
 	)
 }
 
-func GetDiscussionComments(t translations.TranslationHelperFunc) inventory.ServerTool {
+// PinDiscussionInput represents the input for pinning a discussion via the GraphQL API.
+// Used to extend the functionality of the githubv4 library, which doesn't model discussion pinning.
+type PinDiscussionInput struct {
+	DiscussionID     githubv4.ID      `json:"discussionId"`
+	ClientMutationID *githubv4.String `json:"clientMutationId,omitempty"`
+}
+
+// UnpinDiscussionInput represents the input for unpinning a discussion via the GraphQL API.
+// Used to extend the functionality of the githubv4 library, which doesn't model discussion pinning.
+type UnpinDiscussionInput struct {
+	DiscussionID     githubv4.ID      `json:"discussionId"`
+	ClientMutationID *githubv4.String `json:"clientMutationId,omitempty"`
+}
+
+// pinLimitMessage is the part of GitHub's error for pinning a discussion in a repository that
+// already has as many pinned discussions as it allows.
+const pinLimitMessage = "maximum number of pinned discussions"
+
+// pinDiscussionErrorMessage returns the message to surface for a failed pin or unpin of discussion
+// #number in owner/repo: a readable message for GitHub's pin limit, a not-found message naming the
+// discussion, and the error as it is otherwise.
+func pinDiscussionErrorMessage(err error, owner, repo string, discussionNumber int32) string {
+	if strings.Contains(strings.ToLower(err.Error()), pinLimitMessage) {
+		return "the repository already has as many pinned discussions as it allows: unpin one with unpin_discussion first"
+	}
+	return discussionQueryError(err, owner, repo, discussionNumber).Error()
+}
+
+func PinDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
 	return NewTool(
 		ToolsetMetadataDiscussions,
 		mcp.Tool{
-			Name:        "get_discussion_comments",
-			Description: t("TOOL_GET_DISCUSSION_COMMENTS_DESCRIPTION", "Get comments from a discussion"),
+			Name:        "pin_discussion",
+			Description: t("TOOL_PIN_DISCUSSION_DESCRIPTION", "Pin a discussion to the top of a repository's discussions homepage. A repository can only pin a few discussions, so one may need to be unpinned first."),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_GET_DISCUSSION_COMMENTS_USER_TITLE", "Get discussion comments"),
-				ReadOnlyHint: true,
+				Title:        t("TOOL_PIN_DISCUSSION_USER_TITLE", "Pin discussion"),
+				ReadOnlyHint: false,
 			},
-			InputSchema: WithCursorPagination(&jsonschema.Schema{
+			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -405,10 +5779,9 @@ func GetDiscussionComments(t translations.TranslationHelperFunc) inventory.Serve
 					},
 				},
 				Required: []string{"owner", "repo", "discussionNumber"},
-			}),
+			},
 		},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-			// Decode params
 			var params struct {
 				Owner            string
 				Repo             string
@@ -418,106 +5791,115 @@ func GetDiscussionComments(t translations.TranslationHelperFunc) inventory.Serve
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			// Get pagination parameters and convert to GraphQL format
-			pagination, err := OptionalCursorPaginationParams(args)
+			client, err := deps.GetGQLClient(ctx)
 			if err != nil {
-				return nil, nil, err
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
 			}
 
-			// Check if pagination parameters were explicitly provided
-			_, perPageProvided := args["perPage"]
-			paginationExplicit := perPageProvided
-
-			paginationParams, err := pagination.ToGraphQLParams()
+			discussionID, err := getDiscussionID(ctx, client, params.Owner, params.Repo, params.DiscussionNumber)
 			if err != nil {
-				return nil, nil, err
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			// Use default of 30 if pagination was not explicitly provided
-			if !paginationExplicit {
-				defaultFirst := int32(DefaultGraphQLPageSize)
-				paginationParams.First = &defaultFirst
+			var mutation struct {
+				PinDiscussion struct {
+					ClientMutationID githubv4.String
+				} `graphql:"pinDiscussion(input: $input)"`
+			}
+			if err := mutateWithRequestID(ctx, client, &mutation, PinDiscussionInput{DiscussionID: discussionID}, nil); err != nil {
+				return utils.NewToolResultError(pinDiscussionErrorMessage(err, params.Owner, params.Repo, params.DiscussionNumber)), nil, nil
 			}
 
-			client, err := deps.GetGQLClient(ctx)
+			out, err := json.Marshal(map[string]any{
+				"number": int(params.DiscussionNumber),
+				"pinned": true,
+			})
 			if err != nil {
-				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+				return nil, nil, fmt.Errorf("failed to marshal pin discussion response: %w", err)
 			}
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
 
-			var q struct {
-				Repository struct {
-					Discussion struct {
-						Comments struct {
-							Nodes []struct {
-								ID   githubv4.ID
-								Body githubv4.String
-								URL  githubv4.String `graphql:"url"`
-							}
-							PageInfo struct {
-								HasNextPage     githubv4.Boolean
-								HasPreviousPage githubv4.Boolean
-								StartCursor     githubv4.String
-								EndCursor       githubv4.String
-							}
-							TotalCount int
-						} `graphql:"comments(first: $first, after: $after)"`
-					} `graphql:"discussion(number: $discussionNumber)"`
-				} `graphql:"repository(owner: $owner, name: $repo)"`
+func UnpinDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "unpin_discussion",
+			Description: t("TOOL_UNPIN_DISCUSSION_DESCRIPTION", "Unpin a discussion from a repository's discussions homepage."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_UNPIN_DISCUSSION_USER_TITLE", "Unpin discussion"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+				},
+				Required: []string{"owner", "repo", "discussionNumber"},
+			},
+		},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			var params struct {
+				Owner            string
+				Repo             string
+				DiscussionNumber int32
 			}
-			vars := map[string]interface{}{
-				"owner":            githubv4.String(params.Owner),
-				"repo":             githubv4.String(params.Repo),
-				"discussionNumber": githubv4.Int(params.DiscussionNumber),
-				"first":            githubv4.Int(*paginationParams.First),
+			if err := mapstructure.Decode(args, &params); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
-			if paginationParams.After != nil {
-				vars["after"] = githubv4.String(*paginationParams.After)
-			} else {
-				vars["after"] = (*githubv4.String)(nil)
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
 			}
-			if err := client.Query(ctx, &q, vars); err != nil {
+
+			discussionID, err := getDiscussionID(ctx, client, params.Owner, params.Repo, params.DiscussionNumber)
+			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			var comments []map[string]any
-			for _, c := range q.Repository.Discussion.Comments.Nodes {
-				comments = append(comments, map[string]any{
-					"id":   fmt.Sprint(c.ID),
-					"body": string(c.Body),
-					"url":  string(c.URL),
-				})
+			var mutation struct {
+				UnpinDiscussion struct {
+					ClientMutationID githubv4.String
+				} `graphql:"unpinDiscussion(input: $input)"`
 			}
-
-			// Create response with pagination info
-			response := map[string]interface{}{
-				"comments": comments,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     q.Repository.Discussion.Comments.PageInfo.HasNextPage,
-					"hasPreviousPage": q.Repository.Discussion.Comments.PageInfo.HasPreviousPage,
-					"startCursor":     string(q.Repository.Discussion.Comments.PageInfo.StartCursor),
-					"endCursor":       string(q.Repository.Discussion.Comments.PageInfo.EndCursor),
-				},
-				"totalCount": q.Repository.Discussion.Comments.TotalCount,
+			if err := mutateWithRequestID(ctx, client, &mutation, UnpinDiscussionInput{DiscussionID: discussionID}, nil); err != nil {
+				return utils.NewToolResultError(pinDiscussionErrorMessage(err, params.Owner, params.Repo, params.DiscussionNumber)), nil, nil
 			}
 
-			out, err := json.Marshal(response)
+			out, err := json.Marshal(map[string]any{
+				"number": int(params.DiscussionNumber),
+				"pinned": false,
+			})
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal comments: %w", err)
+				return nil, nil, fmt.Errorf("failed to marshal unpin discussion response: %w", err)
 			}
-
 			return utils.NewToolResultText(string(out)), nil, nil
 		},
 	)
 }
 
-func ListDiscussionCategories(t translations.TranslationHelperFunc) inventory.ServerTool {
+func GetDiscussionFirstResponseTimes(t translations.TranslationHelperFunc) inventory.ServerTool {
 	return NewTool(
 		ToolsetMetadataDiscussions,
 		mcp.Tool{
-			Name:        "list_discussion_categories",
-			Description: t("TOOL_LIST_DISCUSSION_CATEGORIES_DESCRIPTION", "List discussion categories with their id and name, for a repository or organisation."),
+			Name:        "get_discussion_first_response_times",
+			Description: t("TOOL_GET_DISCUSSION_FIRST_RESPONSE_TIMES_DESCRIPTION", "Get the time to first response for discussions: the time from a discussion's creation to the first comment by someone other than its author. Only the first 100 comments of each discussion are considered."),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_LIST_DISCUSSION_CATEGORIES_USER_TITLE", "List discussion categories"),
+				Title:        t("TOOL_GET_DISCUSSION_FIRST_RESPONSE_TIMES_USER_TITLE", "Get discussion first response times"),
 				ReadOnlyHint: true,
 			},
 			InputSchema: &jsonschema.Schema{
@@ -529,25 +5911,35 @@ func ListDiscussionCategories(t translations.TranslationHelperFunc) inventory.Se
 					},
 					"repo": {
 						Type:        "string",
-						Description: "Repository name. If not provided, discussion categories will be queried at the organisation level.",
+						Description: "Repository name",
+					},
+					"discussionNumbers": {
+						Type:        "array",
+						Description: fmt.Sprintf("Discussion numbers (at most %d)", maxBatchDiscussions),
+						Items: &jsonschema.Schema{
+							Type: "number",
+						},
+						MinItems: jsonschema.Ptr(1),
+						MaxItems: jsonschema.Ptr(maxBatchDiscussions),
 					},
 				},
-				Required: []string{"owner"},
+				Required: []string{"owner", "repo", "discussionNumbers"},
 			},
 		},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-			owner, err := RequiredParam[string](args, "owner")
-			if err != nil {
-				return utils.NewToolResultError(err.Error()), nil, nil
+			var params struct {
+				Owner             string
+				Repo              string
+				DiscussionNumbers []int32
 			}
-			repo, err := OptionalParam[string](args, "repo")
-			if err != nil {
+			if err := mapstructure.Decode(args, &params); err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
-			// when not provided, default to the .github repository
-			// this will query discussion categories at the organisation level
-			if repo == "" {
-				repo = ".github"
+			if len(params.DiscussionNumbers) == 0 {
+				return utils.NewToolResultError("discussionNumbers must not be empty"), nil, nil
+			}
+			if len(params.DiscussionNumbers) > maxBatchDiscussions {
+				return utils.NewToolResultError(fmt.Sprintf("at most %d discussions can be measured at once", maxBatchDiscussions)), nil, nil
 			}
 
 			client, err := deps.GetGQLClient(ctx)
@@ -555,70 +5947,93 @@ func ListDiscussionCategories(t translations.TranslationHelperFunc) inventory.Se
 				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
 			}
 
-			var q struct {
-				Repository struct {
-					DiscussionCategories struct {
-						Nodes []struct {
-							ID   githubv4.ID
-							Name githubv4.String
-						}
-						PageInfo struct {
-							HasNextPage     githubv4.Boolean
-							HasPreviousPage githubv4.Boolean
-							StartCursor     githubv4.String
-							EndCursor       githubv4.String
-						}
-						TotalCount int
-					} `graphql:"discussionCategories(first: $first)"`
-				} `graphql:"repository(owner: $owner, name: $repo)"`
-			}
-			vars := map[string]interface{}{
-				"owner": githubv4.String(owner),
-				"repo":  githubv4.String(repo),
-				"first": githubv4.Int(25),
-			}
-			if err := client.Query(ctx, &q, vars); err != nil {
-				return utils.NewToolResultError(err.Error()), nil, nil
-			}
-
-			var categories []map[string]string
-			for _, c := range q.Repository.DiscussionCategories.Nodes {
-				categories = append(categories, map[string]string{
-					"id":   fmt.Sprint(c.ID),
-					"name": string(c.Name),
-				})
-			}
-
-			// Create response with pagination info
-			response := map[string]interface{}{
-				"categories": categories,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     q.Repository.DiscussionCategories.PageInfo.HasNextPage,
-					"hasPreviousPage": q.Repository.DiscussionCategories.PageInfo.HasPreviousPage,
-					"startCursor":     string(q.Repository.DiscussionCategories.PageInfo.StartCursor),
-					"endCursor":       string(q.Repository.DiscussionCategories.PageInfo.EndCursor),
-				},
-				"totalCount": q.Repository.DiscussionCategories.TotalCount,
+			results := make([]batchItemResult, 0, len(params.DiscussionNumbers))
+			for _, number := range params.DiscussionNumbers {
+				metric, err := discussionFirstResponseTime(ctx, client, params.Owner, params.Repo, number)
+				if err != nil {
+					results = append(results, batchItemError(int(number), err))
+					continue
+				}
+				results = append(results, batchItemOK(int(number), metric))
 			}
 
-			out, err := json.Marshal(response)
+			out, err := json.Marshal(batchResponse(results))
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal discussion categories: %w", err)
+				return nil, nil, fmt.Errorf("failed to marshal first response times: %w", err)
 			}
 			return utils.NewToolResultText(string(out)), nil, nil
 		},
 	)
 }
 
-func CreateDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
+// discussionFirstResponseTime measures the time from a discussion's creation to the first comment
+// not written by its author, among its first 100 comments.
+func discussionFirstResponseTime(ctx context.Context, client *githubv4.Client, owner, repo string, discussionNumber int32) (map[string]any, error) {
+	var q struct {
+		Repository struct {
+			Discussion struct {
+				CreatedAt githubv4.DateTime
+				Author    *struct {
+					Login githubv4.String
+				}
+				Comments struct {
+					Nodes []struct {
+						CreatedAt githubv4.DateTime
+						Author    *struct {
+							Login githubv4.String
+						}
+					}
+					PageInfo struct {
+						HasNextPage githubv4.Boolean
+					}
+				} `graphql:"comments(first: 100)"`
+			} `graphql:"discussion(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner":            githubv4.String(owner),
+		"repo":             githubv4.String(repo),
+		"discussionNumber": githubv4.Int(discussionNumber),
+	}
+	if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+		return nil, err
+	}
+	d := q.Repository.Discussion
+
+	metric := map[string]any{
+		"createdAt":                  d.CreatedAt.Time,
+		"responded":                  false,
+		"firstResponseAt":            nil,
+		"firstResponder":             nil,
+		"timeToFirstResponseSeconds": nil,
+	}
+	for _, c := range d.Comments.Nodes {
+		// Authors are null for deleted accounts, whose comments count as responses
+		if c.Author != nil && d.Author != nil && c.Author.Login == d.Author.Login {
+			continue
+		}
+		metric["responded"] = true
+		metric["firstResponseAt"] = c.CreatedAt.Time
+		if c.Author != nil {
+			metric["firstResponder"] = string(c.Author.Login)
+		}
+		metric["timeToFirstResponseSeconds"] = int64(c.CreatedAt.Sub(d.CreatedAt.Time).Seconds())
+		return metric, nil
+	}
+	// A response may be among the comments that weren't fetched
+	metric["truncated"] = bool(d.Comments.PageInfo.HasNextPage)
+	return metric, nil
+}
+
+func GetDiscussionAnswerCandidates(t translations.TranslationHelperFunc) inventory.ServerTool {
 	return NewTool(
 		ToolsetMetadataDiscussions,
 		mcp.Tool{
-			Name:        "create_discussion",
-			Description: t("TOOL_CREATE_DISCUSSION_DESCRIPTION", "Create a new discussion in a repository."),
+			Name:        "get_discussion_answer_candidates",
+			Description: t("TOOL_GET_DISCUSSION_ANSWER_CANDIDATES_DESCRIPTION", "Get the most upvoted comments of an unanswered discussion, as candidates for marking as the answer. Only the first 100 comments are considered."),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_CREATE_DISCUSSION_USER_TITLE", "Create discussion"),
-				ReadOnlyHint: false,
+				Title:        t("TOOL_GET_DISCUSSION_ANSWER_CANDIDATES_USER_TITLE", "Get discussion answer candidates"),
+				ReadOnlyHint: true,
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
@@ -631,146 +6046,154 @@ func CreateDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool
 						Type:        "string",
 						Description: "Repository name",
 					},
-					"title": {
-						Type:        "string",
-						Description: "Discussion title",
-					},
-					"body": {
-						Type:        "string",
-						Description: "Discussion body (Markdown)",
-					},
-					"category_id": {
-						Type:        "string",
-						Description: "Discussion category node ID. If provided, this is used directly.",
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
 					},
-					"category_name": {
-						Type:        "string",
-						Description: "Discussion category name. If provided, it will be resolved to a category ID.",
+					"limit": {
+						Type:        "number",
+						Description: "Maximum number of candidates to return. Defaults to 5.",
+						Minimum:     jsonschema.Ptr(1.0),
+						Maximum:     jsonschema.Ptr(100.0),
 					},
 				},
-				Required: []string{"owner", "repo", "title", "body"},
+				Required: []string{"owner", "repo", "discussionNumber"},
 			},
 		},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 			var params struct {
-				Owner        string
-				Repo         string
-				Title        string
-				Body         string
-				CategoryID   string `mapstructure:"category_id"`
-				CategoryName string `mapstructure:"category_name"`
+				Owner            string
+				Repo             string
+				DiscussionNumber int32
 			}
 			if err := mapstructure.Decode(args, &params); err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
+			limit, err := OptionalIntParamWithDefault(args, "limit", 5)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if limit < 1 || limit > 100 {
+				return utils.NewToolResultError(fmt.Sprintf("limit must be between 1 and 100, got %d", limit)), nil, nil
+			}
+
 			client, err := deps.GetGQLClient(ctx)
 			if err != nil {
 				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
 			}
 
-			repoID, err := getRepositoryID(ctx, client, params.Owner, params.Repo)
-			if err != nil {
+			var q struct {
+				Repository struct {
+					Discussion struct {
+						IsAnswered githubv4.Boolean
+						Comments   struct {
+							Nodes []struct {
+								ID          githubv4.ID
+								Body        githubv4.String
+								URL         githubv4.String `graphql:"url"`
+								UpvoteCount githubv4.Int
+								Author      *struct {
+									Login githubv4.String
+								}
+							}
+							PageInfo PageInfoFragment
+						} `graphql:"comments(first: 100)"`
+					} `graphql:"discussion(number: $discussionNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner":            githubv4.String(params.Owner),
+				"repo":             githubv4.String(params.Repo),
+				"discussionNumber": githubv4.Int(params.DiscussionNumber),
+			}
+			if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			categoryID, err := resolveDiscussionCategoryID(ctx, client, params.Owner, params.Repo, params.CategoryID, params.CategoryName)
-			if err != nil {
-				return utils.NewToolResultError(err.Error()), nil, nil
+			discussion := q.Repository.Discussion
+			if discussion.IsAnswered {
+				return utils.NewToolResultError(fmt.Sprintf("discussion #%d in %s/%s is already answered", params.DiscussionNumber, params.Owner, params.Repo)), nil, nil
 			}
 
-			var mutation struct {
-				CreateDiscussion struct {
-					Discussion struct {
-						ID     githubv4.ID
-						Number githubv4.Int
-						URL    githubv4.String `graphql:"url"`
-					}
-				} `graphql:"createDiscussion(input: $input)"`
+			nodes := discussion.Comments.Nodes
+			// Stable, so equally upvoted comments keep their chronological order
+			sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].UpvoteCount > nodes[j].UpvoteCount })
+			if len(nodes) > limit {
+				nodes = nodes[:limit]
 			}
 
-			if err := client.Mutate(ctx, &mutation, githubv4.CreateDiscussionInput{
-				RepositoryID: repoID,
-				Title:        githubv4.String(params.Title),
-				Body:         githubv4.String(params.Body),
-				CategoryID:   *categoryID,
-			}, nil); err != nil {
-				return utils.NewToolResultError(err.Error()), nil, nil
+			candidates := make([]map[string]any, 0, len(nodes))
+			for _, c := range nodes {
+				candidate := map[string]any{
+					"id":          fmt.Sprint(c.ID),
+					"body":        string(c.Body),
+					"url":         string(c.URL),
+					"upvoteCount": int(c.UpvoteCount),
+					"author":      nil,
+				}
+				if c.Author != nil {
+					candidate["author"] = string(c.Author.Login)
+				}
+				candidates = append(candidates, candidate)
 			}
 
 			out, err := json.Marshal(map[string]any{
-				"id":     fmt.Sprint(mutation.CreateDiscussion.Discussion.ID),
-				"number": int(mutation.CreateDiscussion.Discussion.Number),
-				"url":    string(mutation.CreateDiscussion.Discussion.URL),
+				"candidates": candidates,
+				"truncated":  discussion.Comments.PageInfo.HasNextPage,
 			})
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal create discussion response: %w", err)
+				return nil, nil, fmt.Errorf("failed to marshal answer candidates: %w", err)
 			}
 			return utils.NewToolResultText(string(out)), nil, nil
 		},
 	)
 }
 
-func UpdateDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
+func VerifyDiscussionComment(t translations.TranslationHelperFunc) inventory.ServerTool {
 	return NewTool(
 		ToolsetMetadataDiscussions,
 		mcp.Tool{
-			Name:        "update_discussion",
-			Description: t("TOOL_UPDATE_DISCUSSION_DESCRIPTION", "Update a discussion (title/body/category) in a repository."),
+			Name:        "verify_discussion_comment",
+			Description: t("TOOL_VERIFY_DISCUSSION_COMMENT_DESCRIPTION", "Resolve the discussion a comment belongs to, and check it against an expected repository and discussion number."),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_UPDATE_DISCUSSION_USER_TITLE", "Update discussion"),
-				ReadOnlyHint: false,
+				Title:        t("TOOL_VERIFY_DISCUSSION_COMMENT_USER_TITLE", "Verify discussion comment"),
+				ReadOnlyHint: true,
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
+					"comment_id": {
+						Type:        "string",
+						Description: "Discussion comment node ID",
+					},
 					"owner": {
 						Type:        "string",
-						Description: "Repository owner",
+						Description: "Expected repository owner",
 					},
 					"repo": {
 						Type:        "string",
-						Description: "Repository name",
+						Description: "Expected repository name",
 					},
 					"discussionNumber": {
 						Type:        "number",
-						Description: "Discussion Number",
-					},
-					"title": {
-						Type:        "string",
-						Description: "New discussion title (optional)",
-					},
-					"body": {
-						Type:        "string",
-						Description: "New discussion body (optional)",
-					},
-					"category_id": {
-						Type:        "string",
-						Description: "New discussion category node ID (optional). If provided, this is used directly.",
-					},
-					"category_name": {
-						Type:        "string",
-						Description: "New discussion category name (optional). If provided, it will be resolved to a category ID.",
+						Description: "Expected discussion number",
 					},
 				},
-				Required: []string{"owner", "repo", "discussionNumber"},
+				Required: []string{"comment_id"},
 			},
 		},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 			var params struct {
+				CommentID        string `mapstructure:"comment_id"`
 				Owner            string
 				Repo             string
 				DiscussionNumber int32
-				Title            string
-				Body             string
-				CategoryID       string `mapstructure:"category_id"`
-				CategoryName     string `mapstructure:"category_name"`
 			}
 			if err := mapstructure.Decode(args, &params); err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
-
-			if params.Title == "" && params.Body == "" && params.CategoryID == "" && params.CategoryName == "" {
-				return utils.NewToolResultError("at least one of title, body, category_id, or category_name must be provided"), nil, nil
+			if params.CommentID == "" {
+				return utils.NewToolResultError("missing required parameter: comment_id"), nil, nil
 			}
 
 			client, err := deps.GetGQLClient(ctx)
@@ -778,277 +6201,537 @@ func UpdateDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool
 				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
 			}
 
-			discussionID, err := getDiscussionID(ctx, client, params.Owner, params.Repo, params.DiscussionNumber)
-			if err != nil {
-				return utils.NewToolResultError(err.Error()), nil, nil
-			}
-
-			var title *githubv4.String
-			if params.Title != "" {
-				s := githubv4.String(params.Title)
-				title = &s
-			}
-			var body *githubv4.String
-			if params.Body != "" {
-				s := githubv4.String(params.Body)
-				body = &s
-			}
-
-			var categoryID *githubv4.ID
-			if params.CategoryID != "" || params.CategoryName != "" {
-				resolved, err := resolveDiscussionCategoryID(ctx, client, params.Owner, params.Repo, params.CategoryID, params.CategoryName)
-				if err != nil {
-					return utils.NewToolResultError(err.Error()), nil, nil
-				}
-				categoryID = resolved
+			var q struct {
+				Node struct {
+					TypeName githubv4.String `graphql:"__typename"`
+					Comment  struct {
+						Discussion struct {
+							Number     githubv4.Int
+							URL        githubv4.String `graphql:"url"`
+							Repository struct {
+								Name  githubv4.String
+								Owner struct {
+									Login githubv4.String
+								}
+							}
+						}
+					} `graphql:"... on DiscussionComment"`
+				} `graphql:"node(id: $commentId)"`
 			}
-
-			var mutation struct {
-				UpdateDiscussion struct {
-					Discussion struct {
-						ID     githubv4.ID
-						Number githubv4.Int
-						URL    githubv4.String `graphql:"url"`
-					}
-				} `graphql:"updateDiscussion(input: $input)"`
+			if err := queryWithRequestID(ctx, client, &q, map[string]any{"commentId": githubv4.ID(params.CommentID)}); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
-
-			input := githubv4.UpdateDiscussionInput{
-				DiscussionID: discussionID,
-				Title:        title,
-				Body:         body,
-				CategoryID:   categoryID,
+			if q.Node.TypeName != "DiscussionComment" {
+				return utils.NewToolResultError(fmt.Sprintf("%s is not a discussion comment", params.CommentID)), nil, nil
 			}
 
-			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
-				return utils.NewToolResultError(err.Error()), nil, nil
-			}
+			parent := q.Node.Comment.Discussion
+			parentOwner := string(parent.Repository.Owner.Login)
+			parentRepo := string(parent.Repository.Name)
+			// Only the expectations that were provided are checked
+			matches := (params.Owner == "" || strings.EqualFold(params.Owner, parentOwner)) &&
+				(params.Repo == "" || strings.EqualFold(params.Repo, parentRepo)) &&
+				(params.DiscussionNumber == 0 || params.DiscussionNumber == int32(parent.Number))
 
 			out, err := json.Marshal(map[string]any{
-				"id":     fmt.Sprint(mutation.UpdateDiscussion.Discussion.ID),
-				"number": int(mutation.UpdateDiscussion.Discussion.Number),
-				"url":    string(mutation.UpdateDiscussion.Discussion.URL),
+				"matches":          matches,
+				"owner":            parentOwner,
+				"repo":             parentRepo,
+				"discussionNumber": int(parent.Number),
+				"url":              string(parent.URL),
 			})
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal update discussion response: %w", err)
+				return nil, nil, fmt.Errorf("failed to marshal discussion comment verification: %w", err)
 			}
 			return utils.NewToolResultText(string(out)), nil, nil
 		},
 	)
 }
 
-func AddDiscussionComment(t translations.TranslationHelperFunc) inventory.ServerTool {
+// discussionReference is an issue or pull request found by get_discussion_references.
+type discussionReference struct {
+	Number     githubv4.Int
+	Title      githubv4.String
+	URL        githubv4.String `graphql:"url"`
+	Repository struct {
+		NameWithOwner githubv4.String
+	}
+}
+
+// labelSearchQualifiers returns the search qualifiers matching discussions with all or any of
+// labels: a label qualifier per label for all, and a single one listing the labels, which GitHub
+// search treats as a disjunction, for any. Labels are quoted so names with spaces stay whole.
+func labelSearchQualifiers(labels []string, match string) (string, error) {
+	quoted := make([]string, 0, len(labels))
+	for _, label := range labels {
+		// Search syntax has no escape for quotes within a quoted value
+		if strings.Contains(label, `"`) {
+			return "", fmt.Errorf("label %q can't be searched for, as label names in search queries can't contain double quotes", label)
+		}
+		quoted = append(quoted, `"`+label+`"`)
+	}
+	switch match {
+	case "", "all":
+		for i, q := range quoted {
+			quoted[i] = "label:" + q
+		}
+		return strings.Join(quoted, " "), nil
+	case "any":
+		return "label:" + strings.Join(quoted, ","), nil
+	default:
+		return "", fmt.Errorf("invalid labelMatch %q: must be one of all, any", match)
+	}
+}
+
+func SearchDiscussions(t translations.TranslationHelperFunc) inventory.ServerTool {
 	return NewTool(
 		ToolsetMetadataDiscussions,
 		mcp.Tool{
-			Name:        "add_discussion_comment",
-			Description: t("TOOL_ADD_DISCUSSION_COMMENT_DESCRIPTION", "Add a comment to a discussion."),
+			Name:        "search_discussions",
+			Description: t("TOOL_SEARCH_DISCUSSIONS_DESCRIPTION", "Search discussions with GitHub's search syntax, for keyword and qualifier searches that list_discussions can't express."),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_ADD_DISCUSSION_COMMENT_USER_TITLE", "Add discussion comment"),
-				ReadOnlyHint: false,
+				Title:        t("TOOL_SEARCH_DISCUSSIONS_USER_TITLE", "Search discussions"),
+				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithCursorPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
+					"query": {
+						Type:        "string",
+						Description: "Search query using GitHub's discussion search syntax, e.g. 'flaky test is:open in:title' or 'is:unanswered category:Q&A'.",
+					},
 					"owner": {
 						Type:        "string",
-						Description: "Repository owner",
+						Description: "Repository owner. With repo, scopes the search to that repository.",
 					},
 					"repo": {
 						Type:        "string",
-						Description: "Repository name",
-					},
-					"discussionNumber": {
-						Type:        "number",
-						Description: "Discussion Number",
+						Description: "Repository name. With owner, scopes the search to that repository.",
 					},
-					"body": {
-						Type:        "string",
-						Description: "Comment body (Markdown)",
+					"labels": {
+						Type:        "array",
+						Description: "Only return discussions with these labels, added to the query as label qualifiers. Names are quoted for you, so names with spaces can be given as they are; names can't contain double quotes.",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
 					},
-					"reply_to_id": {
+					"labelMatch": {
 						Type:        "string",
-						Description: "Optional discussion comment node ID to reply to.",
+						Description: "Whether discussions must have all of the labels (the default) or any of them.",
+						Enum:        []any{"all", "any"},
 					},
 				},
-				Required: []string{"owner", "repo", "discussionNumber", "body"},
-			},
+				Required: []string{"query"},
+			}),
 		},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-			var params struct {
-				Owner            string
-				Repo             string
-				DiscussionNumber int32
-				Body             string
-				ReplyToID        string `mapstructure:"reply_to_id"`
+			query, err := RequiredParam[string](args, "query")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
-			if err := mapstructure.Decode(args, &params); err != nil {
+			owner, err := OptionalParam[string](args, "owner")
+			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
-
-			client, err := deps.GetGQLClient(ctx)
+			repo, err := OptionalParam[string](args, "repo")
 			if err != nil {
-				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if owner != "" && repo != "" && !hasRepoFilter(query) {
+				query = fmt.Sprintf("repo:%s/%s %s", owner, repo, query)
+			}
+			labels, err := OptionalStringArrayParam(args, "labels")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			labelMatch, err := OptionalParam[string](args, "labelMatch")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if len(labels) > 0 {
+				qualifiers, err := labelSearchQualifiers(labels, labelMatch)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				query = query + " " + qualifiers
 			}
 
-			discussionID, err := getDiscussionID(ctx, client, params.Owner, params.Repo, params.DiscussionNumber)
+			pagination, err := OptionalCursorPaginationParams(args)
+			if err != nil {
+				return nil, nil, err
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			var replyToID *githubv4.ID
-			if params.ReplyToID != "" {
-				id := githubv4.ID(params.ReplyToID)
-				replyToID = &id
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
 			}
 
-			var mutation struct {
-				AddDiscussionComment struct {
-					Comment struct {
-						ID  githubv4.ID
-						URL githubv4.String `graphql:"url"`
+			var q struct {
+				Search struct {
+					DiscussionCount githubv4.Int
+					Nodes           []struct {
+						Discussion NodeFragment `graphql:"... on Discussion"`
 					}
-				} `graphql:"addDiscussionComment(input: $input)"`
+					PageInfo PageInfoFragment
+				} `graphql:"search(query: $query, type: DISCUSSION, first: $first, after: $after)"`
+			}
+			vars := map[string]any{
+				"query":       githubv4.String(query),
+				"first":       githubv4.Int(*paginationParams.First),
+				"includeBody": githubv4.Boolean(false),
+			}
+			if paginationParams.After != nil {
+				vars["after"] = githubv4.String(*paginationParams.After)
+			} else {
+				vars["after"] = (*githubv4.String)(nil)
+			}
+			if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			if err := client.Mutate(ctx, &mutation, githubv4.AddDiscussionCommentInput{
-				DiscussionID: discussionID,
-				Body:         githubv4.String(params.Body),
-				ReplyToID:    replyToID,
-			}, nil); err != nil {
-				return utils.NewToolResultError(err.Error()), nil, nil
+			discussions := make([]*listedDiscussion, 0, len(q.Search.Nodes))
+			for _, node := range q.Search.Nodes {
+				discussions = append(discussions, fragmentToListedDiscussion(node.Discussion))
 			}
 
 			out, err := json.Marshal(map[string]any{
-				"id":  fmt.Sprint(mutation.AddDiscussionComment.Comment.ID),
-				"url": string(mutation.AddDiscussionComment.Comment.URL),
+				"discussions": discussions,
+				"pageInfo": map[string]any{
+					"hasNextPage":     q.Search.PageInfo.HasNextPage,
+					"hasPreviousPage": q.Search.PageInfo.HasPreviousPage,
+					"startCursor":     string(q.Search.PageInfo.StartCursor),
+					"endCursor":       string(q.Search.PageInfo.EndCursor),
+				},
+				"totalCount": int(q.Search.DiscussionCount),
 			})
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal add discussion comment response: %w", err)
+				return nil, nil, fmt.Errorf("failed to marshal discussion search results: %w", err)
 			}
 			return utils.NewToolResultText(string(out)), nil, nil
 		},
 	)
 }
 
-func UpdateDiscussionComment(t translations.TranslationHelperFunc) inventory.ServerTool {
+func SearchDiscussionComments(t translations.TranslationHelperFunc) inventory.ServerTool {
 	return NewTool(
 		ToolsetMetadataDiscussions,
 		mcp.Tool{
-			Name:        "update_discussion_comment",
-			Description: t("TOOL_UPDATE_DISCUSSION_COMMENT_DESCRIPTION", "Update an existing discussion comment."),
+			Name:        "search_discussion_comments",
+			Description: t("TOOL_SEARCH_DISCUSSION_COMMENTS_DESCRIPTION", "Find the comments of a discussion whose body contains some text (case-insensitive). This scans the discussion's first 1000 top-level comments rather than using GitHub search, so it also finds partial words."),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_UPDATE_DISCUSSION_COMMENT_USER_TITLE", "Update discussion comment"),
-				ReadOnlyHint: false,
+				Title:        t("TOOL_SEARCH_DISCUSSION_COMMENTS_USER_TITLE", "Search discussion comments"),
+				ReadOnlyHint: true,
 			},
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
-					"comment_id": {
+					"owner": {
 						Type:        "string",
-						Description: "Discussion comment node ID",
+						Description: "Repository owner",
 					},
-					"body": {
+					"repo": {
 						Type:        "string",
-						Description: "New comment body (Markdown)",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+					"query": {
+						Type:        "string",
+						Description: "Text to find in comment bodies",
 					},
 				},
-				Required: []string{"comment_id", "body"},
+				Required: []string{"owner", "repo", "discussionNumber", "query"},
 			},
 		},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 			var params struct {
-				CommentID string `mapstructure:"comment_id"`
-				Body      string
+				Owner            string
+				Repo             string
+				DiscussionNumber int32
+				Query            string
 			}
 			if err := mapstructure.Decode(args, &params); err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
+			if params.Query == "" {
+				return utils.NewToolResultError("missing required parameter: query"), nil, nil
+			}
 
 			client, err := deps.GetGQLClient(ctx)
 			if err != nil {
 				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
 			}
 
-			var mutation struct {
-				UpdateDiscussionComment struct {
-					Comment struct {
-						ID  githubv4.ID
-						URL githubv4.String `graphql:"url"`
+			needle := strings.ToLower(params.Query)
+			matches := []map[string]any{}
+			scanned := 0
+			truncated, err := scanPages(ctx, discussionScanMaxPages, func(after *githubv4.String) (PageInfoFragment, bool, error) {
+				var q struct {
+					Repository struct {
+						Discussion struct {
+							Comments struct {
+								Nodes []struct {
+									ID     githubv4.ID
+									Body   githubv4.String
+									URL    githubv4.String `graphql:"url"`
+									Author *struct {
+										Login githubv4.String
+									}
+								}
+								PageInfo PageInfoFragment
+							} `graphql:"comments(first: $first, after: $after)"`
+						} `graphql:"discussion(number: $discussionNumber)"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}
+				vars := map[string]any{
+					"owner":            githubv4.String(params.Owner),
+					"repo":             githubv4.String(params.Repo),
+					"discussionNumber": githubv4.Int(params.DiscussionNumber),
+					"first":            githubv4.Int(discussionScanPageSize),
+					"after":            after,
+				}
+				if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+					return PageInfoFragment{}, false, err
+				}
+				for _, c := range q.Repository.Discussion.Comments.Nodes {
+					scanned++
+					if !strings.Contains(strings.ToLower(string(c.Body)), needle) {
+						continue
 					}
-				} `graphql:"updateDiscussionComment(input: $input)"`
-			}
-
-			if err := client.Mutate(ctx, &mutation, githubv4.UpdateDiscussionCommentInput{
-				CommentID: githubv4.ID(params.CommentID),
-				Body:      githubv4.String(params.Body),
-			}, nil); err != nil {
+					match := map[string]any{
+						"id":     fmt.Sprint(c.ID),
+						"url":    string(c.URL),
+						"body":   string(c.Body),
+						"author": nil,
+					}
+					if c.Author != nil {
+						match["author"] = string(c.Author.Login)
+					}
+					matches = append(matches, match)
+				}
+				return q.Repository.Discussion.Comments.PageInfo, false, nil
+			})
+			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
 			out, err := json.Marshal(map[string]any{
-				"id":  fmt.Sprint(mutation.UpdateDiscussionComment.Comment.ID),
-				"url": string(mutation.UpdateDiscussionComment.Comment.URL),
+				"comments":  matches,
+				"scanned":   scanned,
+				"truncated": truncated,
 			})
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal update discussion comment response: %w", err)
+				return nil, nil, fmt.Errorf("failed to marshal matching comments: %w", err)
 			}
 			return utils.NewToolResultText(string(out)), nil, nil
 		},
 	)
 }
 
-func DeleteDiscussionComment(t translations.TranslationHelperFunc) inventory.ServerTool {
+func GetDiscussionReferences(t translations.TranslationHelperFunc) inventory.ServerTool {
 	return NewTool(
 		ToolsetMetadataDiscussions,
 		mcp.Tool{
-			Name:        "delete_discussion_comment",
-			Description: t("TOOL_DELETE_DISCUSSION_COMMENT_DESCRIPTION", "Delete an existing discussion comment."),
+			Name:        "get_discussion_references",
+			Description: t("TOOL_GET_DISCUSSION_REFERENCES_DESCRIPTION", "List issues and pull requests that link to a discussion, found by searching for the discussion's URL."),
 			Annotations: &mcp.ToolAnnotations{
-				Title:        t("TOOL_DELETE_DISCUSSION_COMMENT_USER_TITLE", "Delete discussion comment"),
-				ReadOnlyHint: false,
+				Title:        t("TOOL_GET_DISCUSSION_REFERENCES_USER_TITLE", "Get discussion references"),
+				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithPageTokens(WithCursorPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
-					"comment_id": {
+					"owner": {
 						Type:        "string",
-						Description: "Discussion comment node ID",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
 					},
 				},
-				Required: []string{"comment_id"},
-			},
+				Required: []string{"owner", "repo", "discussionNumber"},
+			})),
 		},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 			var params struct {
-				CommentID string `mapstructure:"comment_id"`
+				Owner            string
+				Repo             string
+				DiscussionNumber int32
 			}
 			if err := mapstructure.Decode(args, &params); err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
+			usePageTokens, err := OptionalPageTokenParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			pagination, err := OptionalCursorPaginationParams(args)
+			if err != nil {
+				return nil, nil, err
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
 
 			client, err := deps.GetGQLClient(ctx)
 			if err != nil {
 				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
 			}
 
-			var mutation struct {
-				DeleteDiscussionComment struct {
-					ClientMutationID githubv4.String
-				} `graphql:"deleteDiscussionComment(input: $input)"`
+			var discussionQuery struct {
+				Repository struct {
+					Discussion struct {
+						URL githubv4.String `graphql:"url"`
+					} `graphql:"discussion(number: $discussionNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			if err := queryWithRequestID(ctx, client, &discussionQuery, map[string]any{
+				"owner":            githubv4.String(params.Owner),
+				"repo":             githubv4.String(params.Repo),
+				"discussionNumber": githubv4.Int(params.DiscussionNumber),
+			}); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			if err := client.Mutate(ctx, &mutation, githubv4.DeleteDiscussionCommentInput{
-				ID: githubv4.ID(params.CommentID),
-			}, nil); err != nil {
+			// Discussions have no timeline, so there are no cross-reference events to read.
+			// Instead, search issues and pull requests for the discussion's URL.
+			var q struct {
+				Search struct {
+					IssueCount githubv4.Int
+					Nodes      []struct {
+						TypeName    githubv4.String     `graphql:"__typename"`
+						Issue       discussionReference `graphql:"... on Issue"`
+						PullRequest discussionReference `graphql:"... on PullRequest"`
+					}
+					PageInfo PageInfoFragment
+				} `graphql:"search(query: $query, type: ISSUE, first: $first, after: $after)"`
+			}
+			vars := map[string]any{
+				"query": githubv4.String(fmt.Sprintf("%q", string(discussionQuery.Repository.Discussion.URL))),
+				"first": githubv4.Int(*paginationParams.First),
+			}
+			if paginationParams.After != nil {
+				vars["after"] = githubv4.String(*paginationParams.After)
+			} else {
+				vars["after"] = (*githubv4.String)(nil)
+			}
+			if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			return utils.NewToolResultText("discussion comment deleted successfully"), nil, nil
+			references := make([]map[string]any, 0, len(q.Search.Nodes))
+			for _, node := range q.Search.Nodes {
+				ref := node.Issue
+				if node.TypeName == "PullRequest" {
+					ref = node.PullRequest
+				}
+				references = append(references, map[string]any{
+					"type":       string(node.TypeName),
+					"repository": string(ref.Repository.NameWithOwner),
+					"number":     int(ref.Number),
+					"title":      string(ref.Title),
+					"url":        string(ref.URL),
+				})
+			}
+
+			response := map[string]any{
+				"references": references,
+				"pageInfo": map[string]any{
+					"hasNextPage":     q.Search.PageInfo.HasNextPage,
+					"hasPreviousPage": q.Search.PageInfo.HasPreviousPage,
+					"startCursor":     string(q.Search.PageInfo.StartCursor),
+					"endCursor":       string(q.Search.PageInfo.EndCursor),
+				},
+				"totalCount": int(q.Search.IssueCount),
+			}
+			if usePageTokens {
+				replacePageInfoWithToken(response, q.Search.PageInfo.HasNextPage, string(q.Search.PageInfo.EndCursor))
+			}
+
+			out, err := json.Marshal(response)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal discussion references: %w", err)
+			}
+			return utils.NewToolResultText(string(out)), nil, nil
 		},
 	)
 }
 
+// graphQLErrorKind is what a GraphQL error means for the caller, as told by classifyGraphQLError.
+type graphQLErrorKind int
+
+const (
+	graphQLErrorOther graphQLErrorKind = iota
+	// graphQLErrorNotFound is an object, such as a repository or discussion, that doesn't exist or
+	// that the token can't see
+	graphQLErrorNotFound
+	// graphQLErrorTransient is a 5xx response or a secondary rate limit, which may succeed if retried
+	graphQLErrorTransient
+)
+
+// graphQLNon200Prefix starts the error the GraphQL client returns for a response that isn't 200 OK,
+// followed by its status.
+const graphQLNon200Prefix = "non-200 OK status code: "
+
+// discussionNotFoundError is a discussion, or the repository it was looked up in, that GitHub
+// reported missing.
+type discussionNotFoundError struct {
+	owner, repo      string
+	discussionNumber int32
+}
+
+func (e *discussionNotFoundError) Error() string {
+	return fmt.Sprintf("discussion #%d not found in %s/%s", e.discussionNumber, e.owner, e.repo)
+}
+
+// classifyGraphQLError tells what a GraphQL error means. GitHub reports missing objects as
+// NOT_FOUND errors with a "Could not resolve to" message rather than with a status code, and the
+// client only keeps the message, so the status of failed responses is read from it too.
+func classifyGraphQLError(err error) graphQLErrorKind {
+	msg := err.Error()
+	var notFound *discussionNotFoundError
+	if errors.As(err, &notFound) || strings.Contains(msg, "Could not resolve to") {
+		return graphQLErrorNotFound
+	}
+	if strings.Contains(strings.ToLower(msg), "secondary rate limit") {
+		return graphQLErrorTransient
+	}
+	if i := strings.Index(msg, graphQLNon200Prefix); i >= 0 {
+		status, _, _ := strings.Cut(msg[i+len(graphQLNon200Prefix):], " ")
+		if code, err := strconv.Atoi(status); err == nil && code >= 500 {
+			return graphQLErrorTransient
+		}
+	}
+	return graphQLErrorOther
+}
+
+// discussionQueryError returns the error to surface for a failed query of discussion #number in
+// owner/repo: a not-found error naming the discussion when GitHub reports it or its repository
+// missing, and err otherwise. The not-found error keeps the request ID of err.
+func discussionQueryError(err error, owner, repo string, discussionNumber int32) error {
+	if classifyGraphQLError(err) == graphQLErrorNotFound {
+		notFound := &discussionNotFoundError{owner: owner, repo: repo, discussionNumber: discussionNumber}
+		if id := requestIDOf(err); id != "" {
+			return &githubRequestError{err: notFound, requestID: id}
+		}
+		return notFound
+	}
+	return err
+}
+
 func getDiscussionID(ctx context.Context, client *githubv4.Client, owner string, repo string, discussionNumber int32) (githubv4.ID, error) {
 	var q struct {
 		Repository struct {
@@ -1063,46 +6746,296 @@ func getDiscussionID(ctx context.Context, client *githubv4.Client, owner string,
 		"repo":             githubv4.String(repo),
 		"discussionNumber": githubv4.Int(discussionNumber),
 	}
-	if err := client.Query(ctx, &q, vars); err != nil {
+	if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+		if classifyGraphQLError(err) == graphQLErrorNotFound {
+			return "", discussionQueryError(err, owner, repo, discussionNumber)
+		}
 		return "", fmt.Errorf("failed to get discussion ID: %w", err)
 	}
 	return q.Repository.Discussion.ID, nil
 }
 
-func resolveDiscussionCategoryID(ctx context.Context, client *githubv4.Client, owner string, repo string, categoryID string, categoryName string) (*githubv4.ID, error) {
-	if categoryID != "" {
-		id := githubv4.ID(categoryID)
-		return &id, nil
+func getDiscussionIDAndBody(ctx context.Context, client *githubv4.Client, owner string, repo string, discussionNumber int32) (githubv4.ID, string, error) {
+	var q struct {
+		Repository struct {
+			Discussion struct {
+				ID   githubv4.ID
+				Body githubv4.String
+			} `graphql:"discussion(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
 	}
-	if categoryName == "" {
-		return nil, fmt.Errorf("either category_id or category_name is required")
+
+	vars := map[string]any{
+		"owner":            githubv4.String(owner),
+		"repo":             githubv4.String(repo),
+		"discussionNumber": githubv4.Int(discussionNumber),
+	}
+	if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+		if classifyGraphQLError(err) == graphQLErrorNotFound {
+			return "", "", discussionQueryError(err, owner, repo, discussionNumber)
+		}
+		return "", "", fmt.Errorf("failed to get discussion: %w", err)
+	}
+	return q.Repository.Discussion.ID, string(q.Repository.Discussion.Body), nil
+}
+
+// appendDiscussionBody joins the existing body and the appended text with a blank line,
+// so the appended text renders as its own Markdown paragraph.
+func appendDiscussionBody(existing string, appended string) string {
+	if existing == "" {
+		return appended
 	}
+	return strings.TrimRight(existing, "\n") + "\n\n" + appended
+}
 
+// getDiscussionLabels returns the discussion's node ID and the names of the labels currently applied to it.
+func getDiscussionLabels(ctx context.Context, client *githubv4.Client, owner string, repo string, discussionNumber int32) (githubv4.ID, []string, error) {
 	var q struct {
 		Repository struct {
-			DiscussionCategories struct {
-				Nodes []struct {
-					ID   githubv4.ID
-					Name githubv4.String
-				}
-			} `graphql:"discussionCategories(first: $first)"`
+			Discussion struct {
+				ID     githubv4.ID
+				Labels struct {
+					Nodes []struct {
+						Name githubv4.String
+					}
+				} `graphql:"labels(first: 100)"`
+			} `graphql:"discussion(number: $discussionNumber)"`
 		} `graphql:"repository(owner: $owner, name: $repo)"`
 	}
+
 	vars := map[string]any{
-		"owner": githubv4.String(owner),
-		"repo":  githubv4.String(repo),
-		"first": githubv4.Int(100),
+		"owner":            githubv4.String(owner),
+		"repo":             githubv4.String(repo),
+		"discussionNumber": githubv4.Int(discussionNumber),
+	}
+	if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+		if classifyGraphQLError(err) == graphQLErrorNotFound {
+			return "", nil, discussionQueryError(err, owner, repo, discussionNumber)
+		}
+		return "", nil, fmt.Errorf("failed to get discussion labels: %w", err)
+	}
+
+	names := make([]string, 0, len(q.Repository.Discussion.Labels.Nodes))
+	for _, l := range q.Repository.Discussion.Labels.Nodes {
+		names = append(names, string(l.Name))
+	}
+	return q.Repository.Discussion.ID, names, nil
+}
+
+// containsFold reports whether names contains name, ignoring case as GitHub does for label names.
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// countDiscussionParticipants counts the unique authors of a discussion and its first 100 comments.
+func countDiscussionParticipants(ctx context.Context, client *githubv4.Client, owner string, repo string, discussionNumber int32, discussionAuthor string) (int, error) {
+	var q struct {
+		Repository struct {
+			Discussion struct {
+				Comments struct {
+					Nodes []struct {
+						Author struct {
+							Login githubv4.String
+						}
+					}
+				} `graphql:"comments(first: 100)"`
+			} `graphql:"discussion(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner":            githubv4.String(owner),
+		"repo":             githubv4.String(repo),
+		"discussionNumber": githubv4.Int(discussionNumber),
+	}
+	if err := queryWithRequestID(ctx, client, &q, vars); err != nil {
+		return 0, fmt.Errorf("failed to get participants for discussion #%d: %w", discussionNumber, err)
+	}
+
+	participants := map[string]bool{}
+	if discussionAuthor != "" {
+		participants[discussionAuthor] = true
+	}
+	for _, c := range q.Repository.Discussion.Comments.Nodes {
+		// Comments from deleted accounts have no author login
+		if c.Author.Login != "" {
+			participants[string(c.Author.Login)] = true
+		}
+	}
+	return len(participants), nil
+}
+
+// scanPages calls fetch for successive pages of a cursor-paginated connection, starting from the
+// first page, until there are no more pages, fetch reports it is done, or maxPages pages have been
+// read. It returns true if the page limit stopped the scan while more pages remained.
+func scanPages(ctx context.Context, maxPages int, fetch func(after *githubv4.String) (pageInfo PageInfoFragment, done bool, err error)) (bool, error) {
+	var after *githubv4.String
+	for page := 0; page < maxPages; page++ {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		pageInfo, done, err := fetch(after)
+		if err != nil {
+			return false, err
+		}
+		if done || !pageInfo.HasNextPage {
+			return false, nil
+		}
+		cursor := pageInfo.EndCursor
+		after = &cursor
+	}
+	return true, nil
+}
+
+// bucketStart returns the start of the day, week (starting Monday), or month containing t.
+func bucketStart(t time.Time, interval string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch interval {
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case "week":
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	default:
+		return day
+	}
+}
+
+// WithDiscussionURL adds a url parameter, which tools read with applyDiscussionURL, as an
+// alternative to giving owner, repo and discussionNumber separately.
+func WithDiscussionURL(schema *jsonschema.Schema) *jsonschema.Schema {
+	schema.Properties["url"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Discussion URL, such as https://github.com/owner/repo/discussions/1, instead of owner, repo and discussionNumber, which it overrides",
+	}
+	return schema
+}
+
+// parseDiscussionURL splits a discussion URL of the form https://{host}/{owner}/{repo}/discussions/{n}
+// into its parts. Any host is accepted, for GitHub Enterprise Server.
+func parseDiscussionURL(rawURL string) (owner, repo string, number int32, err error) {
+	invalid := fmt.Errorf("invalid discussion url %q: expected https://github.com/{owner}/{repo}/discussions/{number}", rawURL)
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return "", "", 0, invalid
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] != "discussions" {
+		return "", "", 0, invalid
+	}
+	n, err := strconv.ParseInt(parts[3], 10, 32)
+	if err != nil || n < 1 {
+		return "", "", 0, invalid
+	}
+	return parts[0], parts[1], int32(n), nil
+}
+
+// applyDiscussionURL replaces owner, repo and discussionNumber in args with those of the url
+// parameter, if set. Otherwise it checks they were given, as they are then required.
+func applyDiscussionURL(args map[string]any) error {
+	rawURL, err := OptionalParam[string](args, "url")
+	if err != nil {
+		return err
+	}
+	if rawURL != "" {
+		owner, repo, number, err := parseDiscussionURL(rawURL)
+		if err != nil {
+			return err
+		}
+		args["owner"] = owner
+		args["repo"] = repo
+		args["discussionNumber"] = number
+		return nil
+	}
+	var target struct {
+		Owner            string
+		Repo             string
+		DiscussionNumber int32
+	}
+	if err := mapstructure.Decode(args, &target); err != nil {
+		return err
+	}
+	if target.Owner == "" || target.Repo == "" || target.DiscussionNumber == 0 {
+		return fmt.Errorf("either url or owner, repo and discussionNumber are required")
+	}
+	return nil
+}
+
+// optionalTimeParam returns the RFC3339 timestamp parameter p, or the zero time if it is absent.
+func optionalTimeParam(args map[string]any, p string) (time.Time, error) {
+	value, err := OptionalParam[string](args, p)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if value == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %q is not an RFC3339 timestamp", p, value)
 	}
-	if err := client.Query(ctx, &q, vars); err != nil {
-		return nil, fmt.Errorf("failed to list discussion categories: %w", err)
+	return parsed.UTC(), nil
+}
+
+// matchDiscussionCategories returns the categories named name, case-insensitively.
+func matchDiscussionCategories(categories []discussionCategoryRef, name string) []discussionCategoryRef {
+	var matches []discussionCategoryRef
+	for _, c := range categories {
+		if strings.EqualFold(string(c.Name), name) {
+			matches = append(matches, c)
+		}
+	}
+	return matches
+}
+
+// resolveDiscussionCategoryID returns categoryID if set, or else the ID of the category named
+// categoryName (case-insensitively), looked up through cache.
+func resolveDiscussionCategoryID(ctx context.Context, cache *DiscussionCategoryCache, client *githubv4.Client, owner string, repo string, categoryID string, categoryName string) (*githubv4.ID, error) {
+	category, err := resolveDiscussionCategory(ctx, cache, client, owner, repo, categoryID, categoryName)
+	if err != nil {
+		return nil, err
+	}
+	return &category.ID, nil
+}
+
+// resolveDiscussionCategory is resolveDiscussionCategoryID returning the whole category, including
+// whether it is answerable. Given a categoryID, nothing is looked up and only the ID is set.
+func resolveDiscussionCategory(ctx context.Context, cache *DiscussionCategoryCache, client *githubv4.Client, owner string, repo string, categoryID string, categoryName string) (*discussionCategoryRef, error) {
+	if categoryID != "" {
+		return &discussionCategoryRef{ID: githubv4.ID(categoryID)}, nil
+	}
+	if categoryName == "" {
+		return nil, fmt.Errorf("either category_id or category_name is required")
 	}
 
-	for _, c := range q.Repository.DiscussionCategories.Nodes {
-		if strings.EqualFold(string(c.Name), categoryName) {
-			id := c.ID
-			return &id, nil
+	categories, cached, err := cache.categories(ctx, client, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	matches := matchDiscussionCategories(categories, categoryName)
+	// The category may have been created or renamed since the categories were cached
+	if cached && len(matches) != 1 {
+		cache.Invalidate(owner, repo)
+		if categories, _, err = cache.categories(ctx, client, owner, repo); err != nil {
+			return nil, err
 		}
+		matches = matchDiscussionCategories(categories, categoryName)
 	}
 
-	return nil, fmt.Errorf("discussion category %q not found; use list_discussion_categories to see available categories", categoryName)
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("discussion category %q not found; use list_discussion_categories to see available categories", categoryName)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, c := range matches {
+			ids = append(ids, fmt.Sprint(c.ID))
+		}
+		return nil, fmt.Errorf("discussion category name %q is ambiguous, it matches categories %s; pass category_id instead", categoryName, strings.Join(ids, ", "))
+	}
 }
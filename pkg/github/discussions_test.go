@@ -3,14 +3,19 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"maps"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/github/github-mcp-server/internal/githubv4mock"
 	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/inventory"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v79/github"
 	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -229,25 +234,28 @@ func Test_ListDiscussions(t *testing.T) {
 
 	// Variables matching what GraphQL receives after JSON marshaling/unmarshaling
 	varsListAll := map[string]interface{}{
-		"owner": "owner",
-		"repo":  "repo",
-		"first": float64(30),
-		"after": (*string)(nil),
+		"owner":       "owner",
+		"repo":        "repo",
+		"first":       float64(30),
+		"after":       (*string)(nil),
+		"includeBody": false,
 	}
 
 	varsRepoNotFound := map[string]interface{}{
-		"owner": "owner",
-		"repo":  "nonexistent-repo",
-		"first": float64(30),
-		"after": (*string)(nil),
+		"owner":       "owner",
+		"repo":        "nonexistent-repo",
+		"first":       float64(30),
+		"after":       (*string)(nil),
+		"includeBody": false,
 	}
 
 	varsDiscussionsFiltered := map[string]interface{}{
-		"owner":      "owner",
-		"repo":       "repo",
-		"categoryId": "DIC_kwDOABC123",
-		"first":      float64(30),
-		"after":      (*string)(nil),
+		"owner":       "owner",
+		"repo":        "repo",
+		"categoryId":  "DIC_kwDOABC123",
+		"first":       float64(30),
+		"after":       (*string)(nil),
+		"includeBody": false,
 	}
 
 	varsOrderByCreatedAsc := map[string]interface{}{
@@ -257,6 +265,7 @@ func Test_ListDiscussions(t *testing.T) {
 		"orderByDirection": "ASC",
 		"first":            float64(30),
 		"after":            (*string)(nil),
+		"includeBody":      false,
 	}
 
 	varsOrderByUpdatedDesc := map[string]interface{}{
@@ -266,6 +275,7 @@ func Test_ListDiscussions(t *testing.T) {
 		"orderByDirection": "DESC",
 		"first":            float64(30),
 		"after":            (*string)(nil),
+		"includeBody":      false,
 	}
 
 	varsCategoryWithOrder := map[string]interface{}{
@@ -276,13 +286,15 @@ func Test_ListDiscussions(t *testing.T) {
 		"orderByDirection": "DESC",
 		"first":            float64(30),
 		"after":            (*string)(nil),
+		"includeBody":      false,
 	}
 
 	varsOrgLevel := map[string]interface{}{
-		"owner": "owner",
-		"repo":  ".github", // This is what gets set when repo is not provided
-		"first": float64(30),
-		"after": (*string)(nil),
+		"owner":       "owner",
+		"repo":        ".github", // This is what gets set when repo is not provided
+		"first":       float64(30),
+		"after":       (*string)(nil),
+		"includeBody": false,
 	}
 
 	tests := []struct {
@@ -404,13 +416,31 @@ func Test_ListDiscussions(t *testing.T) {
 			expectError:   false,
 			expectedCount: 4,
 		},
+		{
+			name: "repository level requires repo",
+			reqParams: map[string]interface{}{
+				"owner": "owner",
+				"level": "repository",
+			},
+			expectError: true,
+			errContains: "repo is required when level is repository",
+		},
+		{
+			name: "invalid level",
+			reqParams: map[string]interface{}{
+				"owner": "owner",
+				"level": "enterprise",
+			},
+			expectError: true,
+			errContains: `invalid level "enterprise"`,
+		},
 	}
 
 	// Define the actual query strings that match the implementation
-	qBasicNoOrder := "query($after:String$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,createdAt,updatedAt,closed,isAnswered,answerChosenAt,author{login},category{name},url},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
-	qWithCategoryNoOrder := "query($after:String$categoryId:ID!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after, categoryId: $categoryId){nodes{number,title,createdAt,updatedAt,closed,isAnswered,answerChosenAt,author{login},category{name},url},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
-	qBasicWithOrder := "query($after:String$first:Int!$orderByDirection:OrderDirection!$orderByField:DiscussionOrderField!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after, orderBy: { field: $orderByField, direction: $orderByDirection }){nodes{number,title,createdAt,updatedAt,closed,isAnswered,answerChosenAt,author{login},category{name},url},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
-	qWithCategoryAndOrder := "query($after:String$categoryId:ID!$first:Int!$orderByDirection:OrderDirection!$orderByField:DiscussionOrderField!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after, categoryId: $categoryId, orderBy: { field: $orderByField, direction: $orderByDirection }){nodes{number,title,createdAt,updatedAt,closed,isAnswered,answerChosenAt,author{login},category{name},url},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+	qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	qWithCategoryNoOrder := "query($after:String$categoryId:ID!$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after, categoryId: $categoryId){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	qBasicWithOrder := "query($after:String$first:Int!$includeBody:Boolean!$orderByDirection:OrderDirection!$orderByField:DiscussionOrderField!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after, orderBy: { field: $orderByField, direction: $orderByDirection }){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	qWithCategoryAndOrder := "query($after:String$categoryId:ID!$first:Int!$includeBody:Boolean!$orderByDirection:OrderDirection!$orderByField:DiscussionOrderField!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after, categoryId: $categoryId, orderBy: { field: $orderByField, direction: $orderByDirection }){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -470,13 +500,21 @@ func Test_ListDiscussions(t *testing.T) {
 					StartCursor     string `json:"startCursor"`
 					EndCursor       string `json:"endCursor"`
 				} `json:"pageInfo"`
-				TotalCount int `json:"totalCount"`
+				TotalCount int    `json:"totalCount"`
+				Warning    string `json:"warning"`
 			}
 			err = json.Unmarshal([]byte(text), &response)
 			require.NoError(t, err)
 
 			assert.Len(t, response.Discussions, tc.expectedCount, "Expected %d discussions, got %d", tc.expectedCount, len(response.Discussions))
 
+			// Falling back to the .github repository is reported
+			if _, hasRepo := tc.reqParams["repo"]; hasRepo {
+				assert.Empty(t, response.Warning)
+			} else {
+				assert.Contains(t, response.Warning, "read from its .github repository")
+			}
+
 			// Verify order if verifyOrder function is provided
 			if tc.verifyOrder != nil {
 				tc.verifyOrder(t, response.Discussions)
@@ -506,10 +544,11 @@ func Test_GetDiscussion(t *testing.T) {
 	assert.Contains(t, schema.Properties, "owner")
 	assert.Contains(t, schema.Properties, "repo")
 	assert.Contains(t, schema.Properties, "discussionNumber")
-	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "discussionNumber"})
+	assert.Contains(t, schema.Properties, "url")
+	assert.Empty(t, schema.Required, "owner, repo and discussionNumber can be given as url instead")
 
 	// Use exact string query that matches implementation output
-	qGetDiscussion := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,body,createdAt,closed,isAnswered,answerChosenAt,url,category{name}}}}"
+	qGetDiscussion := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,body,createdAt,author{login},closed,closedAt,isAnswered,answerChosenAt,url,category{name},upvoteCount,viewerHasUpvoted,labels(first: 10){nodes{name,color}},comments{totalCount},reactionGroups{content,users{totalCount},viewerHasReacted}}}}"
 
 	vars := map[string]interface{}{
 		"owner":            "owner",
@@ -527,24 +566,36 @@ func Test_GetDiscussion(t *testing.T) {
 			name: "successful retrieval",
 			response: githubv4mock.DataResponse(map[string]any{
 				"repository": map[string]any{"discussion": map[string]any{
-					"number":     1,
-					"title":      "Test Discussion Title",
-					"body":       "This is a test discussion",
-					"url":        "https://github.com/owner/repo/discussions/1",
-					"createdAt":  "2025-04-25T12:00:00Z",
-					"closed":     false,
-					"isAnswered": false,
-					"category":   map[string]any{"name": "General"},
+					"number":           1,
+					"title":            "Test Discussion Title",
+					"body":             "This is a test discussion",
+					"url":              "https://github.com/owner/repo/discussions/1",
+					"createdAt":        "2025-04-25T12:00:00Z",
+					"closed":           false,
+					"isAnswered":       false,
+					"category":         map[string]any{"name": "General"},
+					"upvoteCount":      7,
+					"viewerHasUpvoted": true,
+					"comments":         map[string]any{"totalCount": 12},
+					"reactionGroups": []map[string]any{
+						{"content": "THUMBS_UP", "users": map[string]any{"totalCount": 3}, "viewerHasReacted": true},
+						{"content": "ROCKET", "users": map[string]any{"totalCount": 1}, "viewerHasReacted": false},
+					},
 				}},
 			}),
 			expectError: false,
 			expected: map[string]interface{}{
-				"number":     float64(1),
-				"title":      "Test Discussion Title",
-				"body":       "This is a test discussion",
-				"url":        "https://github.com/owner/repo/discussions/1",
-				"closed":     false,
-				"isAnswered": false,
+				"number":           float64(1),
+				"title":            "Test Discussion Title",
+				"body":             "This is a test discussion",
+				"url":              "https://github.com/owner/repo/discussions/1",
+				"closed":           false,
+				"isAnswered":       false,
+				"upvoteCount":      float64(7),
+				"viewerHasUpvoted": true,
+				"commentCount":     float64(12),
+				"reactions":        map[string]any{"THUMBS_UP": float64(3), "ROCKET": float64(1)},
+				"viewerHasReacted": map[string]any{"THUMBS_UP": true, "ROCKET": false},
 			},
 		},
 		{
@@ -553,6 +604,12 @@ func Test_GetDiscussion(t *testing.T) {
 			expectError: true,
 			errContains: "discussion not found",
 		},
+		{
+			name:        "missing discussion is named",
+			response:    githubv4mock.ErrorResponse("Could not resolve to a Discussion with the number of 1."),
+			expectError: true,
+			errContains: "discussion #1 not found in owner/repo",
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -582,6 +639,11 @@ func Test_GetDiscussion(t *testing.T) {
 			assert.Equal(t, tc.expected["url"], out["url"])
 			assert.Equal(t, tc.expected["closed"], out["closed"])
 			assert.Equal(t, tc.expected["isAnswered"], out["isAnswered"])
+			assert.Equal(t, tc.expected["upvoteCount"], out["upvoteCount"])
+			assert.Equal(t, tc.expected["viewerHasUpvoted"], out["viewerHasUpvoted"])
+			assert.Equal(t, tc.expected["commentCount"], out["commentCount"])
+			assert.Equal(t, tc.expected["reactions"], out["reactions"])
+			assert.Equal(t, tc.expected["viewerHasReacted"], out["viewerHasReacted"])
 			// Check category is present
 			category, ok := out["category"].(map[string]interface{})
 			require.True(t, ok)
@@ -590,135 +652,377 @@ func Test_GetDiscussion(t *testing.T) {
 	}
 }
 
-func Test_GetDiscussionComments(t *testing.T) {
-	// Verify tool definition and schema
-	toolDef := GetDiscussionComments(translations.NullTranslationHelper)
-	tool := toolDef.Tool
-	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+func Test_GetDiscussionBodyAsResourceLink(t *testing.T) {
+	qGetDiscussion := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,body,createdAt,author{login},closed,closedAt,isAnswered,answerChosenAt,url,category{name},upvoteCount,viewerHasUpvoted,labels(first: 10){nodes{name,color}},comments{totalCount},reactionGroups{content,users{totalCount},viewerHasReacted}}}}"
+	qGetBody := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){id,body}}}"
+	vars := map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)}
+	body := "A very long discussion body"
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qGetDiscussion, vars, githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{
+				"number":     1,
+				"title":      "Big discussion",
+				"body":       body,
+				"url":        "https://github.com/owner/repo/discussions/1",
+				"createdAt":  "2023-01-01T00:00:00Z",
+				"closed":     false,
+				"isAnswered": false,
+				"category":   map[string]any{"name": "General"},
+			}},
+		})),
+		githubv4mock.NewQueryMatcher(qGetBody, vars, githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{"id": "D_1", "body": body}},
+		})),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := GetDiscussion(translations.NullTranslationHelper)
+	args := map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": 1, "bodyAsResourceLink": true}
 
-	assert.Equal(t, "get_discussion_comments", tool.Name)
-	assert.NotEmpty(t, tool.Description)
-	schema, ok := tool.InputSchema.(*jsonschema.Schema)
-	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
-	assert.Contains(t, schema.Properties, "owner")
-	assert.Contains(t, schema.Properties, "repo")
-	assert.Contains(t, schema.Properties, "discussionNumber")
-	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "discussionNumber"})
+	t.Run("returns a resource link to clients that support them", func(t *testing.T) {
+		ctx := context.Background()
+		server := mcp.NewServer(&mcp.Implementation{Name: "test-server"}, nil)
+		server.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
+			return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+				return next(ContextWithDeps(ctx, deps), method, req)
+			}
+		})
+		toolDef.RegisterFunc(server, deps)
+		resource := GetDiscussionResourceBody(translations.NullTranslationHelper)
+		server.AddResourceTemplate(&resource.Template, resource.Handler(deps))
 
-	// Use exact string query that matches implementation output
-	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{id,body,url},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
+		serverTransport, clientTransport := mcp.NewInMemoryTransports()
+		serverSession, err := server.Connect(ctx, serverTransport, nil)
+		require.NoError(t, err)
+		defer func() { _ = serverSession.Close() }()
+		client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+		session, err := client.Connect(ctx, clientTransport, nil)
+		require.NoError(t, err)
+		defer func() { _ = session.Close() }()
 
-	// Variables matching what GraphQL receives after JSON marshaling/unmarshaling
-	vars := map[string]interface{}{
-		"owner":            "owner",
-		"repo":             "repo",
-		"discussionNumber": float64(1),
-		"first":            float64(30),
-		"after":            (*string)(nil),
-	}
+		res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "get_discussion", Arguments: args})
+		require.NoError(t, err)
+		require.False(t, res.IsError)
+		require.Len(t, res.Content, 2)
 
-	mockResponse := githubv4mock.DataResponse(map[string]any{
-		"repository": map[string]any{
-			"discussion": map[string]any{
-				"comments": map[string]any{
-					"nodes": []map[string]any{
-						{"id": "DC_1", "body": "This is the first comment", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-1"},
-						{"id": "DC_2", "body": "This is the second comment", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-2"},
-					},
-					"pageInfo": map[string]any{
-						"hasNextPage":     false,
-						"hasPreviousPage": false,
-						"startCursor":     "",
-						"endCursor":       "",
-					},
-					"totalCount": 2,
+		text, ok := res.Content[0].(*mcp.TextContent)
+		require.True(t, ok, "expected text content, got %T", res.Content[0])
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(text.Text), &response))
+		assert.NotContains(t, response, "body")
+		assert.Equal(t, "discussion://owner/repo/1/body", response["bodyUri"])
+
+		link, ok := res.Content[1].(*mcp.ResourceLink)
+		require.True(t, ok, "expected a resource link, got %T", res.Content[1])
+		assert.Equal(t, "discussion://owner/repo/1/body", link.URI)
+		assert.Equal(t, "text/markdown", link.MIMEType)
+
+		read, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: link.URI})
+		require.NoError(t, err)
+		require.Len(t, read.Contents, 1)
+		assert.Equal(t, body, read.Contents[0].Text)
+	})
+
+	t.Run("inlines the body when resource link support is unknown", func(t *testing.T) {
+		req := createMCPRequest(args)
+		res, err := toolDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError)
+		require.Len(t, res.Content, 1)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		assert.Equal(t, body, response["body"])
+		assert.NotContains(t, response, "bodyUri")
+	})
+}
+
+func Test_GetDiscussionConvertedFrom(t *testing.T) {
+	qGetDiscussion := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,body,createdAt,author{login},closed,closedAt,isAnswered,answerChosenAt,url,category{name},upvoteCount,viewerHasUpvoted,labels(first: 10){nodes{name,color}},comments{totalCount},reactionGroups{content,users{totalCount},viewerHasReacted}}}}"
+	qConvertedFrom := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){issue(number: $discussionNumber){number,url,timelineItems(itemTypes: [CONVERTED_TO_DISCUSSION_EVENT], first: 1){nodes{... on ConvertedToDiscussionEvent{discussion{number}}}}}}}"
+	vars := func(number int) map[string]any {
+		return map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(number)}
+	}
+	discussion := func(number int) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{
+				"number":    number,
+				"title":     fmt.Sprintf("Discussion %d", number),
+				"url":       fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+				"createdAt": "2023-01-01T00:00:00Z",
+			}},
+		})
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qGetDiscussion, vars(1), discussion(1)),
+		githubv4mock.NewQueryMatcher(qGetDiscussion, vars(2), discussion(2)),
+		githubv4mock.NewQueryMatcher(qConvertedFrom, vars(1), githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"issue": map[string]any{
+				"number": 1,
+				"url":    "https://github.com/owner/repo/issues/1",
+				"timelineItems": map[string]any{
+					"nodes": []map[string]any{{"discussion": map[string]any{"number": 1}}},
 				},
-			},
-		},
+			}},
+		})),
+		githubv4mock.NewQueryMatcher(qConvertedFrom, vars(2), githubv4mock.ErrorResponse("Could not resolve to an Issue with the number of 2.")),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := GetDiscussion(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	get := func(t *testing.T, args map[string]any) map[string]any {
+		req := createMCPRequest(args)
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		return response
+	}
+
+	t.Run("converted from an issue", func(t *testing.T) {
+		response := get(t, map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": 1, "includeConvertedFrom": true})
+		assert.Equal(t, map[string]any{"issueNumber": float64(1), "url": "https://github.com/owner/repo/issues/1"}, response["convertedFrom"])
 	})
-	matcher := githubv4mock.NewQueryMatcher(qGetComments, vars, mockResponse)
-	httpClient := githubv4mock.NewMockedHTTPClient(matcher)
-	gqlClient := githubv4.NewClient(httpClient)
-	deps := BaseDeps{GQLClient: gqlClient}
+
+	t.Run("not detectable", func(t *testing.T) {
+		response := get(t, map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": 2, "includeConvertedFrom": true})
+		assert.Contains(t, response, "convertedFrom")
+		assert.Nil(t, response["convertedFrom"])
+	})
+
+	t.Run("omitted unless requested", func(t *testing.T) {
+		response := get(t, map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": 1})
+		assert.NotContains(t, response, "convertedFrom")
+	})
+}
+
+func Test_GetDiscussionPoll(t *testing.T) {
+	qGetDiscussion := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,body,createdAt,author{login},closed,closedAt,isAnswered,answerChosenAt,url,category{name},upvoteCount,viewerHasUpvoted,labels(first: 10){nodes{name,color}},comments{totalCount},reactionGroups{content,users{totalCount},viewerHasReacted}}}}"
+	qPoll := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){poll{question,totalVoteCount,viewerHasVoted,options(first: 10){nodes{id,option,totalVoteCount,viewerHasVoted}}}}}}"
+	vars := func(number int) map[string]any {
+		return map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(number)}
+	}
+	discussion := func(number int) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{
+				"number":    number,
+				"title":     fmt.Sprintf("Discussion %d", number),
+				"url":       fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+				"createdAt": "2023-01-01T00:00:00Z",
+			}},
+		})
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qGetDiscussion, vars(1), discussion(1)),
+		githubv4mock.NewQueryMatcher(qGetDiscussion, vars(2), discussion(2)),
+		githubv4mock.NewQueryMatcher(qPoll, vars(1), githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{"poll": map[string]any{
+				"question":       "Which day?",
+				"totalVoteCount": 5,
+				"viewerHasVoted": true,
+				"options": map[string]any{"nodes": []map[string]any{
+					{"id": "DPO_1", "option": "Monday", "totalVoteCount": 2},
+					{"id": "DPO_2", "option": "Friday", "totalVoteCount": 3, "viewerHasVoted": true},
+				}},
+			}}},
+		})),
+		githubv4mock.NewQueryMatcher(qPoll, vars(2), githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{"poll": nil}},
+		})),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := GetDiscussion(translations.NullTranslationHelper)
 	handler := toolDef.Handler(deps)
 
-	reqParams := map[string]interface{}{
-		"owner":            "owner",
-		"repo":             "repo",
-		"discussionNumber": int32(1),
+	get := func(t *testing.T, args map[string]any) map[string]any {
+		req := createMCPRequest(args)
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		return response
 	}
-	request := createMCPRequest(reqParams)
 
-	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
-	require.NoError(t, err)
+	t.Run("discussion with a poll", func(t *testing.T) {
+		response := get(t, map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": 1, "includePoll": true})
+		assert.Equal(t, map[string]any{
+			"question":       "Which day?",
+			"totalVoteCount": float64(5),
+			"viewerHasVoted": true,
+			"options": []any{
+				map[string]any{"id": "DPO_1", "option": "Monday", "totalVoteCount": float64(2), "viewerHasVoted": false},
+				map[string]any{"id": "DPO_2", "option": "Friday", "totalVoteCount": float64(3), "viewerHasVoted": true},
+			},
+		}, response["poll"])
+	})
 
-	textContent := getTextResult(t, result)
+	t.Run("discussion without a poll", func(t *testing.T) {
+		response := get(t, map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": 2, "includePoll": true})
+		assert.Contains(t, response, "poll")
+		assert.Nil(t, response["poll"])
+	})
 
-	// (Lines removed)
+	t.Run("omitted unless requested", func(t *testing.T) {
+		response := get(t, map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": 1})
+		assert.NotContains(t, response, "poll")
+	})
+}
 
-	var response struct {
-		Comments []struct {
-			ID   string `json:"id"`
-			Body string `json:"body"`
-			URL  string `json:"url"`
-		} `json:"comments"`
-		PageInfo struct {
-			HasNextPage     bool   `json:"hasNextPage"`
-			HasPreviousPage bool   `json:"hasPreviousPage"`
-			StartCursor     string `json:"startCursor"`
-			EndCursor       string `json:"endCursor"`
-		} `json:"pageInfo"`
-		TotalCount int `json:"totalCount"`
+func Test_DiscussionAnswerChosenAtParity(t *testing.T) {
+	qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	qGetDiscussion := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,body,createdAt,author{login},closed,closedAt,isAnswered,answerChosenAt,url,category{name},upvoteCount,viewerHasUpvoted,labels(first: 10){nodes{name,color}},comments{totalCount},reactionGroups{content,users{totalCount},viewerHasReacted}}}}"
+
+	answered := map[string]any{
+		"number":         5,
+		"title":          "Answered question",
+		"createdAt":      "2023-01-01T00:00:00Z",
+		"closed":         true,
+		"closedAt":       "2023-01-04T00:00:00Z",
+		"isAnswered":     true,
+		"answerChosenAt": "2023-01-02T10:00:00Z",
+		"url":            "https://github.com/owner/repo/discussions/5",
+		"category":       map[string]any{"name": "Q&A"},
 	}
-	err = json.Unmarshal([]byte(textContent.Text), &response)
+	// The list and get queries select slightly different fields
+	listedNode := map[string]any{"updatedAt": "2023-01-03T00:00:00Z", "author": map[string]any{"login": "user1"}}
+	gotNode := map[string]any{"body": "Body"}
+	for k, v := range answered {
+		listedNode[k] = v
+		gotNode[k] = v
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qBasicNoOrder,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(30), "after": (*string)(nil), "includeBody": false},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes": []map[string]any{listedNode, discussionsAll[0]},
+						"pageInfo": map[string]any{
+							"hasNextPage":     false,
+							"hasPreviousPage": false,
+							"startCursor":     "",
+							"endCursor":       "",
+						},
+						"totalCount": 2,
+					},
+				},
+			}),
+		),
+		githubv4mock.NewQueryMatcher(qGetDiscussion,
+			map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(5)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"discussion": gotNode},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	listTool := ListDiscussions(translations.NullTranslationHelper)
+	getTool := GetDiscussion(translations.NullTranslationHelper)
+
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"})
+	res, err := listTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
 	require.NoError(t, err)
-	assert.Len(t, response.Comments, 2)
-	expectedBodies := []string{"This is the first comment", "This is the second comment"}
-	for i, comment := range response.Comments {
-		assert.Equal(t, expectedBodies[i], comment.Body)
-		assert.NotEmpty(t, comment.ID)
-		assert.Contains(t, comment.URL, "https://github.com/")
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+	var listed struct {
+		Discussions []map[string]any `json:"discussions"`
 	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &listed))
+	require.Len(t, listed.Discussions, 2)
+
+	req = createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(5)})
+	res, err = getTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+	var got map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &got))
+
+	assert.Equal(t, "2023-01-02T10:00:00Z", got["answerChosenAt"])
+	assert.Equal(t, got["answerChosenAt"], listed.Discussions[0]["answerChosenAt"])
+	assert.NotContains(t, listed.Discussions[1], "answerChosenAt")
+
+	// closedAt is reported the same way, and left out for open discussions
+	assert.Equal(t, "2023-01-04T00:00:00Z", got["closedAt"])
+	assert.Equal(t, got["closedAt"], listed.Discussions[0]["closedAt"])
+	assert.NotContains(t, listed.Discussions[1], "closedAt")
 }
 
-func Test_ListDiscussionCategories(t *testing.T) {
-	toolDef := ListDiscussionCategories(translations.NullTranslationHelper)
-	tool := toolDef.Tool
-	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+func Test_ListDiscussionsByCategoryName(t *testing.T) {
+	qCategories := "query($first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussionCategories(first: $first){nodes{id,name,isAnswerable}}}}"
+	qWithCategoryNoOrder := "query($after:String$categoryId:ID!$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after, categoryId: $categoryId){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
 
-	assert.Equal(t, "list_discussion_categories", tool.Name)
-	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.Description, "or organisation")
-	schema, ok := tool.InputSchema.(*jsonschema.Schema)
-	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
-	assert.Contains(t, schema.Properties, "owner")
-	assert.Contains(t, schema.Properties, "repo")
-	assert.ElementsMatch(t, schema.Required, []string{"owner"})
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qCategories,
+			map[string]any{"owner": "owner", "repo": "category-name-repo", "first": float64(100)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussionCategories": map[string]any{
+						"nodes": []map[string]any{
+							{"id": "DIC_GENERAL", "name": "General"},
+							{"id": "DIC_QUESTIONS", "name": "Questions"},
+						},
+					},
+				},
+			}),
+		),
+		githubv4mock.NewQueryMatcher(qWithCategoryNoOrder,
+			map[string]any{"owner": "owner", "repo": "category-name-repo", "categoryId": "DIC_QUESTIONS", "first": float64(30), "after": (*string)(nil), "includeBody": false},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes": []map[string]any{discussionsAll[1]},
+						"pageInfo": map[string]any{
+							"hasNextPage":     false,
+							"hasPreviousPage": false,
+							"startCursor":     "",
+							"endCursor":       "",
+						},
+						"totalCount": 1,
+					},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := ListDiscussions(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
 
-	// Use exact string query that matches implementation output
-	qListCategories := "query($first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussionCategories(first: $first){nodes{id,name},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "category-name-repo", "categoryName": "questions"})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
 
-	// Variables for repository-level categories
-	varsRepo := map[string]interface{}{
-		"owner": "owner",
-		"repo":  "repo",
-		"first": float64(25),
+	var response struct {
+		Discussions []*github.Discussion `json:"discussions"`
 	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+	require.Len(t, response.Discussions, 1)
+	assert.Equal(t, "Questions", response.Discussions[0].GetDiscussionCategory().GetName())
 
-	// Variables for organization-level categories (using .github repo)
-	varsOrg := map[string]interface{}{
-		"owner": "owner",
-		"repo":  ".github",
-		"first": float64(25),
-	}
+	req = createMCPRequest(map[string]any{"owner": "owner", "repo": "category-name-repo", "categoryName": "Unknown"})
+	res, err = handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.True(t, res.IsError)
+	assert.Contains(t, getTextResult(t, res).Text, `discussion category "Unknown" not found`)
+}
 
-	mockRespRepo := githubv4mock.DataResponse(map[string]any{
+func Test_ListDiscussionsIncludeParticipants(t *testing.T) {
+	qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	qComments := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: 100){nodes{author{login}}}}}}"
+	listVars := map[string]interface{}{
+		"owner":       "owner",
+		"repo":        "repo",
+		"first":       float64(30),
+		"after":       (*string)(nil),
+		"includeBody": false,
+	}
+	listResponse := githubv4mock.DataResponse(map[string]any{
 		"repository": map[string]any{
-			"discussionCategories": map[string]any{
-				"nodes": []map[string]any{
-					{"id": "123", "name": "CategoryOne"},
-					{"id": "456", "name": "CategoryTwo"},
-				},
+			"discussions": map[string]any{
+				"nodes": []map[string]any{discussionsAll[0], discussionsAll[1]},
 				"pageInfo": map[string]any{
 					"hasNextPage":     false,
 					"hasPreviousPage": false,
@@ -729,123 +1033,5751 @@ func Test_ListDiscussionCategories(t *testing.T) {
 			},
 		},
 	})
+	commentsResponse := func(logins ...string) githubv4mock.GQLResponse {
+		nodes := []map[string]any{}
+		for _, login := range logins {
+			nodes = append(nodes, map[string]any{"author": map[string]any{"login": login}})
+		}
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{"comments": map[string]any{"nodes": nodes}}},
+		})
+	}
+	commentVars := func(number int) map[string]any {
+		return map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(number)}
+	}
 
-	mockRespOrg := githubv4mock.DataResponse(map[string]any{
-		"repository": map[string]any{
-			"discussionCategories": map[string]any{
-				"nodes": []map[string]any{
-					{"id": "789", "name": "Announcements"},
-					{"id": "101", "name": "General"},
-					{"id": "112", "name": "Ideas"},
-				},
-				"pageInfo": map[string]any{
-					"hasNextPage":     false,
-					"hasPreviousPage": false,
-					"startCursor":     "",
-					"endCursor":       "",
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qBasicNoOrder, listVars, listResponse),
+		// The discussion author commenting again and deleted accounts don't add participants
+		githubv4mock.NewQueryMatcher(qComments, commentVars(1), commentsResponse("user1", "alice", "bob", "alice", "")),
+		githubv4mock.NewQueryMatcher(qComments, commentVars(2), commentsResponse()),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := ListDiscussions(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "includeParticipants": true})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var response struct {
+		Discussions []map[string]any `json:"discussions"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+	require.Len(t, response.Discussions, 2)
+	assert.Equal(t, float64(3), response.Discussions[0]["participantCount"])
+	assert.Equal(t, float64(1), response.Discussions[1]["participantCount"])
+
+	// Without the option no comment queries are made and no count is returned
+	req = createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"})
+	res, err = handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+	response.Discussions = nil
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+	assert.NotContains(t, response.Discussions[0], "participantCount")
+}
+
+func Test_ListDiscussionsFetchAll(t *testing.T) {
+	qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	page := func(hasNextPage bool, endCursor string, nodes ...map[string]any) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussions": map[string]any{
+					"nodes": nodes,
+					"pageInfo": map[string]any{
+						"hasNextPage":     hasNextPage,
+						"hasPreviousPage": false,
+						"startCursor":     "",
+						"endCursor":       endCursor,
+					},
+					"totalCount": 3,
 				},
-				"totalCount": 3,
 			},
-		},
-	})
-
-	tests := []struct {
-		name               string
-		reqParams          map[string]interface{}
+		})
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qBasicNoOrder, map[string]any{"owner": "owner", "repo": "repo", "first": float64(100), "after": (*string)(nil), "includeBody": false}, page(true, "cursor-1", discussionsAll[0], discussionsAll[1])),
+		githubv4mock.NewQueryMatcher(qBasicNoOrder, map[string]any{"owner": "owner", "repo": "repo", "first": float64(100), "after": "cursor-1", "includeBody": false}, page(false, "cursor-2", discussionsAll[2])),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := ListDiscussions(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	t.Run("pages through all discussions", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "fetchAll": true})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var response struct {
+			Discussions []*github.Discussion `json:"discussions"`
+			Truncated   bool                 `json:"truncated"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		require.Len(t, response.Discussions, 3)
+		assert.Equal(t, 3, response.Discussions[2].GetNumber())
+		assert.False(t, response.Truncated)
+	})
+
+	t.Run("skips discussions fetched twice", func(t *testing.T) {
+		// Discussion 2 was edited between the requests and moved onto the second page
+		mockClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(qBasicNoOrder, map[string]any{"owner": "owner", "repo": "repo", "first": float64(100), "after": (*string)(nil), "includeBody": false}, page(true, "cursor-1", discussionsAll[0], discussionsAll[1])),
+			githubv4mock.NewQueryMatcher(qBasicNoOrder, map[string]any{"owner": "owner", "repo": "repo", "first": float64(100), "after": "cursor-1", "includeBody": false}, page(false, "cursor-2", discussionsAll[1], discussionsAll[2])),
+		)
+		deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "fetchAll": true})
+		res, err := toolDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var response struct {
+			Discussions       []*github.Discussion `json:"discussions"`
+			DuplicatesSkipped int                  `json:"duplicatesSkipped"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		var numbers []int
+		for _, d := range response.Discussions {
+			numbers = append(numbers, d.GetNumber())
+		}
+		assert.Equal(t, []int{1, 2, 3}, numbers)
+		assert.Equal(t, 1, response.DuplicatesSkipped)
+	})
+
+	t.Run("rejects fetchAll with an explicit cursor", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "fetchAll": true, "after": "cursor-1"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getTextResult(t, res).Text, "fetchAll and after are mutually exclusive")
+	})
+
+	t.Run("maxResults caps the collected discussions", func(t *testing.T) {
+		tests := []struct {
+			maxResults        float64
+			expectedNumbers   []int
+			expectedTruncated bool
+		}{
+			{maxResults: 1, expectedNumbers: []int{1}, expectedTruncated: true},
+			{maxResults: 2, expectedNumbers: []int{1, 2}, expectedTruncated: true},
+			{maxResults: 3, expectedNumbers: []int{1, 2, 3}, expectedTruncated: false},
+			{maxResults: 10, expectedNumbers: []int{1, 2, 3}, expectedTruncated: false},
+		}
+		for _, tc := range tests {
+			req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "fetchAll": true, "maxResults": tc.maxResults})
+			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			require.False(t, res.IsError, getTextResult(t, res).Text)
+
+			var response struct {
+				Discussions []*github.Discussion `json:"discussions"`
+				Truncated   bool                 `json:"truncated"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+			var numbers []int
+			for _, d := range response.Discussions {
+				numbers = append(numbers, d.GetNumber())
+			}
+			assert.Equal(t, tc.expectedNumbers, numbers, "maxResults %v", tc.maxResults)
+			assert.Equal(t, tc.expectedTruncated, response.Truncated, "maxResults %v", tc.maxResults)
+		}
+	})
+
+	t.Run("maxResults keeps the top discussions by sortBy", func(t *testing.T) {
+		withUpvotes := func(d map[string]any, upvotes int) map[string]any {
+			d = maps.Clone(d)
+			d["upvoteCount"] = upvotes
+			return d
+		}
+		mockClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(qBasicNoOrder, map[string]any{"owner": "owner", "repo": "repo", "first": float64(100), "after": (*string)(nil), "includeBody": false}, page(true, "cursor-1", withUpvotes(discussionsAll[0], 1), withUpvotes(discussionsAll[1], 2))),
+			githubv4mock.NewQueryMatcher(qBasicNoOrder, map[string]any{"owner": "owner", "repo": "repo", "first": float64(100), "after": "cursor-1", "includeBody": false}, page(false, "cursor-2", withUpvotes(discussionsAll[2], 9))),
+		)
+		deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "fetchAll": true, "maxResults": float64(2), "sortBy": "UPVOTES"})
+		res, err := toolDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var response struct {
+			Discussions []*github.Discussion `json:"discussions"`
+			Truncated   bool                 `json:"truncated"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		var numbers []int
+		for _, d := range response.Discussions {
+			numbers = append(numbers, d.GetNumber())
+		}
+		assert.Equal(t, []int{3, 2}, numbers)
+		assert.True(t, response.Truncated)
+	})
+
+	t.Run("maxResults requires fetchAll", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "maxResults": float64(2)})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getErrorResult(t, res).Text, "maxResults requires fetchAll")
+	})
+
+	t.Run("stops when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ContextWithDeps(context.Background(), deps))
+		cancel()
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "fetchAll": true})
+		res, err := handler(ctx, &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getErrorResult(t, res).Text, context.Canceled.Error())
+	})
+}
+
+func Test_DiscussionSelectionBudget(t *testing.T) {
+	// Requests over the budget are refused before any query is made
+	deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient())}
+
+	tests := []struct {
+		name        string
+		deps        BaseDeps
+		tool        inventory.ServerTool
+		args        map[string]any
+		expectError string
+	}{
+		{
+			name:        "fetchAll with participants exceeds the default budget",
+			deps:        deps,
+			tool:        ListDiscussions(translations.NullTranslationHelper),
+			args:        map[string]any{"owner": "owner", "repo": "repo", "fetchAll": true, "includeParticipants": true},
+			expectError: "request would select about 2000 nodes, over the budget of 1500; drop includeParticipants",
+		},
+		{
+			name:        "reply context exceeds a configured budget",
+			deps:        BaseDeps{GQLClient: deps.GQLClient, SelectionBudget: 100},
+			tool:        GetDiscussionComments(translations.NullTranslationHelper),
+			args:        map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": 1, "perPage": 60, "includeReplyContext": true},
+			expectError: "request would select about 120 nodes, over the budget of 100; drop includeReplyContext",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := createMCPRequest(tc.args)
+			res, err := tc.tool.Handler(tc.deps)(ContextWithDeps(context.Background(), tc.deps), &req)
+			require.NoError(t, err)
+			require.True(t, res.IsError)
+			assert.Contains(t, getTextResult(t, res).Text, tc.expectError)
+		})
+	}
+}
+
+func Test_DiscussionsWithDeletedCategory(t *testing.T) {
+	nullCategoryNode := map[string]any{
+		"number":     7,
+		"title":      "Discussion in a deleted category",
+		"createdAt":  "2023-01-01T00:00:00Z",
+		"updatedAt":  "2023-01-01T00:00:00Z",
+		"closed":     false,
+		"isAnswered": false,
+		"author":     map[string]any{"login": "user1"},
+		"url":        "https://github.com/owner/repo/discussions/7",
+		"category":   nil,
+	}
+
+	t.Run("list_discussions omits the category", func(t *testing.T) {
+		qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+		vars := map[string]interface{}{
+			"owner":       "owner",
+			"repo":        "repo",
+			"first":       float64(30),
+			"after":       (*string)(nil),
+			"includeBody": false,
+		}
+		mockResponse := githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussions": map[string]any{
+					"nodes": []map[string]any{discussionsAll[0], nullCategoryNode},
+					"pageInfo": map[string]any{
+						"hasNextPage":     false,
+						"hasPreviousPage": false,
+						"startCursor":     "",
+						"endCursor":       "",
+					},
+					"totalCount": 2,
+				},
+			},
+		})
+		deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qBasicNoOrder, vars, mockResponse)))}
+		toolDef := ListDiscussions(translations.NullTranslationHelper)
+		handler := toolDef.Handler(deps)
+
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var response struct {
+			Discussions []map[string]any `json:"discussions"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		require.Len(t, response.Discussions, 2)
+		assert.Equal(t, map[string]any{"name": "General"}, response.Discussions[0]["category"])
+		require.Contains(t, response.Discussions[1], "category")
+		assert.Nil(t, response.Discussions[1]["category"])
+	})
+
+	t.Run("get_discussion returns a null category", func(t *testing.T) {
+		qGetDiscussion := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,body,createdAt,author{login},closed,closedAt,isAnswered,answerChosenAt,url,category{name},upvoteCount,viewerHasUpvoted,labels(first: 10){nodes{name,color}},comments{totalCount},reactionGroups{content,users{totalCount},viewerHasReacted}}}}"
+		vars := map[string]interface{}{
+			"owner":            "owner",
+			"repo":             "repo",
+			"discussionNumber": float64(7),
+		}
+		mockResponse := githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{
+				"number":     7,
+				"title":      "Discussion in a deleted category",
+				"body":       "Body",
+				"url":        "https://github.com/owner/repo/discussions/7",
+				"createdAt":  "2023-01-01T00:00:00Z",
+				"closed":     false,
+				"isAnswered": false,
+				"category":   nil,
+			}},
+		})
+		deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qGetDiscussion, vars, mockResponse)))}
+		toolDef := GetDiscussion(translations.NullTranslationHelper)
+		handler := toolDef.Handler(deps)
+
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(7)})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		require.Contains(t, out, "category")
+		assert.Nil(t, out["category"])
+	})
+}
+
+func Test_ParseDiscussionURL(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		expectedOwner  string
+		expectedRepo   string
+		expectedNumber int32
+		expectError    bool
+	}{
+		{name: "github.com", url: "https://github.com/owner/repo/discussions/42", expectedOwner: "owner", expectedRepo: "repo", expectedNumber: 42},
+		{name: "comment anchor and trailing slash", url: "https://github.com/owner/repo/discussions/42/#discussioncomment-7", expectedOwner: "owner", expectedRepo: "repo", expectedNumber: 42},
+		{name: "enterprise host", url: "https://ghe.example.com/org/project/discussions/3", expectedOwner: "org", expectedRepo: "project", expectedNumber: 3},
+		{name: "issue url", url: "https://github.com/owner/repo/issues/42", expectError: true},
+		{name: "no number", url: "https://github.com/owner/repo/discussions", expectError: true},
+		{name: "non-numeric number", url: "https://github.com/owner/repo/discussions/abc", expectError: true},
+		{name: "zero number", url: "https://github.com/owner/repo/discussions/0", expectError: true},
+		{name: "no scheme", url: "github.com/owner/repo/discussions/42", expectError: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			owner, repo, number, err := parseDiscussionURL(tc.url)
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid discussion url")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOwner, owner)
+			assert.Equal(t, tc.expectedRepo, repo)
+			assert.Equal(t, tc.expectedNumber, number)
+		})
+	}
+}
+
+func Test_GetDiscussionByURL(t *testing.T) {
+	qGetDiscussion := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,body,createdAt,author{login},closed,closedAt,isAnswered,answerChosenAt,url,category{name},upvoteCount,viewerHasUpvoted,labels(first: 10){nodes{name,color}},comments{totalCount},reactionGroups{content,users{totalCount},viewerHasReacted}}}}"
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qGetDiscussion, map[string]any{"owner": "octo", "repo": "widgets", "discussionNumber": float64(7)}, githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussion": map[string]any{
+					"number":    7,
+					"title":     "From a URL",
+					"body":      "Body",
+					"url":       "https://github.com/octo/widgets/discussions/7",
+					"createdAt": "2025-04-25T12:00:00Z",
+					"category":  map[string]any{"name": "General"},
+				},
+			},
+		})),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := GetDiscussion(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	t.Run("url overrides owner, repo and number", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "other", "url": "https://github.com/octo/widgets/discussions/7"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+		assert.Contains(t, getTextResult(t, res).Text, `"title":"From a URL"`)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"url": "https://github.com/octo/widgets/pull/7"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getErrorResult(t, res).Text, "invalid discussion url")
+	})
+
+	t.Run("neither url nor owner, repo and number", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "octo", "repo": "widgets"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Equal(t, "either url or owner, repo and discussionNumber are required", getErrorResult(t, res).Text)
+	})
+}
+
+func Test_GetDiscussionComments(t *testing.T) {
+	// Verify tool definition and schema
+	toolDef := GetDiscussionComments(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_discussion_comments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+	assert.Contains(t, schema.Properties, "owner")
+	assert.Contains(t, schema.Properties, "repo")
+	assert.Contains(t, schema.Properties, "discussionNumber")
+	assert.Contains(t, schema.Properties, "url")
+	assert.Empty(t, schema.Required, "owner, repo and discussionNumber can be given as url instead")
+
+	// Use exact string query that matches implementation output
+	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$includeReplies:Boolean!$includeReplyContext:Boolean!$owner:String!$replyCount:Int!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{id,body,url,isAnswer,author{login},createdAt,updatedAt,isMinimized,minimizedReason,reactionGroups{content,users{totalCount}},replyTo @include(if: $includeReplyContext){id,author{login}},replies(first: $replyCount) @include(if: $includeReplies){nodes{id,body,url},pageInfo{hasNextPage}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},answerChosenAt,answerChosenBy{login}}}}"
+
+	// Variables matching what GraphQL receives after JSON marshaling/unmarshaling
+	vars := map[string]interface{}{
+		"owner":               "owner",
+		"repo":                "repo",
+		"discussionNumber":    float64(1),
+		"first":               float64(30),
+		"after":               (*string)(nil),
+		"includeReplyContext": false,
+		"includeReplies":      false,
+		"replyCount":          float64(5),
+	}
+
+	mockResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"discussion": map[string]any{
+				"comments": map[string]any{
+					"nodes": []map[string]any{
+						{"id": "DC_1", "body": "This is the first comment", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-1", "isAnswer": false},
+						{"id": "DC_2", "body": "This is the second comment", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-2", "isAnswer": true, "reactionGroups": []map[string]any{
+							{"content": "THUMBS_UP", "users": map[string]any{"totalCount": 5}},
+							{"content": "HEART", "users": map[string]any{"totalCount": 2}},
+						}},
+					},
+					"pageInfo": map[string]any{
+						"hasNextPage":     false,
+						"hasPreviousPage": false,
+						"startCursor":     "",
+						"endCursor":       "",
+					},
+					"totalCount": 2,
+				},
+				"answerChosenAt": "2023-01-02T00:00:00Z",
+				"answerChosenBy": map[string]any{"login": "maintainer"},
+			},
+		},
+	})
+	matcher := githubv4mock.NewQueryMatcher(qGetComments, vars, mockResponse)
+	httpClient := githubv4mock.NewMockedHTTPClient(matcher)
+	gqlClient := githubv4.NewClient(httpClient)
+	deps := BaseDeps{GQLClient: gqlClient}
+	handler := toolDef.Handler(deps)
+
+	reqParams := map[string]interface{}{
+		"owner":            "owner",
+		"repo":             "repo",
+		"discussionNumber": int32(1),
+	}
+	request := createMCPRequest(reqParams)
+
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+
+	// (Lines removed)
+
+	var response struct {
+		Comments []struct {
+			ID             string         `json:"id"`
+			Body           string         `json:"body"`
+			URL            string         `json:"url"`
+			IsAnswer       bool           `json:"isAnswer"`
+			AnswerChosenAt string         `json:"answerChosenAt"`
+			AnswerChosenBy string         `json:"answerChosenBy"`
+			Reactions      map[string]int `json:"reactions"`
+		} `json:"comments"`
+		PageInfo struct {
+			HasNextPage     bool   `json:"hasNextPage"`
+			HasPreviousPage bool   `json:"hasPreviousPage"`
+			StartCursor     string `json:"startCursor"`
+			EndCursor       string `json:"endCursor"`
+		} `json:"pageInfo"`
+		TotalCount int `json:"totalCount"`
+	}
+	err = json.Unmarshal([]byte(textContent.Text), &response)
+	require.NoError(t, err)
+	assert.Len(t, response.Comments, 2)
+	expectedBodies := []string{"This is the first comment", "This is the second comment"}
+	for i, comment := range response.Comments {
+		assert.Equal(t, expectedBodies[i], comment.Body)
+		assert.NotEmpty(t, comment.ID)
+		assert.Contains(t, comment.URL, "https://github.com/")
+	}
+
+	// Only the answer comment carries who chose it and when
+	assert.False(t, response.Comments[0].IsAnswer)
+	assert.Empty(t, response.Comments[0].AnswerChosenBy)
+	assert.True(t, response.Comments[1].IsAnswer)
+	assert.Equal(t, "2023-01-02T00:00:00Z", response.Comments[1].AnswerChosenAt)
+	assert.Equal(t, "maintainer", response.Comments[1].AnswerChosenBy)
+
+	// Reaction counts are keyed by content, empty when a comment has none
+	assert.Empty(t, response.Comments[0].Reactions)
+	assert.Equal(t, map[string]int{"THUMBS_UP": 5, "HEART": 2}, response.Comments[1].Reactions)
+}
+
+func Test_GetDiscussionCommentsReplyContext(t *testing.T) {
+	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$includeReplies:Boolean!$includeReplyContext:Boolean!$owner:String!$replyCount:Int!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{id,body,url,isAnswer,author{login},createdAt,updatedAt,isMinimized,minimizedReason,reactionGroups{content,users{totalCount}},replyTo @include(if: $includeReplyContext){id,author{login}},replies(first: $replyCount) @include(if: $includeReplies){nodes{id,body,url},pageInfo{hasNextPage}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},answerChosenAt,answerChosenBy{login}}}}"
+	vars := map[string]interface{}{
+		"owner":               "owner",
+		"repo":                "repo",
+		"discussionNumber":    float64(1),
+		"first":               float64(30),
+		"after":               (*string)(nil),
+		"includeReplyContext": true,
+		"includeReplies":      false,
+		"replyCount":          float64(5),
+	}
+	mockResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"discussion": map[string]any{
+				"comments": map[string]any{
+					"nodes": []map[string]any{
+						{"id": "DC_1", "body": "Top-level comment", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-1", "isAnswer": false, "replyTo": nil},
+						{"id": "DC_2", "body": "A reply", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-2", "isAnswer": false, "replyTo": map[string]any{"id": "DC_1", "author": map[string]any{"login": "user1"}}},
+					},
+					"pageInfo": map[string]any{
+						"hasNextPage":     false,
+						"hasPreviousPage": false,
+						"startCursor":     "",
+						"endCursor":       "",
+					},
+					"totalCount": 2,
+				},
+				"answerChosenAt": nil,
+				"answerChosenBy": nil,
+			},
+		},
+	})
+	deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qGetComments, vars, mockResponse)))}
+	toolDef := GetDiscussionComments(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "includeReplyContext": true})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var response struct {
+		Comments []map[string]any `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+	require.Len(t, response.Comments, 2)
+	assert.Contains(t, response.Comments[0], "replyTo")
+	assert.Nil(t, response.Comments[0]["replyTo"])
+	assert.Equal(t, map[string]any{"id": "DC_1", "author": "user1"}, response.Comments[1]["replyTo"])
+}
+
+func Test_GetDiscussionCommentsBodyMaxLength(t *testing.T) {
+	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$includeReplies:Boolean!$includeReplyContext:Boolean!$owner:String!$replyCount:Int!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{id,body,url,isAnswer,author{login},createdAt,updatedAt,isMinimized,minimizedReason,reactionGroups{content,users{totalCount}},replyTo @include(if: $includeReplyContext){id,author{login}},replies(first: $replyCount) @include(if: $includeReplies){nodes{id,body,url},pageInfo{hasNextPage}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},answerChosenAt,answerChosenBy{login}}}}"
+	vars := map[string]interface{}{
+		"owner":               "owner",
+		"repo":                "repo",
+		"discussionNumber":    float64(1),
+		"first":               float64(30),
+		"after":               (*string)(nil),
+		"includeReplyContext": false,
+		"includeReplies":      false,
+		"replyCount":          float64(5),
+	}
+	mockResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"discussion": map[string]any{
+				"comments": map[string]any{
+					"nodes": []map[string]any{
+						{"id": "DC_1", "body": "A rather long comment", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-1", "author": map[string]any{"login": "alice"}, "createdAt": "2024-03-01T10:00:00Z", "updatedAt": "2024-03-01T12:00:00Z"},
+						{"id": "DC_2", "body": "Short", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-2", "author": nil, "createdAt": "2024-03-02T10:00:00Z"},
+					},
+					"pageInfo": map[string]any{
+						"hasNextPage":     false,
+						"hasPreviousPage": false,
+						"startCursor":     "",
+						"endCursor":       "",
+					},
+					"totalCount": 2,
+				},
+			},
+		},
+	})
+	deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qGetComments, vars, mockResponse)))}
+	toolDef := GetDiscussionComments(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	t.Run("truncates long bodies", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "bodyMaxLength": float64(8)})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var response struct {
+			Comments []map[string]any `json:"comments"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		require.Len(t, response.Comments, 2)
+		assert.Equal(t, "A rather…", response.Comments[0]["body"])
+		assert.Equal(t, true, response.Comments[0]["bodyTruncated"])
+		assert.Equal(t, "alice", response.Comments[0]["author"])
+		assert.Equal(t, "2024-03-01T10:00:00Z", response.Comments[0]["createdAt"])
+		assert.Equal(t, "2024-03-01T12:00:00Z", response.Comments[0]["updatedAt"])
+
+		assert.Equal(t, "Short", response.Comments[1]["body"])
+		assert.NotContains(t, response.Comments[1], "bodyTruncated")
+		assert.Nil(t, response.Comments[1]["author"])
+	})
+
+	t.Run("negative bodyMaxLength", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "bodyMaxLength": float64(-1)})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Equal(t, "bodyMaxLength must be positive", getErrorResult(t, res).Text)
+	})
+}
+
+func Test_GetDiscussionCommentsReplies(t *testing.T) {
+	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$includeReplies:Boolean!$includeReplyContext:Boolean!$owner:String!$replyCount:Int!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{id,body,url,isAnswer,author{login},createdAt,updatedAt,isMinimized,minimizedReason,reactionGroups{content,users{totalCount}},replyTo @include(if: $includeReplyContext){id,author{login}},replies(first: $replyCount) @include(if: $includeReplies){nodes{id,body,url},pageInfo{hasNextPage}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},answerChosenAt,answerChosenBy{login}}}}"
+	vars := map[string]interface{}{
+		"owner":               "owner",
+		"repo":                "repo",
+		"discussionNumber":    float64(1),
+		"first":               float64(30),
+		"after":               (*string)(nil),
+		"includeReplyContext": false,
+		"includeReplies":      true,
+		"replyCount":          float64(2),
+	}
+	mockResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"discussion": map[string]any{
+				"comments": map[string]any{
+					"nodes": []map[string]any{
+						{"id": "DC_1", "body": "Busy thread", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-1", "isAnswer": false, "replies": map[string]any{
+							"nodes": []map[string]any{
+								{"id": "DC_2", "body": "First reply", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-2"},
+								{"id": "DC_3", "body": "Second reply", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-3"},
+							},
+							"pageInfo": map[string]any{"hasNextPage": true},
+						}},
+						{"id": "DC_4", "body": "Quiet thread", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-4", "isAnswer": false, "replies": map[string]any{
+							"nodes":    []map[string]any{},
+							"pageInfo": map[string]any{"hasNextPage": false},
+						}},
+					},
+					"pageInfo": map[string]any{
+						"hasNextPage":     false,
+						"hasPreviousPage": false,
+						"startCursor":     "",
+						"endCursor":       "",
+					},
+					"totalCount": 2,
+				},
+				"answerChosenAt": nil,
+				"answerChosenBy": nil,
+			},
+		},
+	})
+	deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qGetComments, vars, mockResponse)))}
+	toolDef := GetDiscussionComments(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	t.Run("nests replies", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "includeReplies": true, "replyCount": float64(2)})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var response struct {
+			Comments []map[string]any `json:"comments"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		require.Len(t, response.Comments, 2)
+		assert.Equal(t, []any{
+			map[string]any{"id": "DC_2", "body": "First reply", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-2"},
+			map[string]any{"id": "DC_3", "body": "Second reply", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-3"},
+		}, response.Comments[0]["replies"])
+		assert.Equal(t, true, response.Comments[0]["hasMoreReplies"])
+		assert.Equal(t, []any{}, response.Comments[1]["replies"])
+		assert.Equal(t, false, response.Comments[1]["hasMoreReplies"])
+	})
+
+	t.Run("replyCount out of range", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "includeReplies": true, "replyCount": float64(101)})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Equal(t, "replyCount must be between 1 and 100", getErrorResult(t, res).Text)
+	})
+
+	t.Run("over the selection budget", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "perPage": float64(100), "includeReplies": true, "replyCount": float64(50)})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getErrorResult(t, res).Text, "includeReplies")
+	})
+}
+
+func Test_GetDiscussionCommentsAnswerFirst(t *testing.T) {
+	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$includeReplies:Boolean!$includeReplyContext:Boolean!$owner:String!$replyCount:Int!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{id,body,url,isAnswer,author{login},createdAt,updatedAt,isMinimized,minimizedReason,reactionGroups{content,users{totalCount}},replyTo @include(if: $includeReplyContext){id,author{login}},replies(first: $replyCount) @include(if: $includeReplies){nodes{id,body,url},pageInfo{hasNextPage}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},answerChosenAt,answerChosenBy{login}}}}"
+	vars := map[string]interface{}{
+		"owner":               "owner",
+		"repo":                "repo",
+		"discussionNumber":    float64(1),
+		"first":               float64(30),
+		"after":               (*string)(nil),
+		"includeReplyContext": false,
+		"includeReplies":      false,
+		"replyCount":          float64(5),
+	}
+	mockResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"discussion": map[string]any{
+				"comments": map[string]any{
+					"nodes": []map[string]any{
+						{"id": "DC_1", "body": "First", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-1", "isAnswer": false},
+						{"id": "DC_2", "body": "Second", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-2", "isAnswer": false},
+						{"id": "DC_3", "body": "The answer", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-3", "isAnswer": true},
+						{"id": "DC_4", "body": "Fourth", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-4", "isAnswer": false},
+					},
+					"pageInfo": map[string]any{
+						"hasNextPage":     false,
+						"hasPreviousPage": false,
+						"startCursor":     "",
+						"endCursor":       "",
+					},
+					"totalCount": 4,
+				},
+				"answerChosenAt": nil,
+				"answerChosenBy": nil,
+			},
+		},
+	})
+	deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qGetComments, vars, mockResponse)))}
+	toolDef := GetDiscussionComments(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	for _, tc := range []struct {
+		name        string
+		answerFirst bool
+		expectedIDs []string
+	}{
+		{name: "answer moved to the top", answerFirst: true, expectedIDs: []string{"DC_3", "DC_1", "DC_2", "DC_4"}},
+		{name: "chronological by default", answerFirst: false, expectedIDs: []string{"DC_1", "DC_2", "DC_3", "DC_4"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "answerFirst": tc.answerFirst})
+			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			require.False(t, res.IsError, getTextResult(t, res).Text)
+
+			var response struct {
+				Comments        []map[string]any `json:"comments"`
+				AnswerCommentID *string          `json:"answerCommentId"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+			var ids []string
+			for _, c := range response.Comments {
+				ids = append(ids, c["id"].(string))
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+			require.NotNil(t, response.AnswerCommentID)
+			assert.Equal(t, "DC_3", *response.AnswerCommentID)
+			if tc.answerFirst {
+				assert.Equal(t, true, response.Comments[0]["isAnswer"])
+			}
+		})
+	}
+}
+
+func Test_ListDiscussionCategories(t *testing.T) {
+	toolDef := ListDiscussionCategories(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_discussion_categories", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.Description, "or organisation")
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+	assert.Contains(t, schema.Properties, "owner")
+	assert.Contains(t, schema.Properties, "repo")
+	assert.ElementsMatch(t, schema.Required, []string{"owner"})
+
+	// Use exact string query that matches implementation output
+	qListCategories := "query($after:String$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussionCategories(first: $first, after: $after){nodes{id,name,emoji,description,isAnswerable,slug},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+
+	// Variables for repository-level categories
+	varsRepo := map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+		"first": float64(25),
+		"after": (*string)(nil),
+	}
+
+	// Variables for organization-level categories (using .github repo)
+	varsOrg := map[string]interface{}{
+		"owner": "owner",
+		"repo":  ".github",
+		"first": float64(25),
+		"after": (*string)(nil),
+	}
+
+	mockRespRepo := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"discussionCategories": map[string]any{
+				"nodes": []map[string]any{
+					{"id": "123", "name": "CategoryOne", "emoji": ":bulb:", "description": "Share ideas", "isAnswerable": false},
+					{"id": "456", "name": "CategoryTwo", "isAnswerable": true},
+				},
+				"pageInfo": map[string]any{
+					"hasNextPage":     false,
+					"hasPreviousPage": false,
+					"startCursor":     "",
+					"endCursor":       "",
+				},
+				"totalCount": 2,
+			},
+		},
+	})
+
+	mockRespOrg := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"discussionCategories": map[string]any{
+				"nodes": []map[string]any{
+					{"id": "789", "name": "Announcements", "emoji": "", "description": "", "isAnswerable": false},
+					{"id": "101", "name": "General", "emoji": "", "description": "", "isAnswerable": false},
+					{"id": "112", "name": "Ideas", "emoji": "", "description": "", "isAnswerable": false},
+				},
+				"pageInfo": map[string]any{
+					"hasNextPage":     false,
+					"hasPreviousPage": false,
+					"startCursor":     "",
+					"endCursor":       "",
+				},
+				"totalCount": 3,
+			},
+		},
+	})
+
+	tests := []struct {
+		name               string
+		reqParams          map[string]interface{}
 		vars               map[string]interface{}
 		mockResponse       githubv4mock.GQLResponse
 		expectError        bool
 		expectedCount      int
-		expectedCategories []map[string]string
+		expectedCategories []map[string]any
+	}{
+		{
+			name: "list repository-level discussion categories",
+			reqParams: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			vars:          varsRepo,
+			mockResponse:  mockRespRepo,
+			expectError:   false,
+			expectedCount: 2,
+			expectedCategories: []map[string]any{
+				{"id": "123", "name": "CategoryOne", "emoji": ":bulb:", "description": "Share ideas", "isAnswerable": false},
+				{"id": "456", "name": "CategoryTwo", "emoji": "", "description": "", "isAnswerable": true},
+			},
+		},
+		{
+			name: "list only answerable discussion categories",
+			reqParams: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"answerableOnly": true,
+			},
+			vars:          varsRepo,
+			mockResponse:  mockRespRepo,
+			expectError:   false,
+			expectedCount: 1,
+			expectedCategories: []map[string]any{
+				{"id": "456", "name": "CategoryTwo", "emoji": "", "description": "", "isAnswerable": true},
+			},
+		},
+		{
+			name: "list org-level discussion categories (no repo provided)",
+			reqParams: map[string]interface{}{
+				"owner": "owner",
+				// repo is not provided, it will default to ".github"
+			},
+			vars:          varsOrg,
+			mockResponse:  mockRespOrg,
+			expectError:   false,
+			expectedCount: 3,
+			expectedCategories: []map[string]any{
+				{"id": "789", "name": "Announcements", "emoji": "", "description": "", "isAnswerable": false},
+				{"id": "101", "name": "General", "emoji": "", "description": "", "isAnswerable": false},
+				{"id": "112", "name": "Ideas", "emoji": "", "description": "", "isAnswerable": false},
+			},
+		},
+		{
+			name: "list org-level discussion categories from the organisation's discussions repository",
+			reqParams: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "community",
+				"level": "organization",
+			},
+			vars: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "community",
+				"first": float64(25),
+				"after": (*string)(nil),
+			},
+			mockResponse:  mockRespOrg,
+			expectError:   false,
+			expectedCount: 3,
+			expectedCategories: []map[string]any{
+				{"id": "789", "name": "Announcements", "emoji": "", "description": "", "isAnswerable": false},
+				{"id": "101", "name": "General", "emoji": "", "description": "", "isAnswerable": false},
+				{"id": "112", "name": "Ideas", "emoji": "", "description": "", "isAnswerable": false},
+			},
+		},
+		{
+			name: "repository level requires repo",
+			reqParams: map[string]interface{}{
+				"owner": "owner",
+				"level": "repository",
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matcher := githubv4mock.NewQueryMatcher(qListCategories, tc.vars, tc.mockResponse)
+			httpClient := githubv4mock.NewMockedHTTPClient(matcher)
+			gqlClient := githubv4.NewClient(httpClient)
+
+			deps := BaseDeps{GQLClient: gqlClient}
+			handler := toolDef.Handler(deps)
+
+			req := createMCPRequest(tc.reqParams)
+			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+			text := getTextResult(t, res).Text
+
+			if tc.expectError {
+				require.True(t, res.IsError)
+				return
+			}
+			require.NoError(t, err)
+
+			var response struct {
+				Categories []map[string]any `json:"categories"`
+				PageInfo   struct {
+					HasNextPage     bool   `json:"hasNextPage"`
+					HasPreviousPage bool   `json:"hasPreviousPage"`
+					StartCursor     string `json:"startCursor"`
+					EndCursor       string `json:"endCursor"`
+				} `json:"pageInfo"`
+				TotalCount int    `json:"totalCount"`
+				Warning    string `json:"warning"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(text), &response))
+			assert.Equal(t, tc.expectedCategories, response.Categories)
+			if _, hasRepo := tc.reqParams["repo"]; hasRepo {
+				assert.Empty(t, response.Warning)
+			} else {
+				assert.Contains(t, response.Warning, ".github")
+			}
+		})
+	}
+}
+
+func Test_GetDiscussionCategory(t *testing.T) {
+	toolDef := GetDiscussionCategory(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_discussion_category", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "get_discussion_category tool should be read-only")
+	assert.ElementsMatch(t, tool.InputSchema.(*jsonschema.Schema).Required, []string{"category_id"})
+
+	qNode := "query($categoryId:ID!){node(id: $categoryId){__typename,... on DiscussionCategory{id,name,emoji,description,isAnswerable}}}"
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qNode,
+			map[string]any{"categoryId": "DIC_1"},
+			githubv4mock.DataResponse(map[string]any{
+				"node": map[string]any{
+					"__typename":   "DiscussionCategory",
+					"id":           "DIC_1",
+					"name":         "Q&A",
+					"emoji":        ":pray:",
+					"description":  "Ask the community for help",
+					"isAnswerable": true,
+				},
+			}),
+		),
+		githubv4mock.NewQueryMatcher(qNode,
+			map[string]any{"categoryId": "D_1"},
+			githubv4mock.DataResponse(map[string]any{"node": map[string]any{"__typename": "Discussion"}}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	t.Run("resolves category details", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"category_id": "DIC_1"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, map[string]any{
+			"id":           "DIC_1",
+			"name":         "Q&A",
+			"emoji":        ":pray:",
+			"description":  "Ask the community for help",
+			"isAnswerable": true,
+		}, out)
+	})
+
+	t.Run("not a discussion category", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"category_id": "D_1"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Equal(t, "D_1 is not a discussion category", getErrorResult(t, res).Text)
+	})
+
+	t.Run("missing category_id", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getErrorResult(t, res).Text, "category_id")
+	})
+}
+
+func Test_CreateDiscussion(t *testing.T) {
+	toolDef := CreateDiscussion(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_discussion", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "create_discussion tool should not be read-only")
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+	assert.Contains(t, schema.Properties, "owner")
+	assert.Contains(t, schema.Properties, "repo")
+	assert.Contains(t, schema.Properties, "title")
+	assert.Contains(t, schema.Properties, "body")
+	assert.Contains(t, schema.Properties, "category_id")
+	assert.Contains(t, schema.Properties, "category_name")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "title", "body"})
+
+	t.Run("create with category_id", func(t *testing.T) {
+		mockedClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(
+				struct {
+					Repository struct {
+						ID githubv4.ID
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}{},
+				map[string]any{
+					"owner": githubv4.String("owner"),
+					"repo":  githubv4.String("repo"),
+				},
+				githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"id": githubv4.ID("repo-id"),
+					},
+				}),
+			),
+			githubv4mock.NewMutationMatcher(
+				struct {
+					CreateDiscussion struct {
+						Discussion struct {
+							ID       githubv4.ID
+							Number   githubv4.Int
+							URL      githubv4.String `graphql:"url"`
+							Category *struct {
+								ID           githubv4.ID
+								Name         githubv4.String
+								IsAnswerable githubv4.Boolean
+							}
+						}
+					} `graphql:"createDiscussion(input: $input)"`
+				}{},
+				githubv4.CreateDiscussionInput{
+					RepositoryID: githubv4.ID("repo-id"),
+					Title:        githubv4.String("My title"),
+					Body:         githubv4.String("My body"),
+					CategoryID:   githubv4.ID("DIC_1"),
+				},
+				nil,
+				githubv4mock.DataResponse(map[string]any{
+					"createDiscussion": map[string]any{
+						"discussion": map[string]any{
+							"id":       githubv4.ID("DISC_1"),
+							"number":   githubv4.Int(1),
+							"url":      githubv4.String("https://github.com/owner/repo/discussions/1"),
+							"category": map[string]any{"id": "DIC_1", "name": "Q&A", "isAnswerable": true},
+						},
+					},
+				}),
+			),
+		)
+
+		deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+		handler := toolDef.Handler(deps)
+
+		req := createMCPRequest(map[string]any{
+			"owner":       "owner",
+			"repo":        "repo",
+			"title":       "My title",
+			"body":        "My body",
+			"category_id": "DIC_1",
+		})
+
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		assert.False(t, res.IsError)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, "DISC_1", out["id"])
+		assert.Equal(t, float64(1), out["number"])
+		assert.Equal(t, "https://github.com/owner/repo/discussions/1", out["url"])
+		assert.Equal(t, "Q&A", out["categoryName"])
+		assert.Equal(t, true, out["categoryIsAnswerable"])
+	})
+
+	t.Run("create with category_name", func(t *testing.T) {
+		mockedClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(
+				struct {
+					Repository struct {
+						ID githubv4.ID
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}{},
+				map[string]any{
+					"owner": githubv4.String("owner"),
+					"repo":  githubv4.String("repo"),
+				},
+				githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"id": githubv4.ID("repo-id"),
+					},
+				}),
+			),
+			githubv4mock.NewQueryMatcher(
+				struct {
+					Repository struct {
+						DiscussionCategories struct {
+							Nodes []struct {
+								ID           githubv4.ID
+								Name         githubv4.String
+								IsAnswerable githubv4.Boolean
+							}
+						} `graphql:"discussionCategories(first: $first)"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}{},
+				map[string]any{
+					"owner": githubv4.String("owner"),
+					"repo":  githubv4.String("repo"),
+					"first": githubv4.Int(100),
+				},
+				githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"discussionCategories": map[string]any{
+							"nodes": []map[string]any{
+								{"id": githubv4.ID("CAT_GENERAL"), "name": githubv4.String("General")},
+							},
+						},
+					},
+				}),
+			),
+			githubv4mock.NewMutationMatcher(
+				struct {
+					CreateDiscussion struct {
+						Discussion struct {
+							ID       githubv4.ID
+							Number   githubv4.Int
+							URL      githubv4.String `graphql:"url"`
+							Category *struct {
+								ID           githubv4.ID
+								Name         githubv4.String
+								IsAnswerable githubv4.Boolean
+							}
+						}
+					} `graphql:"createDiscussion(input: $input)"`
+				}{},
+				githubv4.CreateDiscussionInput{
+					RepositoryID: githubv4.ID("repo-id"),
+					Title:        githubv4.String("My title"),
+					Body:         githubv4.String("My body"),
+					CategoryID:   githubv4.ID("CAT_GENERAL"),
+				},
+				nil,
+				githubv4mock.DataResponse(map[string]any{
+					"createDiscussion": map[string]any{
+						"discussion": map[string]any{
+							"id":       githubv4.ID("DISC_2"),
+							"number":   githubv4.Int(2),
+							"url":      githubv4.String("https://github.com/owner/repo/discussions/2"),
+							"category": map[string]any{"id": "CAT_GENERAL", "name": "General"},
+						},
+					},
+				}),
+			),
+		)
+
+		deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+		handler := toolDef.Handler(deps)
+
+		req := createMCPRequest(map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"title":         "My title",
+			"body":          "My body",
+			"category_name": "General",
+		})
+
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		assert.False(t, res.IsError)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, "DISC_2", out["id"])
+		assert.Equal(t, float64(2), out["number"])
+		assert.Equal(t, "https://github.com/owner/repo/discussions/2", out["url"])
+		// The category resolved from the name is echoed back
+		assert.Equal(t, "CAT_GENERAL", out["categoryId"])
+		assert.Equal(t, "General", out["categoryName"])
+		assert.Equal(t, false, out["categoryIsAnswerable"])
+	})
+
+	t.Run("create with ambiguous category_name", func(t *testing.T) {
+		mockedClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(
+				struct {
+					Repository struct {
+						ID githubv4.ID
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}{},
+				map[string]any{
+					"owner": githubv4.String("owner"),
+					"repo":  githubv4.String("repo"),
+				},
+				githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"id": githubv4.ID("repo-id"),
+					},
+				}),
+			),
+			githubv4mock.NewQueryMatcher(
+				struct {
+					Repository struct {
+						DiscussionCategories struct {
+							Nodes []struct {
+								ID           githubv4.ID
+								Name         githubv4.String
+								IsAnswerable githubv4.Boolean
+							}
+						} `graphql:"discussionCategories(first: $first)"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}{},
+				map[string]any{
+					"owner": githubv4.String("owner"),
+					"repo":  githubv4.String("repo"),
+					"first": githubv4.Int(100),
+				},
+				githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"discussionCategories": map[string]any{
+							"nodes": []map[string]any{
+								{"id": githubv4.ID("CAT_GENERAL"), "name": githubv4.String("General")},
+								{"id": githubv4.ID("CAT_GENERAL_2"), "name": githubv4.String("general")},
+							},
+						},
+					},
+				}),
+			),
+		)
+
+		deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+		handler := toolDef.Handler(deps)
+
+		req := createMCPRequest(map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"title":         "My title",
+			"body":          "My body",
+			"category_name": "General",
+		})
+
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		text := getTextResult(t, res).Text
+		assert.Contains(t, text, "ambiguous")
+		assert.Contains(t, text, "CAT_GENERAL, CAT_GENERAL_2")
+	})
+}
+
+func Test_UpdateDiscussion(t *testing.T) {
+	toolDef := UpdateDiscussion(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_discussion", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "update_discussion tool should not be read-only")
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			struct {
+				Repository struct {
+					Discussion struct {
+						ID githubv4.ID
+					} `graphql:"discussion(number: $discussionNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}{},
+			map[string]any{
+				"owner":            githubv4.String("owner"),
+				"repo":             githubv4.String("repo"),
+				"discussionNumber": githubv4.Int(1),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussion": map[string]any{
+						"id": githubv4.ID("DISC_ID"),
+					},
+				},
+			}),
+		),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				UpdateDiscussion struct {
+					Discussion struct {
+						ID       githubv4.ID
+						Number   githubv4.Int
+						URL      githubv4.String `graphql:"url"`
+						Category *struct {
+							ID   githubv4.ID
+							Name githubv4.String
+						}
+					}
+				} `graphql:"updateDiscussion(input: $input)"`
+			}{},
+			githubv4.UpdateDiscussionInput{
+				DiscussionID: githubv4.ID("DISC_ID"),
+				Title:        func() *githubv4.String { s := githubv4.String("New title"); return &s }(),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"updateDiscussion": map[string]any{
+					"discussion": map[string]any{
+						"id":       githubv4.ID("DISC_ID"),
+						"number":   githubv4.Int(1),
+						"url":      githubv4.String("https://github.com/owner/repo/discussions/1"),
+						"category": map[string]any{"id": "DIC_1", "name": "General"},
+					},
+				},
+			}),
+		),
+	)
+
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{
+		"owner":            "owner",
+		"repo":             "repo",
+		"discussionNumber": int32(1),
+		"title":            "New title",
+	})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	assert.False(t, res.IsError)
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	assert.Equal(t, "DISC_ID", out["id"])
+	assert.Equal(t, float64(1), out["number"])
+	assert.Equal(t, "https://github.com/owner/repo/discussions/1", out["url"])
+	assert.Equal(t, "DIC_1", out["categoryId"])
+	assert.Equal(t, "General", out["categoryName"])
+}
+
+func Test_UpdateDiscussionAppendBody(t *testing.T) {
+	toolDef := UpdateDiscussion(translations.NullTranslationHelper)
+
+	t.Run("appends to the existing body", func(t *testing.T) {
+		mockedClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(
+				struct {
+					Repository struct {
+						Discussion struct {
+							ID   githubv4.ID
+							Body githubv4.String
+						} `graphql:"discussion(number: $discussionNumber)"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}{},
+				map[string]any{
+					"owner":            githubv4.String("owner"),
+					"repo":             githubv4.String("repo"),
+					"discussionNumber": githubv4.Int(1),
+				},
+				githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"discussion": map[string]any{
+							"id":   githubv4.ID("DISC_ID"),
+							"body": githubv4.String("Original body\n"),
+						},
+					},
+				}),
+			),
+			githubv4mock.NewMutationMatcher(
+				struct {
+					UpdateDiscussion struct {
+						Discussion struct {
+							ID       githubv4.ID
+							Number   githubv4.Int
+							URL      githubv4.String `graphql:"url"`
+							Category *struct {
+								ID   githubv4.ID
+								Name githubv4.String
+							}
+						}
+					} `graphql:"updateDiscussion(input: $input)"`
+				}{},
+				githubv4.UpdateDiscussionInput{
+					DiscussionID: githubv4.ID("DISC_ID"),
+					Body:         githubv4.NewString("Original body\n\nAppended note"),
+				},
+				nil,
+				githubv4mock.DataResponse(map[string]any{
+					"updateDiscussion": map[string]any{
+						"discussion": map[string]any{
+							"id":       githubv4.ID("DISC_ID"),
+							"number":   githubv4.Int(1),
+							"url":      githubv4.String("https://github.com/owner/repo/discussions/1"),
+							"category": map[string]any{"id": "DIC_1", "name": "General"},
+						},
+					},
+				}),
+			),
+		)
+
+		deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+		handler := toolDef.Handler(deps)
+
+		req := createMCPRequest(map[string]any{
+			"owner":            "owner",
+			"repo":             "repo",
+			"discussionNumber": int32(1),
+			"appendBody":       "Appended note",
+		})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, "DISC_ID", out["id"])
+	})
+
+	t.Run("body and appendBody are mutually exclusive", func(t *testing.T) {
+		deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient())}
+		handler := toolDef.Handler(deps)
+
+		req := createMCPRequest(map[string]any{
+			"owner":            "owner",
+			"repo":             "repo",
+			"discussionNumber": int32(1),
+			"body":             "Replacement",
+			"appendBody":       "Appended note",
+		})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getTextResult(t, res).Text, "mutually exclusive")
+	})
+}
+
+func Test_AddDiscussionComment(t *testing.T) {
+	toolDef := AddDiscussionComment(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_discussion_comment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "add_discussion_comment tool should not be read-only")
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			struct {
+				Repository struct {
+					Discussion struct {
+						ID githubv4.ID
+					} `graphql:"discussion(number: $discussionNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}{},
+			map[string]any{
+				"owner":            githubv4.String("owner"),
+				"repo":             githubv4.String("repo"),
+				"discussionNumber": githubv4.Int(1),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussion": map[string]any{
+						"id": githubv4.ID("DISC_ID"),
+					},
+				},
+			}),
+		),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				AddDiscussionComment struct {
+					Comment struct {
+						ID  githubv4.ID
+						URL githubv4.String `graphql:"url"`
+					}
+				} `graphql:"addDiscussionComment(input: $input)"`
+			}{},
+			githubv4.AddDiscussionCommentInput{
+				DiscussionID: githubv4.ID("DISC_ID"),
+				Body:         githubv4.String("Hello"),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"addDiscussionComment": map[string]any{
+					"comment": map[string]any{
+						"id":  githubv4.ID("DC_1"),
+						"url": githubv4.String("https://github.com/owner/repo/discussions/1#discussioncomment-1"),
+					},
+				},
+			}),
+		),
+	)
+
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{
+		"owner":            "owner",
+		"repo":             "repo",
+		"discussionNumber": int32(1),
+		"body":             "Hello",
+	})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	assert.False(t, res.IsError)
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	assert.Equal(t, "DC_1", out["id"])
+	assert.Equal(t, "https://github.com/owner/repo/discussions/1#discussioncomment-1", out["url"])
+}
+
+func Test_AddDiscussionCommentAttribution(t *testing.T) {
+	toolDef := AddDiscussionComment(translations.NullTranslationHelper)
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			struct {
+				Repository struct {
+					Discussion struct {
+						ID githubv4.ID
+					} `graphql:"discussion(number: $discussionNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}{},
+			map[string]any{
+				"owner":            githubv4.String("owner"),
+				"repo":             githubv4.String("repo"),
+				"discussionNumber": githubv4.Int(1),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussion": map[string]any{
+						"id": githubv4.ID("DISC_ID"),
+					},
+				},
+			}),
+		),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				AddDiscussionComment struct {
+					Comment struct {
+						ID  githubv4.ID
+						URL githubv4.String `graphql:"url"`
+					}
+				} `graphql:"addDiscussionComment(input: $input)"`
+			}{},
+			githubv4.AddDiscussionCommentInput{
+				DiscussionID: githubv4.ID("DISC_ID"),
+				Body:         githubv4.String("Hello\n\n<!-- source: triage-bot -->"),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"addDiscussionComment": map[string]any{
+					"comment": map[string]any{
+						"id":  githubv4.ID("DC_1"),
+						"url": githubv4.String("https://github.com/owner/repo/discussions/1#discussioncomment-1"),
+					},
+				},
+			}),
+		),
+	)
+
+	// The label is sanitized so it can't close the HTML comment early
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient), MutationAttribution: "triage---bot>"}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{
+		"owner":            "owner",
+		"repo":             "repo",
+		"discussionNumber": int32(1),
+		"body":             "Hello",
+	})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	assert.Equal(t, "DC_1", out["id"])
+}
+
+func Test_UpdateDiscussionComment(t *testing.T) {
+	toolDef := UpdateDiscussionComment(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_discussion_comment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "update_discussion_comment tool should not be read-only")
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewMutationMatcher(
+			struct {
+				UpdateDiscussionComment struct {
+					Comment struct {
+						ID  githubv4.ID
+						URL githubv4.String `graphql:"url"`
+					}
+				} `graphql:"updateDiscussionComment(input: $input)"`
+			}{},
+			githubv4.UpdateDiscussionCommentInput{
+				CommentID: githubv4.ID("DC_1"),
+				Body:      githubv4.String("Updated"),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"updateDiscussionComment": map[string]any{
+					"comment": map[string]any{
+						"id":  githubv4.ID("DC_1"),
+						"url": githubv4.String("https://github.com/owner/repo/discussions/1#discussioncomment-1"),
+					},
+				},
+			}),
+		),
+	)
+
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{
+		"comment_id": "DC_1",
+		"body":       "Updated",
+	})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	assert.False(t, res.IsError)
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	assert.Equal(t, "DC_1", out["id"])
+}
+
+func Test_DeleteDiscussionComment(t *testing.T) {
+	toolDef := DeleteDiscussionComment(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_discussion_comment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "delete_discussion_comment tool should not be read-only")
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewMutationMatcher(
+			struct {
+				DeleteDiscussionComment struct {
+					ClientMutationID githubv4.String
+				} `graphql:"deleteDiscussionComment(input: $input)"`
+			}{},
+			githubv4.DeleteDiscussionCommentInput{
+				ID: githubv4.ID("DC_1"),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"deleteDiscussionComment": map[string]any{
+					"clientMutationId": githubv4.String("mut"),
+				},
+			}),
+		),
+	)
+
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{
+		"comment_id": "DC_1",
+	})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	assert.False(t, res.IsError)
+	assert.Equal(t, "discussion comment deleted successfully", getTextResult(t, res).Text)
+}
+
+func Test_ReactToDiscussionAnswer(t *testing.T) {
+	toolDef := ReactToDiscussionAnswer(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "react_to_discussion_answer", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "react_to_discussion_answer tool should not be read-only")
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "discussionNumber", "content"})
+
+	qAnswer := struct {
+		Repository struct {
+			Discussion struct {
+				Answer *struct {
+					ID  githubv4.ID
+					URL githubv4.String `graphql:"url"`
+				}
+			} `graphql:"discussion(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	vars := map[string]any{
+		"owner":            githubv4.String("owner"),
+		"repo":             githubv4.String("repo"),
+		"discussionNumber": githubv4.Int(1),
+	}
+	addReactionMutation := githubv4mock.NewMutationMatcher(
+		struct {
+			AddReaction struct {
+				Reaction struct {
+					ID      githubv4.ID
+					Content githubv4.ReactionContent
+				}
+			} `graphql:"addReaction(input: $input)"`
+		}{},
+		githubv4.AddReactionInput{
+			SubjectID: githubv4.ID("DC_ANSWER"),
+			Content:   githubv4.ReactionContentHeart,
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{
+			"addReaction": map[string]any{
+				"reaction": map[string]any{
+					"id":      "REA_1",
+					"content": "HEART",
+				},
+			},
+		}),
+	)
+
+	tests := []struct {
+		name        string
+		matchers    []githubv4mock.Matcher
+		expectError bool
+		errContains string
+	}{
+		{
+			name: "reacts to the accepted answer",
+			matchers: []githubv4mock.Matcher{
+				githubv4mock.NewQueryMatcher(qAnswer, vars, githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"discussion": map[string]any{
+							"answer": map[string]any{
+								"id":  "DC_ANSWER",
+								"url": "https://github.com/owner/repo/discussions/1#discussioncomment-9",
+							},
+						},
+					},
+				})),
+				addReactionMutation,
+			},
+		},
+		{
+			name: "unanswered discussion",
+			matchers: []githubv4mock.Matcher{
+				githubv4mock.NewQueryMatcher(qAnswer, vars, githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"discussion": map[string]any{
+							"answer": nil,
+						},
+					},
+				})),
+			},
+			expectError: true,
+			errContains: "has no accepted answer",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matchers...))}
+			handler := toolDef.Handler(deps)
+
+			req := createMCPRequest(map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"discussionNumber": int32(1),
+				"content":          "HEART",
+			})
+			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			text := getTextResult(t, res).Text
+
+			if tc.expectError {
+				require.True(t, res.IsError)
+				assert.Contains(t, text, tc.errContains)
+				return
+			}
+			require.False(t, res.IsError, text)
+
+			var out map[string]any
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			assert.Equal(t, "REA_1", out["reactionId"])
+			assert.Equal(t, "HEART", out["content"])
+			assert.Equal(t, "DC_ANSWER", out["commentId"])
+		})
+	}
+}
+
+func Test_AddDiscussionLabels(t *testing.T) {
+	toolDef := AddDiscussionLabels(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_discussion_labels", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "add_discussion_labels tool should not be read-only")
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "discussionNumber", "labels"})
+
+	qDiscussionLabels := struct {
+		Repository struct {
+			Discussion struct {
+				ID     githubv4.ID
+				Labels struct {
+					Nodes []struct {
+						Name githubv4.String
+					}
+				} `graphql:"labels(first: 100)"`
+			} `graphql:"discussion(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	qLabel := struct {
+		Repository struct {
+			Label struct {
+				ID   githubv4.ID
+				Name githubv4.String
+			} `graphql:"label(name: $name)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	mAddLabels := struct {
+		AddLabelsToLabelable struct {
+			ClientMutationID githubv4.String
+		} `graphql:"addLabelsToLabelable(input: $input)"`
+	}{}
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			qDiscussionLabels,
+			map[string]any{
+				"owner":            githubv4.String("owner"),
+				"repo":             githubv4.String("repo"),
+				"discussionNumber": githubv4.Int(1),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussion": map[string]any{
+						"id": "DISC_ID",
+						"labels": map[string]any{
+							"nodes": []map[string]any{
+								{"name": "bug"},
+							},
+						},
+					},
+				},
+			}),
+		),
+		githubv4mock.NewQueryMatcher(
+			qLabel,
+			map[string]any{
+				"owner": githubv4.String("owner"),
+				"repo":  githubv4.String("repo"),
+				"name":  githubv4.String("enhancement"),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"label": map[string]any{
+						"id":   "LA_ENHANCEMENT",
+						"name": "enhancement",
+					},
+				},
+			}),
+		),
+		githubv4mock.NewMutationMatcher(
+			mAddLabels,
+			githubv4.AddLabelsToLabelableInput{
+				LabelableID: githubv4.ID("DISC_ID"),
+				LabelIDs:    []githubv4.ID{"LA_ENHANCEMENT"},
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"addLabelsToLabelable": map[string]any{
+					"clientMutationId": "",
+				},
+			}),
+		),
+	)
+
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{
+		"owner":            "owner",
+		"repo":             "repo",
+		"discussionNumber": int32(1),
+		"labels":           []any{"Bug", "enhancement"},
+	})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var out struct {
+		Added          []string `json:"added"`
+		AlreadyPresent []string `json:"alreadyPresent"`
+		Labels         []string `json:"labels"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	assert.Equal(t, []string{"enhancement"}, out.Added)
+	assert.Equal(t, []string{"Bug"}, out.AlreadyPresent)
+	assert.Equal(t, []string{"bug", "enhancement"}, out.Labels)
+}
+
+func Test_RemoveDiscussionLabels(t *testing.T) {
+	toolDef := RemoveDiscussionLabels(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_discussion_labels", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "remove_discussion_labels tool should not be read-only")
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "discussionNumber", "labels"})
+
+	qDiscussionLabels := struct {
+		Repository struct {
+			Discussion struct {
+				ID     githubv4.ID
+				Labels struct {
+					Nodes []struct {
+						Name githubv4.String
+					}
+				} `graphql:"labels(first: 100)"`
+			} `graphql:"discussion(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	qLabel := struct {
+		Repository struct {
+			Label struct {
+				ID   githubv4.ID
+				Name githubv4.String
+			} `graphql:"label(name: $name)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	labelMatcher := func(name string, label map[string]any) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(
+			qLabel,
+			map[string]any{
+				"owner": githubv4.String("owner"),
+				"repo":  githubv4.String("repo"),
+				"name":  githubv4.String(name),
+			},
+			githubv4mock.DataResponse(map[string]any{"repository": map[string]any{"label": label}}),
+		)
+	}
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			qDiscussionLabels,
+			map[string]any{
+				"owner":            githubv4.String("owner"),
+				"repo":             githubv4.String("repo"),
+				"discussionNumber": githubv4.Int(1),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussion": map[string]any{
+						"id": "DISC_ID",
+						"labels": map[string]any{
+							"nodes": []map[string]any{
+								{"name": "bug"},
+								{"name": "triage"},
+							},
+						},
+					},
+				},
+			}),
+		),
+		labelMatcher("Bug", map[string]any{"id": "LA_BUG", "name": "bug"}),
+		labelMatcher("enhancement", map[string]any{"id": "LA_ENHANCEMENT", "name": "enhancement"}),
+		labelMatcher("missing", nil),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				RemoveLabelsFromLabelable struct {
+					ClientMutationID githubv4.String
+				} `graphql:"removeLabelsFromLabelable(input: $input)"`
+			}{},
+			githubv4.RemoveLabelsFromLabelableInput{
+				LabelableID: githubv4.ID("DISC_ID"),
+				LabelIDs:    []githubv4.ID{"LA_BUG"},
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"removeLabelsFromLabelable": map[string]any{
+					"clientMutationId": "",
+				},
+			}),
+		),
+	)
+
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+	handler := toolDef.Handler(deps)
+
+	t.Run("removes present labels", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{
+			"owner":            "owner",
+			"repo":             "repo",
+			"discussionNumber": int32(1),
+			"labels":           []any{"Bug", "enhancement"},
+		})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out struct {
+			Removed    []string `json:"removed"`
+			NotPresent []string `json:"notPresent"`
+			Labels     []string `json:"labels"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, []string{"Bug"}, out.Removed)
+		assert.Equal(t, []string{"enhancement"}, out.NotPresent)
+		assert.Equal(t, []string{"triage"}, out.Labels)
+	})
+
+	t.Run("unknown label", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{
+			"owner":            "owner",
+			"repo":             "repo",
+			"discussionNumber": int32(1),
+			"labels":           []any{"missing"},
+		})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Equal(t, "label 'missing' not found in owner/repo", getErrorResult(t, res).Text)
+	})
+}
+
+func Test_DiscussionActivityHistogram(t *testing.T) {
+	toolDef := DiscussionActivityHistogram(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "discussion_activity_histogram", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "discussion_activity_histogram tool should be read-only")
+
+	qHistogram := "query($after:String$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after, orderBy: {field: CREATED_AT, direction: DESC}){nodes{createdAt},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor}}}}"
+
+	page := func(after any, hasNextPage bool, endCursor string, createdAt ...string) githubv4mock.Matcher {
+		nodes := make([]map[string]any, 0, len(createdAt))
+		for _, c := range createdAt {
+			nodes = append(nodes, map[string]any{"createdAt": c})
+		}
+		return githubv4mock.NewQueryMatcher(qHistogram,
+			map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"first": float64(100),
+				"after": after,
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes": nodes,
+						"pageInfo": map[string]any{
+							"hasNextPage":     hasNextPage,
+							"hasPreviousPage": false,
+							"startCursor":     "",
+							"endCursor":       endCursor,
+						},
+					},
+				},
+			}),
+		)
+	}
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		page((*string)(nil), true, "cursor-1", "2023-01-18T10:00:00Z", "2023-01-16T00:00:00Z"),
+		page("cursor-1", true, "cursor-2", "2023-01-15T23:59:59Z", "2023-01-10T08:00:00Z", "2023-01-02T00:00:00Z"),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{
+		"owner":    "owner",
+		"repo":     "repo",
+		"interval": "week",
+		"since":    "2023-01-09T00:00:00Z",
+	})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var out struct {
+		Interval string `json:"interval"`
+		Buckets  []struct {
+			Bucket string `json:"bucket"`
+			Count  int    `json:"count"`
+		} `json:"buckets"`
+		Scanned   int  `json:"scanned"`
+		Truncated bool `json:"truncated"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	assert.Equal(t, "week", out.Interval)
+	require.Len(t, out.Buckets, 2)
+	assert.Equal(t, "2023-01-09", out.Buckets[0].Bucket)
+	assert.Equal(t, 2, out.Buckets[0].Count)
+	assert.Equal(t, "2023-01-16", out.Buckets[1].Bucket)
+	assert.Equal(t, 2, out.Buckets[1].Count)
+	assert.Equal(t, 4, out.Scanned)
+	assert.False(t, out.Truncated)
+}
+
+func Test_ExportDiscussionCategories(t *testing.T) {
+	toolDef := ExportDiscussionCategories(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "export_discussion_categories", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "export_discussion_categories tool should be read-only")
+
+	qCategories := "query($after:String$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussionCategories(first: $first, after: $after){nodes{id,name},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor}}}}"
+	page := func(after any, hasNextPage bool, endCursor string, nodes ...map[string]any) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(qCategories,
+			map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"first": float64(100),
+				"after": after,
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussionCategories": map[string]any{
+						"nodes": nodes,
+						"pageInfo": map[string]any{
+							"hasNextPage":     hasNextPage,
+							"hasPreviousPage": false,
+							"startCursor":     "",
+							"endCursor":       endCursor,
+						},
+					},
+				},
+			}),
+		)
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		page((*string)(nil), true, "cursor-1",
+			map[string]any{"id": "DIC_1", "name": "General"},
+			map[string]any{"id": "DIC_2", "name": "Q&A"},
+		),
+		page("cursor-1", false, "cursor-2",
+			map[string]any{"id": "DIC_3", "name": "general"},
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var out struct {
+		Categories map[string]string `json:"categories"`
+		Note       string            `json:"note"`
+		Truncated  bool              `json:"truncated"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	assert.Equal(t, map[string]string{"General": "DIC_1", "Q&A": "DIC_2", "general": "DIC_3"}, out.Categories)
+	assert.Contains(t, out.Note, "General, general")
+	assert.False(t, out.Truncated)
+
+	t.Run("organisation level warns about the .github fallback", func(t *testing.T) {
+		orgClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(qCategories,
+				map[string]any{"owner": "owner", "repo": ".github", "first": float64(100), "after": (*string)(nil)},
+				githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"discussionCategories": map[string]any{
+							"nodes":    []map[string]any{{"id": "DIC_9", "name": "Announcements"}},
+							"pageInfo": map[string]any{"hasNextPage": false, "hasPreviousPage": false, "startCursor": "", "endCursor": ""},
+						},
+					},
+				}),
+			),
+		)
+		orgDeps := BaseDeps{GQLClient: githubv4.NewClient(orgClient)}
+		req := createMCPRequest(map[string]any{"owner": "owner", "level": "organization"})
+		res, err := toolDef.Handler(orgDeps)(ContextWithDeps(context.Background(), orgDeps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var orgOut struct {
+			Categories map[string]string `json:"categories"`
+			Warning    string            `json:"warning"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &orgOut))
+		assert.Equal(t, map[string]string{"Announcements": "DIC_9"}, orgOut.Categories)
+		assert.Contains(t, orgOut.Warning, ".github repository")
+	})
+
+	t.Run("organisation level reads repo without a warning", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "level": "organization"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var repoOut map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &repoOut))
+		assert.Len(t, repoOut["categories"], 3)
+		assert.NotContains(t, repoOut, "warning")
+	})
+}
+
+func Test_GetDiscussionReferences(t *testing.T) {
+	toolDef := GetDiscussionReferences(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_discussion_references", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "get_discussion_references tool should be read-only")
+
+	qDiscussionURL := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){url}}}"
+	qSearch := "query($after:String$first:Int!$query:String!){search(query: $query, type: ISSUE, first: $first, after: $after){issueCount,nodes{__typename,... on Issue{number,title,url,repository{nameWithOwner}},... on PullRequest{number,title,url,repository{nameWithOwner}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor}}}"
+
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qDiscussionURL,
+			map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"discussion": map[string]any{"url": "https://github.com/owner/repo/discussions/1"}},
+			}),
+		),
+		githubv4mock.NewQueryMatcher(qSearch,
+			map[string]any{"query": `"https://github.com/owner/repo/discussions/1"`, "first": float64(30), "after": (*string)(nil)},
+			githubv4mock.DataResponse(map[string]any{
+				"search": map[string]any{
+					"issueCount": 2,
+					"nodes": []map[string]any{
+						{"__typename": "Issue", "number": 10, "title": "Follow-up", "url": "https://github.com/owner/repo/issues/10", "repository": map[string]any{"nameWithOwner": "owner/repo"}},
+						{"__typename": "PullRequest", "number": 11, "title": "Fix it", "url": "https://github.com/other/repo/pull/11", "repository": map[string]any{"nameWithOwner": "other/repo"}},
+					},
+					"pageInfo": map[string]any{
+						"hasNextPage":     false,
+						"hasPreviousPage": false,
+						"startCursor":     "",
+						"endCursor":       "",
+					},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1)})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var out struct {
+		References []map[string]any `json:"references"`
+		TotalCount int              `json:"totalCount"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	assert.Equal(t, 2, out.TotalCount)
+	assert.Equal(t, []map[string]any{
+		{"type": "Issue", "repository": "owner/repo", "number": float64(10), "title": "Follow-up", "url": "https://github.com/owner/repo/issues/10"},
+		{"type": "PullRequest", "repository": "other/repo", "number": float64(11), "title": "Fix it", "url": "https://github.com/other/repo/pull/11"},
+	}, out.References)
+}
+
+func Test_ListDiscussionsWithRecentCommentBy(t *testing.T) {
+	toolDef := ListDiscussionsWithRecentCommentBy(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_discussions_with_recent_comment_by", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "list_discussions_with_recent_comment_by tool should be read-only")
+
+	qDiscussions := "query($after:String$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after, orderBy: {field: UPDATED_AT, direction: DESC}){nodes{number,title,url,comments(last: 1){nodes{author{login},createdAt}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor}}}}"
+	node := func(number int, latestCommenters ...string) map[string]any {
+		comments := []map[string]any{}
+		for _, login := range latestCommenters {
+			comments = append(comments, map[string]any{"author": map[string]any{"login": login}, "createdAt": "2023-05-01T00:00:00Z"})
+		}
+		return map[string]any{
+			"number":   number,
+			"title":    fmt.Sprintf("Discussion %d", number),
+			"url":      fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+			"comments": map[string]any{"nodes": comments},
+		}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qDiscussions,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(100), "after": (*string)(nil)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes": []map[string]any{node(1, "someone-else"), node(2, "Agent-Bot"), node(3)},
+						"pageInfo": map[string]any{
+							"hasNextPage":     false,
+							"hasPreviousPage": false,
+							"startCursor":     "",
+							"endCursor":       "",
+						},
+					},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "login": "agent-bot"})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var out struct {
+		Discussions []map[string]any `json:"discussions"`
+		Scanned     int              `json:"scanned"`
+		Truncated   bool             `json:"truncated"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	require.Len(t, out.Discussions, 1)
+	assert.Equal(t, float64(2), out.Discussions[0]["number"])
+	assert.Equal(t, "2023-05-01T00:00:00Z", out.Discussions[0]["commentedAt"])
+	assert.Equal(t, 3, out.Scanned)
+	assert.False(t, out.Truncated)
+}
+
+func Test_GetDiscussionAnswerCandidates(t *testing.T) {
+	toolDef := GetDiscussionAnswerCandidates(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_discussion_answer_candidates", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "get_discussion_answer_candidates tool should be read-only")
+
+	qCandidates := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){isAnswered,comments(first: 100){nodes{id,body,url,upvoteCount,author{login}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor}}}}}"
+	response := func(isAnswered bool) githubv4mock.GQLResponse {
+		comment := func(id string, upvotes int) map[string]any {
+			return map[string]any{
+				"id":          id,
+				"body":        "Comment " + id,
+				"url":         "https://github.com/owner/repo/discussions/1#" + id,
+				"upvoteCount": upvotes,
+				"author":      map[string]any{"login": "user-" + id},
+			}
+		}
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{
+				"isAnswered": isAnswered,
+				"comments": map[string]any{
+					"nodes": []map[string]any{comment("DC_1", 1), comment("DC_2", 7), comment("DC_3", 3), comment("DC_4", 7)},
+					"pageInfo": map[string]any{
+						"hasNextPage":     false,
+						"hasPreviousPage": false,
+						"startCursor":     "",
+						"endCursor":       "",
+					},
+				},
+			}},
+		})
+	}
+
+	tests := []struct {
+		name        string
+		number      int
+		isAnswered  bool
+		expectError string
+		expectIDs   []string
+	}{
+		{
+			name:      "ranks comments by upvotes",
+			number:    1,
+			expectIDs: []string{"DC_2", "DC_4", "DC_3"},
+		},
+		{
+			name:        "already answered",
+			number:      2,
+			isAnswered:  true,
+			expectError: "discussion #2 in owner/repo is already answered",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			vars := map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(tc.number)}
+			deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qCandidates, vars, response(tc.isAnswered))))}
+			handler := toolDef.Handler(deps)
+
+			req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": tc.number, "limit": 3})
+			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			if tc.expectError != "" {
+				require.True(t, res.IsError)
+				assert.Contains(t, getTextResult(t, res).Text, tc.expectError)
+				return
+			}
+			require.False(t, res.IsError, getTextResult(t, res).Text)
+
+			var out struct {
+				Candidates []struct {
+					ID          string `json:"id"`
+					UpvoteCount int    `json:"upvoteCount"`
+				} `json:"candidates"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+			ids := make([]string, 0, len(out.Candidates))
+			for _, c := range out.Candidates {
+				ids = append(ids, c.ID)
+			}
+			assert.Equal(t, tc.expectIDs, ids)
+		})
+	}
+}
+
+func Test_VerifyDiscussionComment(t *testing.T) {
+	toolDef := VerifyDiscussionComment(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "verify_discussion_comment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "verify_discussion_comment tool should be read-only")
+
+	qNode := "query($commentId:ID!){node(id: $commentId){__typename,... on DiscussionComment{discussion{number,url,repository{name,owner{login}}}}}}"
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qNode,
+			map[string]any{"commentId": "DC_1"},
+			githubv4mock.DataResponse(map[string]any{
+				"node": map[string]any{
+					"__typename": "DiscussionComment",
+					"discussion": map[string]any{
+						"number":     3,
+						"url":        "https://github.com/owner/repo/discussions/3",
+						"repository": map[string]any{"name": "repo", "owner": map[string]any{"login": "owner"}},
+					},
+				},
+			}),
+		),
+		githubv4mock.NewQueryMatcher(qNode,
+			map[string]any{"commentId": "IC_1"},
+			githubv4mock.DataResponse(map[string]any{"node": map[string]any{"__typename": "IssueComment"}}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	tests := []struct {
+		name          string
+		args          map[string]any
+		expectError   string
+		expectMatches bool
+	}{
+		{
+			name:          "matching parent",
+			args:          map[string]any{"comment_id": "DC_1", "owner": "Owner", "repo": "repo", "discussionNumber": 3},
+			expectMatches: true,
+		},
+		{
+			name:          "different discussion",
+			args:          map[string]any{"comment_id": "DC_1", "owner": "owner", "repo": "repo", "discussionNumber": 4},
+			expectMatches: false,
+		},
+		{
+			name:        "not a discussion comment",
+			args:        map[string]any{"comment_id": "IC_1"},
+			expectError: "IC_1 is not a discussion comment",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := createMCPRequest(tc.args)
+			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			if tc.expectError != "" {
+				require.True(t, res.IsError)
+				assert.Contains(t, getTextResult(t, res).Text, tc.expectError)
+				return
+			}
+			require.False(t, res.IsError, getTextResult(t, res).Text)
+
+			var out map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+			assert.Equal(t, tc.expectMatches, out["matches"])
+			assert.Equal(t, float64(3), out["discussionNumber"])
+			assert.Equal(t, "https://github.com/owner/repo/discussions/3", out["url"])
+		})
+	}
+}
+
+func Test_ListLockedDiscussions(t *testing.T) {
+	toolDef := ListLockedDiscussions(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_locked_discussions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "list_locked_discussions tool should be read-only")
+
+	qDiscussions := "query($after:String$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after, orderBy: {field: UPDATED_AT, direction: DESC}){nodes{number,title,url,locked,activeLockReason},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor}}}}"
+	node := func(number int, locked bool, reason any) map[string]any {
+		return map[string]any{
+			"number":           number,
+			"title":            fmt.Sprintf("Discussion %d", number),
+			"url":              fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+			"locked":           locked,
+			"activeLockReason": reason,
+		}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qDiscussions,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(100), "after": (*string)(nil)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes": []map[string]any{node(1, false, nil), node(2, true, "SPAM"), node(3, true, nil)},
+						"pageInfo": map[string]any{
+							"hasNextPage":     false,
+							"hasPreviousPage": false,
+							"startCursor":     "",
+							"endCursor":       "",
+						},
+					},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var out struct {
+		Discussions []map[string]any `json:"discussions"`
+		Scanned     int              `json:"scanned"`
+		Truncated   bool             `json:"truncated"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	require.Len(t, out.Discussions, 2)
+	assert.Equal(t, float64(2), out.Discussions[0]["number"])
+	assert.Equal(t, "SPAM", out.Discussions[0]["lockReason"])
+	assert.Equal(t, float64(3), out.Discussions[1]["number"])
+	assert.Nil(t, out.Discussions[1]["lockReason"])
+	assert.Equal(t, 3, out.Scanned)
+	assert.False(t, out.Truncated)
+}
+
+func Test_GetDiscussions(t *testing.T) {
+	toolDef := GetDiscussions(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_discussions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "get_discussions tool should be read-only")
+
+	qGetDiscussion := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,url,closed,isAnswered}}}"
+	vars := func(number int) map[string]any {
+		return map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(number)}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qGetDiscussion, vars(1), githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussion": map[string]any{
+					"number":     1,
+					"title":      "First",
+					"url":        "https://github.com/owner/repo/discussions/1",
+					"closed":     false,
+					"isAnswered": true,
+				},
+			},
+		})),
+		githubv4mock.NewQueryMatcher(qGetDiscussion, vars(2), githubv4mock.ErrorResponse("Could not resolve to a Discussion with the number of 2.")),
+		githubv4mock.NewQueryMatcher(qGetDiscussion, vars(3), githubv4mock.ErrorResponse("Something went wrong while executing your query.")),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	t.Run("mixed results", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumbers": []any{float64(1), float64(2), float64(3)}})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out struct {
+			Results   []map[string]any `json:"results"`
+			Succeeded int              `json:"succeeded"`
+			Failed    int              `json:"failed"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		require.Len(t, out.Results, 3)
+		assert.Equal(t, 1, out.Succeeded)
+		assert.Equal(t, 2, out.Failed)
+
+		// Every item has the same keys, whatever its status
+		for _, r := range out.Results {
+			assert.ElementsMatch(t, []string{"number", "status", "code", "message", "data"}, keysOf(r))
+		}
+
+		assert.Equal(t, float64(1), out.Results[0]["number"])
+		assert.Equal(t, "ok", out.Results[0]["status"])
+		assert.Nil(t, out.Results[0]["code"])
+		assert.Nil(t, out.Results[0]["message"])
+		assert.Equal(t, "First", out.Results[0]["data"].(map[string]any)["title"])
+
+		assert.Equal(t, float64(2), out.Results[1]["number"])
+		assert.Equal(t, "error", out.Results[1]["status"])
+		assert.Equal(t, "NOT_FOUND", out.Results[1]["code"])
+		assert.Contains(t, out.Results[1]["message"], "Could not resolve")
+		assert.Nil(t, out.Results[1]["data"])
+
+		assert.Equal(t, "error", out.Results[2]["status"])
+		assert.Equal(t, "FAILED", out.Results[2]["code"])
+	})
+
+	t.Run("too many discussions", func(t *testing.T) {
+		numbers := make([]any, maxBatchDiscussions+1)
+		for i := range numbers {
+			numbers[i] = float64(i + 1)
+		}
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumbers": numbers})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getTextResult(t, res).Text, "at most 25")
+	})
+}
+
+func keysOf(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func Test_GetOldestUnansweredDiscussion(t *testing.T) {
+	toolDef := GetOldestUnansweredDiscussion(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_oldest_unanswered_discussion", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "get_oldest_unanswered_discussion tool should be read-only")
+
+	qDiscussions := "query($after:String$categoryId:ID!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after, categoryId: $categoryId, orderBy: {field: CREATED_AT, direction: ASC}){nodes{number,title,url,createdAt,closed,isAnswered},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor}}}}"
+	node := func(number int, closed, answered bool) map[string]any {
+		return map[string]any{
+			"number":     number,
+			"title":      fmt.Sprintf("Question %d", number),
+			"url":        fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+			"createdAt":  fmt.Sprintf("2024-01-%02dT00:00:00Z", number),
+			"closed":     closed,
+			"isAnswered": answered,
+		}
+	}
+	page := func(hasNextPage bool, endCursor string, nodes ...map[string]any) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussions": map[string]any{
+					"nodes": nodes,
+					"pageInfo": map[string]any{
+						"hasNextPage":     hasNextPage,
+						"hasPreviousPage": false,
+						"startCursor":     "",
+						"endCursor":       endCursor,
+					},
+				},
+			},
+		})
+	}
+	vars := func(category string, after any) map[string]any {
+		return map[string]any{"owner": "owner", "repo": "repo", "categoryId": category, "first": float64(100), "after": after}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qDiscussions, vars("DIC_Q", (*string)(nil)), page(true, "cursor-1", node(1, false, true), node(2, true, false))),
+		githubv4mock.NewQueryMatcher(qDiscussions, vars("DIC_Q", "cursor-1"), page(false, "", node(3, false, false), node(4, false, false))),
+		githubv4mock.NewQueryMatcher(qDiscussions, vars("DIC_EMPTY", (*string)(nil)), page(false, "", node(1, false, true))),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	t.Run("found on the second page", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "category": "DIC_Q"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out struct {
+			Discussion map[string]any `json:"discussion"`
+			Scanned    int            `json:"scanned"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, float64(3), out.Discussion["number"])
+		assert.Equal(t, "Question 3", out.Discussion["title"])
+		assert.Equal(t, 3, out.Scanned)
+	})
+
+	t.Run("none unanswered", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "category": "DIC_EMPTY"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError)
+		assert.Equal(t, "no open unanswered discussions in category DIC_EMPTY of owner/repo", getTextResult(t, res).Text)
+	})
+}
+
+func Test_GetDiscussionCommentsPageTokens(t *testing.T) {
+	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$includeReplies:Boolean!$includeReplyContext:Boolean!$owner:String!$replyCount:Int!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{id,body,url,isAnswer,author{login},createdAt,updatedAt,isMinimized,minimizedReason,reactionGroups{content,users{totalCount}},replyTo @include(if: $includeReplyContext){id,author{login}},replies(first: $replyCount) @include(if: $includeReplies){nodes{id,body,url},pageInfo{hasNextPage}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},answerChosenAt,answerChosenBy{login}}}}"
+	vars := func(after any) map[string]any {
+		return map[string]any{
+			"owner":               "owner",
+			"repo":                "repo",
+			"discussionNumber":    float64(1),
+			"first":               float64(2),
+			"after":               after,
+			"includeReplyContext": false,
+			"includeReplies":      false,
+			"replyCount":          float64(5),
+		}
+	}
+	page := func(hasNextPage bool, endCursor string, ids ...string) githubv4mock.GQLResponse {
+		nodes := make([]map[string]any, 0, len(ids))
+		for _, id := range ids {
+			nodes = append(nodes, map[string]any{"id": id, "body": "Comment " + id, "url": "https://github.com/owner/repo/discussions/1#" + id, "isAnswer": false})
+		}
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussion": map[string]any{
+					"comments": map[string]any{
+						"nodes": nodes,
+						"pageInfo": map[string]any{
+							"hasNextPage":     hasNextPage,
+							"hasPreviousPage": endCursor == "",
+							"startCursor":     "",
+							"endCursor":       endCursor,
+						},
+						"totalCount": 4,
+					},
+					"answerChosenAt": nil,
+					"answerChosenBy": nil,
+				},
+			},
+		})
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qGetComments, vars((*string)(nil)), page(true, "cursor-1", "DC_1", "DC_2")),
+		// A set cursor is sent as a non-null String
+		githubv4mock.NewQueryMatcher(strings.Replace(qGetComments, "$after:String", "$after:String!", 1), vars("cursor-1"), page(false, "", "DC_3", "DC_4")),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := GetDiscussionComments(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	t.Run("round trip through a paging loop", func(t *testing.T) {
+		var ids []string
+		args := map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "perPage": float64(2), "usePageTokens": true}
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 3, "paging loop did not terminate")
+			req := createMCPRequest(args)
+			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			require.False(t, res.IsError, getTextResult(t, res).Text)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+			assert.NotContains(t, response, "pageInfo")
+			require.Contains(t, response, "nextPageToken")
+			for _, c := range response["comments"].([]any) {
+				ids = append(ids, c.(map[string]any)["id"].(string))
+			}
+			if response["nextPageToken"] == nil {
+				break
+			}
+			args = map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "perPage": float64(2), "pageToken": response["nextPageToken"]}
+		}
+		assert.Equal(t, []string{"DC_1", "DC_2", "DC_3", "DC_4"}, ids)
+	})
+
+	t.Run("pageToken with after", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "after": "cursor-1", "pageToken": encodePageToken("cursor-1")})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getTextResult(t, res).Text, "mutually exclusive")
+	})
+
+	t.Run("invalid pageToken", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "pageToken": "cursor-1"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getTextResult(t, res).Text, "invalid pageToken")
+	})
+}
+
+func Test_RemoveDiscussionReaction(t *testing.T) {
+	toolDef := RemoveDiscussionReaction(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_discussion_reaction", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "remove_discussion_reaction tool should not be read-only")
+
+	qReactions := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){id,reactionGroups{content,viewerHasReacted}}}}"
+	reactionsResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"discussion": map[string]any{
+				"id": "D_1",
+				"reactionGroups": []map[string]any{
+					{"content": "THUMBS_UP", "viewerHasReacted": false},
+					{"content": "HEART", "viewerHasReacted": true},
+				},
+			},
+		},
+	})
+	removeReactionMutation := githubv4mock.NewMutationMatcher(
+		struct {
+			RemoveReaction struct {
+				Reaction struct {
+					Content githubv4.ReactionContent
+				}
+			} `graphql:"removeReaction(input: $input)"`
+		}{},
+		githubv4.RemoveReactionInput{
+			SubjectID: githubv4.ID("D_1"),
+			Content:   githubv4.ReactionContentHeart,
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{
+			"removeReaction": map[string]any{
+				"reaction": map[string]any{
+					"content": "HEART",
+				},
+			},
+		}),
+	)
+	// No mutation is mocked for THUMBS_UP, so calling removeReaction for it would fail the request
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qReactions, map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)}, reactionsResponse),
+		removeReactionMutation,
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	tests := []struct {
+		name            string
+		content         string
+		expectedRemoved bool
+	}{
+		{name: "removes an existing reaction", content: "HEART", expectedRemoved: true},
+		{name: "no-op without a reaction to remove", content: "THUMBS_UP", expectedRemoved: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "content": tc.content})
+			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			require.False(t, res.IsError, getTextResult(t, res).Text)
+
+			var out map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+			assert.Equal(t, tc.expectedRemoved, out["removed"])
+			assert.Equal(t, tc.content, out["content"])
+		})
+	}
+
+	t.Run("by subject_id", func(t *testing.T) {
+		qNodeReactions := "query($id:ID!){node(id: $id){... on Reactable{reactionGroups{content,viewerHasReacted}}}}"
+		mockClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(qNodeReactions, map[string]any{"id": "DC_1"}, githubv4mock.DataResponse(map[string]any{
+				"node": map[string]any{
+					"reactionGroups": []map[string]any{{"content": "ROCKET", "viewerHasReacted": true}},
+				},
+			})),
+			githubv4mock.NewMutationMatcher(
+				struct {
+					RemoveReaction struct {
+						Reaction struct {
+							Content githubv4.ReactionContent
+						}
+					} `graphql:"removeReaction(input: $input)"`
+				}{},
+				githubv4.RemoveReactionInput{SubjectID: githubv4.ID("DC_1"), Content: githubv4.ReactionContentRocket},
+				nil,
+				githubv4mock.DataResponse(map[string]any{"removeReaction": map[string]any{"reaction": map[string]any{"content": "ROCKET"}}}),
+			),
+		)
+		deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+		req := createMCPRequest(map[string]any{"subject_id": "DC_1", "content": "ROCKET"})
+		res, err := toolDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, true, out["removed"])
+		assert.Equal(t, "DC_1", out["subjectId"])
+		assert.NotContains(t, out, "discussionNumber")
+	})
+
+	t.Run("missing subject", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "content": "HEART"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Equal(t, "either subject_id or owner, repo and discussionNumber are required", getErrorResult(t, res).Text)
+	})
+}
+
+func Test_AddDiscussionReaction(t *testing.T) {
+	toolDef := AddDiscussionReaction(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_discussion_reaction", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "add_discussion_reaction tool should not be read-only")
+	assert.ElementsMatch(t, tool.InputSchema.(*jsonschema.Schema).Required, []string{"subject_id", "content"})
+
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewMutationMatcher(
+			struct {
+				AddReaction struct {
+					Reaction struct {
+						ID      githubv4.ID
+						Content githubv4.ReactionContent
+					}
+					Subject struct {
+						Reactions struct {
+							TotalCount githubv4.Int
+						}
+					}
+				} `graphql:"addReaction(input: $input)"`
+			}{},
+			githubv4.AddReactionInput{SubjectID: githubv4.ID("DC_1"), Content: githubv4.ReactionContentHooray},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"addReaction": map[string]any{
+					"reaction": map[string]any{"id": "REA_1", "content": "HOORAY"},
+					"subject":  map[string]any{"reactions": map[string]any{"totalCount": 4}},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	t.Run("adds a reaction", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"subject_id": "DC_1", "content": "HOORAY"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, "REA_1", out["reactionId"])
+		assert.Equal(t, "HOORAY", out["content"])
+		assert.Equal(t, "DC_1", out["subjectId"])
+		assert.Equal(t, float64(4), out["reactionCount"])
+	})
+
+	t.Run("missing subject_id", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"content": "HOORAY"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getErrorResult(t, res).Text, "subject_id")
+	})
+}
+
+func Test_GetDiscussionSignals(t *testing.T) {
+	toolDef := GetDiscussionSignals(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_discussion_signals", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "get_discussion_signals tool should be read-only")
+
+	qSignals := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){isAnswered,author{login},reactions{totalCount},comments(first: 100){totalCount,nodes{id,url,upvoteCount,author{login}},pageInfo{hasNextPage}}}}}"
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qSignals, map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)}, githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussion": map[string]any{
+					"isAnswered": true,
+					"author":     map[string]any{"login": "alice"},
+					"reactions":  map[string]any{"totalCount": 7},
+					"comments": map[string]any{
+						"totalCount": 4,
+						"nodes": []map[string]any{
+							{"id": "DC_1", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-1", "upvoteCount": 2, "author": map[string]any{"login": "bob"}},
+							{"id": "DC_2", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-2", "upvoteCount": 5, "author": map[string]any{"login": "carol"}},
+							{"id": "DC_3", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-3", "upvoteCount": 5, "author": map[string]any{"login": "alice"}},
+							{"id": "DC_4", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-4", "upvoteCount": 0, "author": nil},
+						},
+						"pageInfo": map[string]any{"hasNextPage": false},
+					},
+				},
+			},
+		})),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1)})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var out struct {
+		Signals   map[string]any `json:"signals"`
+		Truncated bool           `json:"truncated"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	assert.Equal(t, float64(7), out.Signals["totalReactions"])
+	assert.Equal(t, true, out.Signals["isAnswered"])
+	assert.Equal(t, float64(3), out.Signals["participantCount"])
+	assert.Equal(t, float64(4), out.Signals["commentCount"])
+	assert.Equal(t, map[string]any{
+		"id":          "DC_2",
+		"url":         "https://github.com/owner/repo/discussions/1#discussioncomment-2",
+		"upvoteCount": float64(5),
+		"author":      "carol",
+	}, out.Signals["topUpvotedComment"])
+	assert.False(t, out.Truncated)
+}
+
+func Test_AddDiscussionComments(t *testing.T) {
+	toolDef := AddDiscussionComments(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_discussion_comments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "add_discussion_comments tool should not be read-only")
+
+	qDiscussionID := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){id}}}"
+	idVars := func(number int) map[string]any {
+		return map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(number)}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qDiscussionID, idVars(1), githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{"id": "D_1"}},
+		})),
+		githubv4mock.NewQueryMatcher(qDiscussionID, idVars(2), githubv4mock.ErrorResponse("Could not resolve to a Discussion with the number of 2.")),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				AddDiscussionComment struct {
+					Comment struct {
+						ID  githubv4.ID
+						URL githubv4.String `graphql:"url"`
+					}
+				} `graphql:"addDiscussionComment(input: $input)"`
+			}{},
+			githubv4.AddDiscussionCommentInput{
+				DiscussionID: githubv4.ID("D_1"),
+				Body:         githubv4.String("Closing the loop"),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"addDiscussionComment": map[string]any{
+					"comment": map[string]any{
+						"id":  "DC_1",
+						"url": "https://github.com/owner/repo/discussions/1#discussioncomment-1",
+					},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	t.Run("comments on each discussion", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumbers": []any{float64(1), float64(2)}, "body": "Closing the loop"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out struct {
+			Results []map[string]any `json:"results"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		require.Len(t, out.Results, 2)
+		assert.Equal(t, "ok", out.Results[0]["status"])
+		assert.Equal(t, "DC_1", out.Results[0]["data"].(map[string]any)["id"])
+		assert.Equal(t, "error", out.Results[1]["status"])
+		assert.Equal(t, "NOT_FOUND", out.Results[1]["code"])
+		assert.Equal(t, "discussion #2 not found in owner/repo", out.Results[1]["message"])
+	})
+
+	t.Run("reply_to_id with several discussions", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumbers": []any{float64(1), float64(2)}, "body": "Closing the loop", "reply_to_id": "DC_0"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getTextResult(t, res).Text, "reply_to_id can only be used with a single discussion number")
+	})
+}
+
+func Test_ListPinnedDiscussions(t *testing.T) {
+	toolDef := ListPinnedDiscussions(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_pinned_discussions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "list_pinned_discussions tool should be read-only")
+
+	qPinned := "query($first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){pinnedDiscussions(first: $first){nodes{discussion{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},preconfiguredGradient}}}}"
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qPinned, map[string]any{"owner": "owner", "repo": "repo", "first": float64(10), "includeBody": false}, githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"pinnedDiscussions": map[string]any{
+					"nodes": []map[string]any{
+						{"discussion": discussionsGeneral[1], "preconfiguredGradient": "BLUE_MINT"},
+						{"discussion": discussionsGeneral[0], "preconfiguredGradient": nil},
+					},
+				},
+			},
+		})),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var out struct {
+		Discussions []map[string]any `json:"discussions"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	require.Len(t, out.Discussions, 2)
+	// Pin order differs from number order, and must be kept
+	assert.Equal(t, float64(3), out.Discussions[0]["number"])
+	assert.Equal(t, "Discussion 3 title", out.Discussions[0]["title"])
+	assert.Equal(t, "BLUE_MINT", out.Discussions[0]["preconfiguredGradient"])
+	assert.Equal(t, float64(1), out.Discussions[1]["number"])
+	assert.NotContains(t, out.Discussions[1], "preconfiguredGradient")
+}
+
+func Test_PinDiscussion(t *testing.T) {
+	toolDef := PinDiscussion(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "pin_discussion", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "pin_discussion tool should not be read-only")
+
+	qDiscussionID := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){id}}}"
+	discussionID := func(number int, id string) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(qDiscussionID,
+			map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(number)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"discussion": map[string]any{"id": id}},
+			}),
+		)
+	}
+	pinMutation := func(id string, response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				PinDiscussion struct {
+					ClientMutationID githubv4.String
+				} `graphql:"pinDiscussion(input: $input)"`
+			}{},
+			PinDiscussionInput{DiscussionID: githubv4.ID(id)},
+			nil,
+			response,
+		)
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		discussionID(1, "D_1"),
+		discussionID(2, "D_2"),
+		discussionID(3, "D_3"),
+		pinMutation("D_1", githubv4mock.DataResponse(map[string]any{"pinDiscussion": map[string]any{"clientMutationId": ""}})),
+		pinMutation("D_2", githubv4mock.ErrorResponse("Repository has reached the maximum number of pinned discussions")),
+		pinMutation("D_3", githubv4mock.ErrorResponse("API rate limit exceeded while trying to pin")),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	t.Run("pins the discussion", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1)})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, float64(1), out["number"])
+		assert.Equal(t, true, out["pinned"])
+	})
+
+	t.Run("pin limit reached", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(2)})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Equal(t, "the repository already has as many pinned discussions as it allows: unpin one with unpin_discussion first", getErrorResult(t, res).Text)
+	})
+
+	t.Run("rate limit is not reported as the pin limit", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(3)})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Equal(t, "API rate limit exceeded while trying to pin", getErrorResult(t, res).Text)
+	})
+}
+
+func Test_UnpinDiscussion(t *testing.T) {
+	toolDef := UnpinDiscussion(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "unpin_discussion", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "unpin_discussion tool should not be read-only")
+
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			"query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){id}}}",
+			map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"discussion": map[string]any{"id": "D_1"}},
+			}),
+		),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				UnpinDiscussion struct {
+					ClientMutationID githubv4.String
+				} `graphql:"unpinDiscussion(input: $input)"`
+			}{},
+			UnpinDiscussionInput{DiscussionID: githubv4.ID("D_1")},
+			nil,
+			githubv4mock.DataResponse(map[string]any{"unpinDiscussion": map[string]any{"clientMutationId": ""}}),
+		),
+		githubv4mock.NewQueryMatcher(
+			"query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){id}}}",
+			map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(2)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"discussion": map[string]any{"id": "D_2"}},
+			}),
+		),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				UnpinDiscussion struct {
+					ClientMutationID githubv4.String
+				} `graphql:"unpinDiscussion(input: $input)"`
+			}{},
+			UnpinDiscussionInput{DiscussionID: githubv4.ID("D_2")},
+			nil,
+			githubv4mock.ErrorResponse("Could not resolve to a node with the global id of 'D_2'"),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+
+	t.Run("unpins the discussion", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1)})
+		res, err := toolDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, float64(1), out["number"])
+		assert.Equal(t, false, out["pinned"])
+	})
+
+	t.Run("discussion gone before the unpin", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(2)})
+		res, err := toolDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Equal(t, "discussion #2 not found in owner/repo", getErrorResult(t, res).Text)
+	})
+}
+
+func Test_GetDiscussionCommentsSinceCursor(t *testing.T) {
+	// A set cursor is sent as a non-null String
+	qGetComments := "query($after:String!$discussionNumber:Int!$first:Int!$includeReplies:Boolean!$includeReplyContext:Boolean!$owner:String!$replyCount:Int!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{id,body,url,isAnswer,author{login},createdAt,updatedAt,isMinimized,minimizedReason,reactionGroups{content,users{totalCount}},replyTo @include(if: $includeReplyContext){id,author{login}},replies(first: $replyCount) @include(if: $includeReplies){nodes{id,body,url},pageInfo{hasNextPage}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},answerChosenAt,answerChosenBy{login}}}}"
+	vars := func(after string) map[string]any {
+		return map[string]any{
+			"owner":               "owner",
+			"repo":                "repo",
+			"discussionNumber":    float64(1),
+			"first":               float64(30),
+			"after":               after,
+			"includeReplyContext": false,
+			"includeReplies":      false,
+			"replyCount":          float64(5),
+		}
+	}
+	page := func(endCursor string, nodes ...map[string]any) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussion": map[string]any{
+					"comments": map[string]any{
+						"nodes": nodes,
+						"pageInfo": map[string]any{
+							"hasNextPage":     false,
+							"hasPreviousPage": true,
+							"startCursor":     endCursor,
+							"endCursor":       endCursor,
+						},
+						"totalCount": 3,
+					},
+					"answerChosenAt": nil,
+					"answerChosenBy": nil,
+				},
+			},
+		})
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qGetComments, vars("cursor-2"), page("cursor-3",
+			map[string]any{"id": "DC_3", "body": "Newest comment", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-3", "isAnswer": false},
+		)),
+		githubv4mock.NewQueryMatcher(qGetComments, vars("cursor-3"), page("")),
+		githubv4mock.NewQueryMatcher(qGetComments, vars("cursor-4"), page("cursor-5",
+			map[string]any{"id": "DC_5", "body": "Spam", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-5", "isMinimized": true, "minimizedReason": "spam"},
+		)),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := GetDiscussionComments(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	var response struct {
+		Comments     []map[string]any `json:"comments"`
+		HasNew       bool             `json:"hasNew"`
+		LatestCursor string           `json:"latestCursor"`
+	}
+
+	t.Run("only newer comments", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "sinceCursor": "cursor-2"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		require.Len(t, response.Comments, 1)
+		assert.Equal(t, "DC_3", response.Comments[0]["id"])
+		assert.True(t, response.HasNew)
+		assert.Equal(t, "cursor-3", response.LatestCursor)
+	})
+
+	t.Run("no new comments", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "sinceCursor": "cursor-3"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		response.Comments = nil
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		assert.Empty(t, response.Comments)
+		assert.False(t, response.HasNew)
+		assert.Equal(t, "cursor-3", response.LatestCursor)
+	})
+
+	t.Run("only minimized new comments", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "sinceCursor": "cursor-4", "includeMinimized": false})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		response.Comments = nil
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		assert.Empty(t, response.Comments)
+		assert.False(t, response.HasNew)
+		assert.Equal(t, "cursor-5", response.LatestCursor, "the cursor moves past the left out comments")
+	})
+
+	t.Run("combined with after", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "sinceCursor": "cursor-3", "after": "cursor-1"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getTextResult(t, res).Text, "sinceCursor cannot be combined")
+	})
+}
+
+func Test_CreateDiscussionFirstComment(t *testing.T) {
+	createMutation := func(body string, number int) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				CreateDiscussion struct {
+					Discussion struct {
+						ID       githubv4.ID
+						Number   githubv4.Int
+						URL      githubv4.String `graphql:"url"`
+						Category *struct {
+							ID           githubv4.ID
+							Name         githubv4.String
+							IsAnswerable githubv4.Boolean
+						}
+					}
+				} `graphql:"createDiscussion(input: $input)"`
+			}{},
+			githubv4.CreateDiscussionInput{
+				RepositoryID: githubv4.ID("repo-id"),
+				Title:        githubv4.String("My title"),
+				Body:         githubv4.String(body),
+				CategoryID:   githubv4.ID("DIC_1"),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"createDiscussion": map[string]any{
+					"discussion": map[string]any{
+						"id":       fmt.Sprintf("DISC_%d", number),
+						"number":   number,
+						"url":      fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+						"category": map[string]any{"id": "DIC_1", "name": "General"},
+					},
+				},
+			}),
+		)
+	}
+	commentMutation := func(discussionID, body string, response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				AddDiscussionComment struct {
+					Comment struct {
+						ID  githubv4.ID
+						URL githubv4.String `graphql:"url"`
+					}
+				} `graphql:"addDiscussionComment(input: $input)"`
+			}{},
+			githubv4.AddDiscussionCommentInput{
+				DiscussionID: githubv4.ID(discussionID),
+				Body:         githubv4.String(body),
+			},
+			nil,
+			response,
+		)
+	}
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			struct {
+				Repository struct {
+					ID githubv4.ID
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}{},
+			map[string]any{
+				"owner": githubv4.String("owner"),
+				"repo":  githubv4.String("repo"),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"id": githubv4.ID("repo-id"),
+				},
+			}),
+		),
+		createMutation("First body", 1),
+		createMutation("Second body", 2),
+		commentMutation("DISC_1", "Some context", githubv4mock.DataResponse(map[string]any{
+			"addDiscussionComment": map[string]any{
+				"comment": map[string]any{
+					"id":  "DC_1",
+					"url": "https://github.com/owner/repo/discussions/1#discussioncomment-1",
+				},
+			},
+		})),
+		commentMutation("DISC_2", "Some context", githubv4mock.ErrorResponse("Something went wrong while executing your query.")),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+	toolDef := CreateDiscussion(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	t.Run("posts the first comment", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "title": "My title", "body": "First body", "category_id": "DIC_1", "first_comment": "Some context"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, "https://github.com/owner/repo/discussions/1", out["url"])
+		assert.Equal(t, map[string]any{"id": "DC_1", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-1"}, out["firstComment"])
+		assert.NotContains(t, out, "warning")
+	})
+
+	t.Run("comment failure still returns the discussion", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "title": "My title", "body": "Second body", "category_id": "DIC_1", "first_comment": "Some context"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, "DISC_2", out["id"])
+		assert.Equal(t, "https://github.com/owner/repo/discussions/2", out["url"])
+		assert.NotContains(t, out, "firstComment")
+		assert.Contains(t, out["warning"], "the discussion was created, but posting first_comment failed")
+	})
+}
+
+func Test_GetDiscussionFirstResponseTimes(t *testing.T) {
+	toolDef := GetDiscussionFirstResponseTimes(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_discussion_first_response_times", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "get_discussion_first_response_times tool should be read-only")
+
+	qFirstResponse := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){createdAt,author{login},comments(first: 100){nodes{createdAt,author{login}},pageInfo{hasNextPage}}}}}"
+	discussion := func(comments ...map[string]any) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussion": map[string]any{
+					"createdAt": "2024-01-01T00:00:00Z",
+					"author":    map[string]any{"login": "asker"},
+					"comments": map[string]any{
+						"nodes":    comments,
+						"pageInfo": map[string]any{"hasNextPage": false},
+					},
+				},
+			},
+		})
+	}
+	vars := func(number int) map[string]any {
+		return map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(number)}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qFirstResponse, vars(1), discussion(
+			map[string]any{"createdAt": "2024-01-01T00:30:00Z", "author": map[string]any{"login": "asker"}},
+			map[string]any{"createdAt": "2024-01-01T02:00:00Z", "author": map[string]any{"login": "helper"}},
+			map[string]any{"createdAt": "2024-01-01T03:00:00Z", "author": map[string]any{"login": "other"}},
+		)),
+		githubv4mock.NewQueryMatcher(qFirstResponse, vars(2), discussion(
+			map[string]any{"createdAt": "2024-01-01T00:30:00Z", "author": map[string]any{"login": "asker"}},
+		)),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumbers": []any{float64(1), float64(2)}})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var out struct {
+		Results []struct {
+			Number int            `json:"number"`
+			Status string         `json:"status"`
+			Data   map[string]any `json:"data"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	require.Len(t, out.Results, 2)
+
+	// The author's own follow-up doesn't count as a response
+	responded := out.Results[0]
+	assert.Equal(t, "ok", responded.Status)
+	assert.Equal(t, true, responded.Data["responded"])
+	assert.Equal(t, "helper", responded.Data["firstResponder"])
+	assert.Equal(t, "2024-01-01T02:00:00Z", responded.Data["firstResponseAt"])
+	assert.Equal(t, float64(7200), responded.Data["timeToFirstResponseSeconds"])
+
+	noResponse := out.Results[1]
+	assert.Equal(t, "ok", noResponse.Status)
+	assert.Equal(t, false, noResponse.Data["responded"])
+	assert.Nil(t, noResponse.Data["firstResponseAt"])
+	assert.Nil(t, noResponse.Data["timeToFirstResponseSeconds"])
+	assert.Equal(t, false, noResponse.Data["truncated"])
+}
+
+func Test_ListDiscussionCategoriesNameContains(t *testing.T) {
+	qCategories := "query($after:String$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussionCategories(first: $first, after: $after){nodes{id,name,emoji,description,isAnswerable,slug},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+	page := func(hasNextPage bool, endCursor string, nodes ...map[string]any) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussionCategories": map[string]any{
+					"nodes": nodes,
+					"pageInfo": map[string]any{
+						"hasNextPage":     hasNextPage,
+						"hasPreviousPage": false,
+						"startCursor":     "",
+						"endCursor":       endCursor,
+					},
+				},
+			},
+		})
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qCategories, map[string]any{"owner": "owner", "repo": "repo", "first": float64(100), "after": (*string)(nil)}, page(true, "cursor-1",
+			map[string]any{"id": "DIC_1", "name": "General", "isAnswerable": false},
+			map[string]any{"id": "DIC_2", "name": "Feature Requests", "isAnswerable": false},
+		)),
+		githubv4mock.NewQueryMatcher(qCategories, map[string]any{"owner": "owner", "repo": "repo", "first": float64(100), "after": "cursor-1"}, page(false, "",
+			map[string]any{"id": "DIC_3", "name": "Bug requests", "isAnswerable": true},
+			map[string]any{"id": "DIC_4", "name": "Q&A", "isAnswerable": true},
+		)),
+		githubv4mock.NewQueryMatcher(qCategories, map[string]any{"owner": "owner", "repo": "repo", "first": float64(2), "after": "cursor-1"}, page(false, "",
+			map[string]any{"id": "DIC_3", "name": "Bug requests", "isAnswerable": true},
+			map[string]any{"id": "DIC_4", "name": "Q&A", "isAnswerable": true},
+		)),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := ListDiscussionCategories(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		expectedIDs []string
+	}{
+		{
+			name:        "matches across pages case-insensitively",
+			args:        map[string]any{"owner": "owner", "repo": "repo", "nameContains": "REQUEST"},
+			expectedIDs: []string{"DIC_2", "DIC_3"},
+		},
+		{
+			name:        "combined with answerableOnly",
+			args:        map[string]any{"owner": "owner", "repo": "repo", "nameContains": "request", "answerableOnly": true},
+			expectedIDs: []string{"DIC_3"},
+		},
+		{
+			name:        "fetchAll follows pageInfo",
+			args:        map[string]any{"owner": "owner", "repo": "repo", "fetchAll": true},
+			expectedIDs: []string{"DIC_1", "DIC_2", "DIC_3", "DIC_4"},
+		},
+		{
+			name:        "after fetches a single later page",
+			args:        map[string]any{"owner": "owner", "repo": "repo", "perPage": float64(2), "after": "cursor-1"},
+			expectedIDs: []string{"DIC_3", "DIC_4"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := createMCPRequest(tc.args)
+			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			require.False(t, res.IsError, getTextResult(t, res).Text)
+
+			var response struct {
+				Categories []map[string]any `json:"categories"`
+				TotalCount int              `json:"totalCount"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+			var ids []string
+			for _, c := range response.Categories {
+				ids = append(ids, c["id"].(string))
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+			if _, ok := tc.args["after"]; !ok {
+				assert.Equal(t, len(tc.expectedIDs), response.TotalCount)
+			}
+		})
+	}
+
+	t.Run("after with fetchAll", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "fetchAll": true, "after": "cursor-1"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getErrorResult(t, res).Text, "after cannot be combined with fetchAll")
+	})
+}
+
+func Test_DeleteDiscussion(t *testing.T) {
+	toolDef := DeleteDiscussion(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_discussion", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "delete_discussion tool should not be read-only")
+	require.NotNil(t, tool.Annotations.DestructiveHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	// Without the deleteDiscussion mutation mocked, any unconfirmed deletion fails the request
+	qDiscussionID := githubv4mock.NewQueryMatcher(
+		"query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){id}}}",
+		map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{"id": "D_1"}},
+		}),
+	)
+	deleteMutation := githubv4mock.NewMutationMatcher(
+		struct {
+			DeleteDiscussion struct {
+				ClientMutationID githubv4.String
+			} `graphql:"deleteDiscussion(input: $input)"`
+		}{},
+		githubv4.DeleteDiscussionInput{ID: githubv4.ID("D_1")},
+		nil,
+		githubv4mock.DataResponse(map[string]any{
+			"deleteDiscussion": map[string]any{"clientMutationId": ""},
+		}),
+	)
+
+	call := func(t *testing.T, deps BaseDeps, args map[string]any) *mcp.CallToolResult {
+		handler := toolDef.Handler(deps)
+		req := createMCPRequest(args)
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("first call issues a token and deletes nothing", func(t *testing.T) {
+		deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(qDiscussionID))}
+		res := call(t, deps, map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1)})
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, false, out["deleted"])
+		assert.Equal(t, discussionDeleteConfirmationToken("owner", "repo", 1), out["confirmationToken"])
+	})
+
+	t.Run("mismatched token", func(t *testing.T) {
+		deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(qDiscussionID))}
+		res := call(t, deps, map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "confirmationToken": discussionDeleteConfirmationToken("owner", "repo", 2)})
+		require.True(t, res.IsError)
+		assert.Contains(t, getTextResult(t, res).Text, "confirmationToken does not match discussion #1 in owner/repo")
+	})
+
+	t.Run("valid token deletes", func(t *testing.T) {
+		deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(qDiscussionID, deleteMutation))}
+		first := call(t, deps, map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1)})
+		require.False(t, first.IsError, getTextResult(t, first).Text)
+		var issued map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, first).Text), &issued))
+
+		res := call(t, deps, map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "confirmationToken": issued["confirmationToken"]})
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, true, out["deleted"])
+		assert.Equal(t, float64(1), out["discussionNumber"])
+	})
+}
+
+func Test_ListDiscussionsAnswerLatency(t *testing.T) {
+	qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	answered := map[string]any{
+		"number":         5,
+		"title":          "Answered question",
+		"createdAt":      "2023-01-01T00:00:00Z",
+		"updatedAt":      "2023-01-03T00:00:00Z",
+		"closed":         false,
+		"isAnswered":     true,
+		"answerChosenAt": "2023-01-02T10:00:00Z",
+		"author":         map[string]any{"login": "user1"},
+		"url":            "https://github.com/owner/repo/discussions/5",
+		"category":       map[string]any{"name": "Q&A"},
+	}
+	unanswered := map[string]any{
+		"number":     6,
+		"title":      "Open question",
+		"createdAt":  "2023-01-01T00:00:00Z",
+		"updatedAt":  "2023-01-03T00:00:00Z",
+		"closed":     false,
+		"isAnswered": false,
+		"author":     map[string]any{"login": "user1"},
+		"url":        "https://github.com/owner/repo/discussions/6",
+		"category":   map[string]any{"name": "Q&A"},
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qBasicNoOrder,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(30), "after": (*string)(nil), "includeBody": false},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes": []map[string]any{answered, unanswered},
+						"pageInfo": map[string]any{
+							"hasNextPage":     false,
+							"hasPreviousPage": false,
+							"startCursor":     "",
+							"endCursor":       "",
+						},
+						"totalCount": 2,
+					},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := ListDiscussions(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	list := func(t *testing.T, args map[string]any) []map[string]any {
+		req := createMCPRequest(args)
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+		var response struct {
+			Discussions []map[string]any `json:"discussions"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		require.Len(t, response.Discussions, 2)
+		return response.Discussions
+	}
+
+	t.Run("computed for answered discussions", func(t *testing.T) {
+		discussions := list(t, map[string]any{"owner": "owner", "repo": "repo", "includeAnswerLatency": true})
+		// 1 day and 10 hours
+		assert.Equal(t, float64(122400), discussions[0]["answerLatencySeconds"])
+		require.Contains(t, discussions[1], "answerLatencySeconds")
+		assert.Nil(t, discussions[1]["answerLatencySeconds"])
+	})
+
+	t.Run("omitted by default", func(t *testing.T) {
+		discussions := list(t, map[string]any{"owner": "owner", "repo": "repo"})
+		assert.NotContains(t, discussions[0], "answerLatencySeconds")
+		assert.NotContains(t, discussions[1], "answerLatencySeconds")
+	})
+}
+
+func Test_CloseDiscussion(t *testing.T) {
+	toolDef := CloseDiscussion(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "close_discussion", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "close_discussion tool should not be read-only")
+
+	qDiscussionID := githubv4mock.NewQueryMatcher(
+		"query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){id}}}",
+		map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{"id": "D_1"}},
+		}),
+	)
+	closeMutation := func(reason githubv4.DiscussionCloseReason) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				CloseDiscussion struct {
+					Discussion struct {
+						Number githubv4.Int
+						URL    githubv4.String `graphql:"url"`
+						Closed githubv4.Boolean
+					}
+				} `graphql:"closeDiscussion(input: $input)"`
+			}{},
+			githubv4.CloseDiscussionInput{
+				DiscussionID: githubv4.ID("D_1"),
+				Reason:       &reason,
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"closeDiscussion": map[string]any{
+					"discussion": map[string]any{
+						"number": 1,
+						"url":    "https://github.com/owner/repo/discussions/1",
+						"closed": true,
+					},
+				},
+			}),
+		)
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		qDiscussionID,
+		closeMutation(githubv4.DiscussionCloseReasonResolved),
+		closeMutation(githubv4.DiscussionCloseReasonDuplicate),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		expectedReason string
+		expectError    string
+	}{
+		{
+			name:           "defaults to RESOLVED",
+			args:           map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1)},
+			expectedReason: "RESOLVED",
+		},
+		{
+			name:           "with a reason",
+			args:           map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "reason": "DUPLICATE"},
+			expectedReason: "DUPLICATE",
+		},
+		{
+			name:        "invalid reason",
+			args:        map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "reason": "SPAM"},
+			expectError: `invalid reason "SPAM": must be one of RESOLVED, OUTDATED, DUPLICATE`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := createMCPRequest(tc.args)
+			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			if tc.expectError != "" {
+				require.True(t, res.IsError)
+				assert.Equal(t, tc.expectError, getErrorResult(t, res).Text)
+				return
+			}
+			require.False(t, res.IsError, getTextResult(t, res).Text)
+
+			var out map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+			assert.Equal(t, float64(1), out["number"])
+			assert.Equal(t, "https://github.com/owner/repo/discussions/1", out["url"])
+			assert.Equal(t, true, out["closed"])
+			assert.Equal(t, tc.expectedReason, out["reason"])
+		})
+	}
+}
+
+func Test_LockDiscussion(t *testing.T) {
+	toolDef := LockDiscussion(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "lock_discussion", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "lock_discussion tool should not be read-only")
+
+	qDiscussionID := githubv4mock.NewQueryMatcher(
+		"query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){id}}}",
+		map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{"id": "D_1"}},
+		}),
+	)
+	lockMutation := func(reason *githubv4.LockReason) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				LockLockable struct {
+					LockedRecord struct {
+						Locked githubv4.Boolean
+					}
+				} `graphql:"lockLockable(input: $input)"`
+			}{},
+			githubv4.LockLockableInput{
+				LockableID: githubv4.ID("D_1"),
+				LockReason: reason,
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"lockLockable": map[string]any{"lockedRecord": map[string]any{"locked": true}},
+			}),
+		)
+	}
+	tooHeated := githubv4.LockReasonTooHeated
+	mockClient := githubv4mock.NewMockedHTTPClient(qDiscussionID, lockMutation(nil), lockMutation(&tooHeated))
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		expectError string
+	}{
+		{
+			name: "without a reason",
+			args: map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1)},
+		},
+		{
+			name: "with a reason",
+			args: map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "lockReason": "TOO_HEATED"},
+		},
+		{
+			name:        "invalid reason",
+			args:        map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "lockReason": "DUPLICATE"},
+			expectError: `invalid lockReason "DUPLICATE": must be one of OFF_TOPIC, TOO_HEATED, RESOLVED, SPAM`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := createMCPRequest(tc.args)
+			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			if tc.expectError != "" {
+				require.True(t, res.IsError)
+				assert.Equal(t, tc.expectError, getErrorResult(t, res).Text)
+				return
+			}
+			require.False(t, res.IsError, getTextResult(t, res).Text)
+
+			var out map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+			assert.Equal(t, float64(1), out["number"])
+			assert.Equal(t, true, out["locked"])
+		})
+	}
+}
+
+func Test_UnlockDiscussion(t *testing.T) {
+	toolDef := UnlockDiscussion(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "unlock_discussion", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "unlock_discussion tool should not be read-only")
+
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			"query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){id}}}",
+			map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"discussion": map[string]any{"id": "D_1"}},
+			}),
+		),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				UnlockLockable struct {
+					UnlockedRecord struct {
+						Locked githubv4.Boolean
+					}
+				} `graphql:"unlockLockable(input: $input)"`
+			}{},
+			githubv4.UnlockLockableInput{LockableID: githubv4.ID("D_1")},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"unlockLockable": map[string]any{"unlockedRecord": map[string]any{"locked": false}},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1)})
+	res, err := toolDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	assert.Equal(t, float64(1), out["number"])
+	assert.Equal(t, false, out["locked"])
+}
+
+func Test_ListUncategorizedDiscussions(t *testing.T) {
+	toolDef := ListUncategorizedDiscussions(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_uncategorized_discussions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "list_uncategorized_discussions tool should be read-only")
+
+	qDiscussions := "query($after:String$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after, orderBy: {field: UPDATED_AT, direction: DESC}){nodes{number,title,url,category{id}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor}}}}"
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qDiscussions,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(100), "after": (*string)(nil)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes": []map[string]any{
+							{"number": 1, "title": "Categorized", "url": "https://github.com/owner/repo/discussions/1", "category": map[string]any{"id": "DIC_1"}},
+							{"number": 2, "title": "Imported", "url": "https://github.com/owner/repo/discussions/2", "category": nil},
+						},
+						"pageInfo": map[string]any{
+							"hasNextPage":     false,
+							"hasPreviousPage": false,
+							"startCursor":     "",
+							"endCursor":       "",
+						},
+					},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var out struct {
+		Discussions []map[string]any `json:"discussions"`
+		Scanned     int              `json:"scanned"`
+		Truncated   bool             `json:"truncated"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	require.Len(t, out.Discussions, 1)
+	assert.Equal(t, float64(2), out.Discussions[0]["number"])
+	assert.Equal(t, "Imported", out.Discussions[0]["title"])
+	assert.Equal(t, 2, out.Scanned)
+	assert.False(t, out.Truncated)
+}
+
+func Test_CountDiscussions(t *testing.T) {
+	toolDef := CountDiscussions(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "count_discussions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "count_discussions tool should be read-only")
+
+	qRange := "query($owner:String!$repo:String!){repository(owner: $owner, name: $repo){oldest: discussions(first: 1, orderBy: {field: CREATED_AT, direction: ASC}){nodes{number}},newest: discussions(first: 1, orderBy: {field: CREATED_AT, direction: DESC}){nodes{number}}}}"
+	qCount := "query($categoryId:ID$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: 1, categoryId: $categoryId){totalCount}}}"
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qCount,
+			map[string]any{"owner": "owner", "repo": "repo", "categoryId": (*string)(nil)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"discussions": map[string]any{"totalCount": 1500}},
+			}),
+		),
+		githubv4mock.NewQueryMatcher(qRange,
+			map[string]any{"owner": "owner", "repo": "repo"},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"oldest": map[string]any{"nodes": []map[string]any{{"number": 3}}},
+					"newest": map[string]any{"nodes": []map[string]any{{"number": 1840}}},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	t.Run("count only", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, map[string]any{"count": float64(1500)}, out, "the count isn't limited to scanned discussions")
+	})
+
+	t.Run("with number range", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "includeNumberRange": true})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, float64(1500), out["count"])
+		assert.Equal(t, float64(3), out["minNumber"])
+		assert.Equal(t, float64(1840), out["maxNumber"])
+	})
+
+	t.Run("no discussions", func(t *testing.T) {
+		emptyClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(qCount,
+				map[string]any{"owner": "owner", "repo": "repo", "categoryId": (*string)(nil)},
+				githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{"discussions": map[string]any{"totalCount": 0}},
+				}),
+			),
+			githubv4mock.NewQueryMatcher(qRange,
+				map[string]any{"owner": "owner", "repo": "repo"},
+				githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"oldest": map[string]any{"nodes": []map[string]any{}},
+						"newest": map[string]any{"nodes": []map[string]any{}},
+					},
+				}),
+			),
+		)
+		emptyDeps := BaseDeps{GQLClient: githubv4.NewClient(emptyClient)}
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "includeNumberRange": true})
+		res, err := toolDef.Handler(emptyDeps)(ContextWithDeps(context.Background(), emptyDeps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, map[string]any{"count": float64(0), "minNumber": nil, "maxNumber": nil}, out)
+	})
+}
+
+func Test_MarkDiscussionCommentAsAnswer(t *testing.T) {
+	toolDef := MarkDiscussionCommentAsAnswer(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "mark_discussion_comment_as_answer", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "mark_discussion_comment_as_answer tool should not be read-only")
+
+	mutation := struct {
+		MarkDiscussionCommentAsAnswer struct {
+			Discussion struct {
+				Number         githubv4.Int
+				AnswerChosenAt *githubv4.DateTime
+			}
+		} `graphql:"markDiscussionCommentAsAnswer(input: $input)"`
+	}{}
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewMutationMatcher(mutation,
+			githubv4.MarkDiscussionCommentAsAnswerInput{ID: githubv4.ID("DC_1")},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"markDiscussionCommentAsAnswer": map[string]any{
+					"discussion": map[string]any{
+						"number":         3,
+						"answerChosenAt": "2025-05-01T10:00:00Z",
+					},
+				},
+			}),
+		),
+		githubv4mock.NewMutationMatcher(mutation,
+			githubv4.MarkDiscussionCommentAsAnswerInput{ID: githubv4.ID("DC_2")},
+			nil,
+			githubv4mock.ErrorResponse("Discussion is not in an answerable category"),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+	handler := toolDef.Handler(deps)
+
+	t.Run("marks the answer", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"comment_id": "DC_1"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, float64(3), out["discussionNumber"])
+		assert.Equal(t, "2025-05-01T10:00:00Z", out["answerChosenAt"])
+	})
+
+	t.Run("not a Q&A category", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"comment_id": "DC_2"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getErrorResult(t, res).Text, "only discussions in a Q&A category can have an answer")
+	})
+}
+
+func Test_UnmarkDiscussionCommentAsAnswer(t *testing.T) {
+	toolDef := UnmarkDiscussionCommentAsAnswer(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "unmark_discussion_comment_as_answer", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "unmark_discussion_comment_as_answer tool should not be read-only")
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewMutationMatcher(
+			struct {
+				UnmarkDiscussionCommentAsAnswer struct {
+					Discussion struct {
+						Number     githubv4.Int
+						IsAnswered githubv4.Boolean
+					}
+				} `graphql:"unmarkDiscussionCommentAsAnswer(input: $input)"`
+			}{},
+			githubv4.UnmarkDiscussionCommentAsAnswerInput{ID: githubv4.ID("DC_1")},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"unmarkDiscussionCommentAsAnswer": map[string]any{
+					"discussion": map[string]any{
+						"number":     3,
+						"isAnswered": false,
+					},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{"comment_id": "DC_1"})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	assert.Equal(t, float64(3), out["discussionNumber"])
+	assert.Equal(t, false, out["isAnswered"])
+}
+
+func Test_MarkDiscussionAnswerByText(t *testing.T) {
+	toolDef := MarkDiscussionAnswerByText(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "mark_discussion_answer_by_text", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "mark_discussion_answer_by_text tool should not be read-only")
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "discussionNumber", "bodyMatch"})
+
+	qComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{id,body,url},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor}}}}}"
+	newMockClient := func() *http.Client {
+		return githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(qComments,
+				map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1), "first": float64(100), "after": (*string)(nil)},
+				githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"discussion": map[string]any{
+							"comments": map[string]any{
+								"nodes": []map[string]any{
+									{"id": "DC_1", "body": "Have you tried restarting it?", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-1"},
+									{"id": "DC_2", "body": "Set GOFLAGS=-mod=mod and rebuild.", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-2"},
+									{"id": "DC_3", "body": "Rebuild fixed it for me too.", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-3"},
+								},
+								"pageInfo": map[string]any{
+									"hasNextPage":     false,
+									"hasPreviousPage": false,
+									"startCursor":     "",
+									"endCursor":       "",
+								},
+							},
+						},
+					},
+				}),
+			),
+			githubv4mock.NewMutationMatcher(
+				struct {
+					MarkDiscussionCommentAsAnswer struct {
+						Discussion struct {
+							Number         githubv4.Int
+							AnswerChosenAt *githubv4.DateTime
+						}
+					} `graphql:"markDiscussionCommentAsAnswer(input: $input)"`
+				}{},
+				githubv4.MarkDiscussionCommentAsAnswerInput{ID: githubv4.ID("DC_2")},
+				nil,
+				githubv4mock.DataResponse(map[string]any{
+					"markDiscussionCommentAsAnswer": map[string]any{
+						"discussion": map[string]any{
+							"number":         1,
+							"answerChosenAt": "2025-05-01T10:00:00Z",
+						},
+					},
+				}),
+			),
+		)
+	}
+
+	tests := []struct {
+		name        string
+		bodyMatch   string
+		expectError string
+	}{
+		{
+			name:      "unique match",
+			bodyMatch: "GOFLAGS",
+		},
+		{
+			name:      "case-insensitive match",
+			bodyMatch: "goflags",
+		},
+		{
+			name:        "no match",
+			bodyMatch:   "reinstall",
+			expectError: `no comment on discussion #1 in owner/repo contains "reinstall"`,
+		},
+		{
+			name:        "ambiguous match",
+			bodyMatch:   "ebuild",
+			expectError: `2 comments on discussion #1 in owner/repo contain "ebuild"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{GQLClient: githubv4.NewClient(newMockClient())}
+			handler := toolDef.Handler(deps)
+
+			req := createMCPRequest(map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"discussionNumber": int32(1),
+				"bodyMatch":        tc.bodyMatch,
+			})
+			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+
+			if tc.expectError != "" {
+				require.True(t, res.IsError)
+				assert.Contains(t, getErrorResult(t, res).Text, tc.expectError)
+				return
+			}
+
+			require.False(t, res.IsError, getTextResult(t, res).Text)
+			var out map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+			assert.Equal(t, "DC_2", out["commentId"])
+			assert.Equal(t, float64(1), out["discussionNumber"])
+			assert.Equal(t, "2025-05-01T10:00:00Z", out["answerChosenAt"])
+		})
+	}
+
+	t.Run("refuses to mark when not every comment was checked", func(t *testing.T) {
+		// Every page has a next one, so the scan stops at its page limit
+		page := githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"discussion": map[string]any{"comments": map[string]any{
+				"nodes": []map[string]any{
+					{"id": "DC_2", "body": "Set GOFLAGS=-mod=mod and rebuild.", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-2"},
+				},
+				"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "next"},
+			}}},
+		})
+		mockClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(qComments,
+				map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1), "first": float64(100), "after": (*string)(nil)},
+				page,
+			),
+			githubv4mock.NewQueryMatcher(qComments,
+				map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1), "first": float64(100), "after": "next"},
+				page,
+			),
+		)
+		deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "bodyMatch": "GOFLAGS"})
+		res, err := toolDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getErrorResult(t, res).Text, "comments after the first 1000 weren't checked")
+	})
+}
+
+func Test_ListDiscussionCategoriesSlug(t *testing.T) {
+	toolDef := ListDiscussionCategories(translations.NullTranslationHelper)
+	qWithSlug := "query($after:String$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussionCategories(first: $first, after: $after){nodes{id,name,emoji,description,isAnswerable,slug},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+	qWithoutSlug := "query($after:String$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussionCategories(first: $first, after: $after){nodes{id,name,emoji,description,isAnswerable},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+	vars := map[string]any{"owner": "owner", "repo": "repo", "first": float64(25), "after": (*string)(nil)}
+	page := func(nodes ...map[string]any) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussionCategories": map[string]any{
+					"nodes": nodes,
+					"pageInfo": map[string]any{
+						"hasNextPage":     false,
+						"hasPreviousPage": false,
+						"startCursor":     "",
+						"endCursor":       "",
+					},
+					"totalCount": len(nodes),
+				},
+			},
+		})
+	}
+
+	tests := []struct {
+		name               string
+		matchers           []githubv4mock.Matcher
+		expectedCategories []map[string]any
+	}{
+		{
+			name: "slug included",
+			matchers: []githubv4mock.Matcher{
+				githubv4mock.NewQueryMatcher(qWithSlug, vars, page(
+					map[string]any{"id": "DIC_1", "name": "Ideas", "isAnswerable": false, "slug": "ideas"},
+					map[string]any{"id": "DIC_2", "name": "Q&A", "isAnswerable": true, "slug": "q-a"},
+				)),
+			},
+			expectedCategories: []map[string]any{
+				{"id": "DIC_1", "name": "Ideas", "emoji": "", "description": "", "isAnswerable": false, "slug": "ideas"},
+				{"id": "DIC_2", "name": "Q&A", "emoji": "", "description": "", "isAnswerable": true, "slug": "q-a"},
+			},
+		},
+		{
+			name: "slug unsupported",
+			matchers: []githubv4mock.Matcher{
+				githubv4mock.NewQueryMatcher(qWithSlug, vars, githubv4mock.ErrorResponse("Field 'slug' doesn't exist on type 'DiscussionCategory'")),
+				githubv4mock.NewQueryMatcher(qWithoutSlug, vars, page(
+					map[string]any{"id": "DIC_1", "name": "Ideas", "isAnswerable": false},
+				)),
+			},
+			expectedCategories: []map[string]any{
+				{"id": "DIC_1", "name": "Ideas", "emoji": "", "description": "", "isAnswerable": false},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matchers...))}
+			handler := toolDef.Handler(deps)
+
+			req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"})
+			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			require.False(t, res.IsError, getTextResult(t, res).Text)
+
+			var response struct {
+				Categories []map[string]any `json:"categories"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+			assert.Equal(t, tc.expectedCategories, response.Categories)
+		})
+	}
+}
+
+func Test_ListDiscussionsEngagementCounts(t *testing.T) {
+	qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	node := func(number, reactions, comments int) map[string]any {
+		return map[string]any{
+			"number":     number,
+			"title":      fmt.Sprintf("Discussion %d", number),
+			"createdAt":  "2023-01-01T00:00:00Z",
+			"updatedAt":  "2023-01-02T00:00:00Z",
+			"closed":     false,
+			"isAnswered": false,
+			"author":     map[string]any{"login": "user1"},
+			"url":        fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+			"category":   map[string]any{"name": "Ideas"},
+			"reactions":  map[string]any{"totalCount": reactions},
+			"comments":   map[string]any{"totalCount": comments},
+		}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qBasicNoOrder,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(30), "after": (*string)(nil), "includeBody": false},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes": []map[string]any{node(1, 12, 3), node(2, 0, 0)},
+						"pageInfo": map[string]any{
+							"hasNextPage":     false,
+							"hasPreviousPage": false,
+							"startCursor":     "",
+							"endCursor":       "",
+						},
+						"totalCount": 2,
+					},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := ListDiscussions(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var response struct {
+		Discussions []map[string]any `json:"discussions"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+	require.Len(t, response.Discussions, 2)
+	assert.Equal(t, float64(12), response.Discussions[0]["reactionCount"])
+	assert.Equal(t, float64(0), response.Discussions[1]["reactionCount"])
+	assert.Equal(t, float64(3), response.Discussions[0]["commentCount"])
+	assert.Equal(t, float64(0), response.Discussions[1]["commentCount"])
+}
+
+func Test_DiscussionsDefaultPageSize(t *testing.T) {
+	qListDiscussions := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$includeReplies:Boolean!$includeReplyContext:Boolean!$owner:String!$replyCount:Int!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{id,body,url,isAnswer,author{login},createdAt,updatedAt,isMinimized,minimizedReason,reactionGroups{content,users{totalCount}},replyTo @include(if: $includeReplyContext){id,author{login}},replies(first: $replyCount) @include(if: $includeReplies){nodes{id,body,url},pageInfo{hasNextPage}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},answerChosenAt,answerChosenBy{login}}}}"
+	emptyPage := map[string]any{
+		"nodes":      []map[string]any{},
+		"pageInfo":   map[string]any{"hasNextPage": false, "hasPreviousPage": false, "startCursor": "", "endCursor": ""},
+		"totalCount": 0,
+	}
+
+	for _, tc := range []struct {
+		name            string
+		defaultPageSize int
+		perPage         any
+		expectedFirst   float64
+	}{
+		{name: "unset falls back to 30", expectedFirst: 30},
+		{name: "configured default", defaultPageSize: 50, expectedFirst: 50},
+		{name: "configured default is capped at 100", defaultPageSize: 500, expectedFirst: 100},
+		{name: "perPage overrides the configured default", defaultPageSize: 50, perPage: float64(10), expectedFirst: 10},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(qListDiscussions,
+					map[string]any{"owner": "owner", "repo": "repo", "first": tc.expectedFirst, "after": (*string)(nil), "includeBody": false},
+					githubv4mock.DataResponse(map[string]any{"repository": map[string]any{"discussions": emptyPage}}),
+				),
+				githubv4mock.NewQueryMatcher(qGetComments,
+					map[string]any{
+						"owner":               "owner",
+						"repo":                "repo",
+						"discussionNumber":    float64(1),
+						"first":               tc.expectedFirst,
+						"after":               (*string)(nil),
+						"includeReplyContext": false,
+						"includeReplies":      false,
+						"replyCount":          float64(5),
+					},
+					githubv4mock.DataResponse(map[string]any{"repository": map[string]any{"discussion": map[string]any{"comments": emptyPage}}}),
+				),
+			)
+			deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient), DefaultPageSize: tc.defaultPageSize}
+			listDiscussions := ListDiscussions(translations.NullTranslationHelper)
+			getComments := GetDiscussionComments(translations.NullTranslationHelper)
+
+			listArgs := map[string]any{"owner": "owner", "repo": "repo"}
+			commentArgs := map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1)}
+			if tc.perPage != nil {
+				listArgs["perPage"] = tc.perPage
+				commentArgs["perPage"] = tc.perPage
+			}
+
+			req := createMCPRequest(listArgs)
+			res, err := listDiscussions.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			require.False(t, res.IsError, getTextResult(t, res).Text)
+
+			req = createMCPRequest(commentArgs)
+			res, err = getComments.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			require.False(t, res.IsError, getTextResult(t, res).Text)
+		})
+	}
+
+	t.Run("perPage above 100 is rejected", func(t *testing.T) {
+		deps := BaseDeps{}
+		for _, toolDef := range []inventory.ServerTool{ListDiscussions(translations.NullTranslationHelper), GetDiscussionComments(translations.NullTranslationHelper)} {
+			req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "perPage": float64(500)})
+			res, err := toolDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			require.True(t, res.IsError, toolDef.Tool.Name)
+			assert.Equal(t, "perPage must be between 1 and 100", getErrorResult(t, res).Text, toolDef.Tool.Name)
+		}
+	})
+}
+
+func Test_ListDiscussionsBackward(t *testing.T) {
+	nodes := "nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	qBackward := "query($before:String$includeBody:Boolean!$last:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(last: $last, before: $before){" + nodes
+	qBackwardWithCategory := "query($before:String$categoryId:ID!$includeBody:Boolean!$last:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(last: $last, before: $before, categoryId: $categoryId){" + nodes
+	page := func(startCursor string, hasPreviousPage bool, numbers ...int) githubv4mock.GQLResponse {
+		var ns []map[string]any
+		for _, n := range numbers {
+			ns = append(ns, map[string]any{
+				"number":    n,
+				"title":     fmt.Sprintf("Discussion %d", n),
+				"createdAt": "2023-01-01T00:00:00Z",
+				"updatedAt": "2023-01-02T00:00:00Z",
+				"author":    map[string]any{"login": "user1"},
+				"url":       fmt.Sprintf("https://github.com/owner/repo/discussions/%d", n),
+			})
+		}
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussions": map[string]any{
+					"nodes": ns,
+					"pageInfo": map[string]any{
+						"hasNextPage":     false,
+						"hasPreviousPage": hasPreviousPage,
+						"startCursor":     startCursor,
+						"endCursor":       "",
+					},
+					"totalCount": 5,
+				},
+			},
+		})
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qBackward,
+			map[string]any{"owner": "owner", "repo": "repo", "last": float64(2), "before": (*string)(nil), "includeBody": false},
+			page("cursor-4", true, 4, 5),
+		),
+		githubv4mock.NewQueryMatcher(qBackward,
+			map[string]any{"owner": "owner", "repo": "repo", "last": float64(30), "before": "cursor-4", "includeBody": false},
+			page("cursor-1", false, 1, 2, 3),
+		),
+		githubv4mock.NewQueryMatcher(qBackwardWithCategory,
+			map[string]any{"owner": "owner", "repo": "repo", "categoryId": "DIC_1", "last": float64(1), "before": (*string)(nil), "includeBody": false},
+			page("cursor-5", true, 5),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := ListDiscussions(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	tests := []struct {
+		name                string
+		args                map[string]any
+		expectedNumbers     []int
+		expectedStartCursor string
+		expectedHasPrevious bool
+	}{
+		{
+			name:                "last returns the tail",
+			args:                map[string]any{"owner": "owner", "repo": "repo", "last": float64(2)},
+			expectedNumbers:     []int{4, 5},
+			expectedStartCursor: "cursor-4",
+			expectedHasPrevious: true,
+		},
+		{
+			name:                "before pages backward by perPage",
+			args:                map[string]any{"owner": "owner", "repo": "repo", "before": "cursor-4"},
+			expectedNumbers:     []int{1, 2, 3},
+			expectedStartCursor: "cursor-1",
+		},
+		{
+			name:                "last with category",
+			args:                map[string]any{"owner": "owner", "repo": "repo", "category": "DIC_1", "last": float64(1)},
+			expectedNumbers:     []int{5},
+			expectedStartCursor: "cursor-5",
+			expectedHasPrevious: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := createMCPRequest(tc.args)
+			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			require.False(t, res.IsError, getTextResult(t, res).Text)
+
+			var response struct {
+				Discussions []struct {
+					Number int `json:"number"`
+				} `json:"discussions"`
+				PageInfo struct {
+					HasPreviousPage bool   `json:"hasPreviousPage"`
+					StartCursor     string `json:"startCursor"`
+				} `json:"pageInfo"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+			var numbers []int
+			for _, d := range response.Discussions {
+				numbers = append(numbers, d.Number)
+			}
+			assert.Equal(t, tc.expectedNumbers, numbers)
+			assert.Equal(t, tc.expectedStartCursor, response.PageInfo.StartCursor)
+			assert.Equal(t, tc.expectedHasPrevious, response.PageInfo.HasPreviousPage)
+		})
+	}
+
+	for _, tc := range []struct {
+		name        string
+		args        map[string]any
+		expectError string
+	}{
+		{
+			name:        "after with before",
+			args:        map[string]any{"owner": "owner", "repo": "repo", "after": "cursor-1", "before": "cursor-4"},
+			expectError: "after cannot be combined with last or before",
+		},
+		{
+			name:        "fetchAll with last",
+			args:        map[string]any{"owner": "owner", "repo": "repo", "fetchAll": true, "last": float64(2)},
+			expectError: "fetchAll pages forward from the start and cannot be combined with last or before",
+		},
+		{
+			name:        "page tokens with before",
+			args:        map[string]any{"owner": "owner", "repo": "repo", "usePageTokens": true, "before": "cursor-4"},
+			expectError: "page tokens only page forward and cannot be combined with last or before",
+		},
+		{
+			name:        "pageToken with last",
+			args:        map[string]any{"owner": "owner", "repo": "repo", "pageToken": encodePageToken("cursor-1"), "last": float64(2)},
+			expectError: "page tokens only page forward and cannot be combined with last or before",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := createMCPRequest(tc.args)
+			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			require.True(t, res.IsError)
+			assert.Equal(t, tc.expectError, getErrorResult(t, res).Text)
+		})
+	}
+}
+
+func Test_DiscussionLabels(t *testing.T) {
+	qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	qGetDiscussion := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,body,createdAt,author{login},closed,closedAt,isAnswered,answerChosenAt,url,category{name},upvoteCount,viewerHasUpvoted,labels(first: 10){nodes{name,color}},comments{totalCount},reactionGroups{content,users{totalCount},viewerHasReacted}}}}"
+	labels := func(names ...string) map[string]any {
+		nodes := []map[string]any{}
+		for _, name := range names {
+			nodes = append(nodes, map[string]any{"name": name, "color": "d73a4a"})
+		}
+		return map[string]any{"nodes": nodes}
+	}
+	node := func(number int, labelNames ...string) map[string]any {
+		return map[string]any{
+			"number":    number,
+			"title":     fmt.Sprintf("Discussion %d", number),
+			"createdAt": "2023-01-01T00:00:00Z",
+			"updatedAt": "2023-01-02T00:00:00Z",
+			"author":    map[string]any{"login": "user1"},
+			"url":       fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+			"labels":    labels(labelNames...),
+		}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qBasicNoOrder,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(30), "after": (*string)(nil), "includeBody": false},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes": []map[string]any{node(1, "bug", "triage"), node(2, "bug"), node(3)},
+						"pageInfo": map[string]any{
+							"hasNextPage":     false,
+							"hasPreviousPage": false,
+							"startCursor":     "",
+							"endCursor":       "",
+						},
+						"totalCount": 3,
+					},
+				},
+			}),
+		),
+		githubv4mock.NewQueryMatcher(qGetDiscussion,
+			map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"discussion": map[string]any{
+					"number":    1,
+					"title":     "Discussion 1",
+					"url":       "https://github.com/owner/repo/discussions/1",
+					"createdAt": "2023-01-01T00:00:00Z",
+					"labels":    labels("bug"),
+				}},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	listDef := ListDiscussions(translations.NullTranslationHelper)
+	getDef := GetDiscussion(translations.NullTranslationHelper)
+
+	listNumbers := func(t *testing.T, args map[string]any) ([]int, map[string]any) {
+		req := createMCPRequest(args)
+		res, err := listDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		numbers := []int{}
+		for _, d := range response["discussions"].([]any) {
+			numbers = append(numbers, int(d.(map[string]any)["number"].(float64)))
+		}
+		return numbers, response
+	}
+
+	t.Run("list includes labels", func(t *testing.T) {
+		numbers, response := listNumbers(t, map[string]any{"owner": "owner", "repo": "repo"})
+		assert.Equal(t, []int{1, 2, 3}, numbers)
+		discussions := response["discussions"].([]any)
+		assert.Equal(t, []any{
+			map[string]any{"name": "bug", "color": "d73a4a"},
+			map[string]any{"name": "triage", "color": "d73a4a"},
+		}, discussions[0].(map[string]any)["labels"])
+		assert.Equal(t, []any{}, discussions[2].(map[string]any)["labels"])
+		assert.NotContains(t, response, "filteredCount")
+	})
+
+	t.Run("list filters by all labels", func(t *testing.T) {
+		numbers, response := listNumbers(t, map[string]any{"owner": "owner", "repo": "repo", "labels": []any{"BUG", "triage"}})
+		assert.Equal(t, []int{1}, numbers)
+		assert.Equal(t, float64(1), response["filteredCount"])
+		assert.Equal(t, float64(3), response["totalCount"])
+	})
+
+	t.Run("get includes labels", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": 1})
+		res, err := getDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		assert.Equal(t, []any{map[string]any{"name": "bug", "color": "d73a4a"}}, response["labels"])
+	})
+}
+
+func Test_ListDiscussionsIncludePinned(t *testing.T) {
+	qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	qPinned := "query($first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){pinnedDiscussions(first: $first){nodes{discussion{number}}}}}"
+	node := func(number int) map[string]any {
+		return map[string]any{
+			"number":    number,
+			"title":     fmt.Sprintf("Discussion %d", number),
+			"createdAt": "2023-01-01T00:00:00Z",
+			"updatedAt": "2023-01-02T00:00:00Z",
+			"author":    map[string]any{"login": "user1"},
+			"url":       fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+		}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qBasicNoOrder,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(30), "after": (*string)(nil), "includeBody": false},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes": []map[string]any{node(1), node(2)},
+						"pageInfo": map[string]any{
+							"hasNextPage":     false,
+							"hasPreviousPage": false,
+							"startCursor":     "",
+							"endCursor":       "",
+						},
+						"totalCount": 2,
+					},
+				},
+			}),
+		),
+		githubv4mock.NewQueryMatcher(qPinned,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(maxPinnedDiscussions)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"pinnedDiscussions": map[string]any{
+						"nodes": []map[string]any{
+							{"discussion": map[string]any{"number": 2}},
+							{"discussion": map[string]any{"number": 7}},
+						},
+					},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := ListDiscussions(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	list := func(t *testing.T, args map[string]any) []map[string]any {
+		req := createMCPRequest(args)
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var response struct {
+			Discussions []map[string]any `json:"discussions"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		require.Len(t, response.Discussions, 2)
+		return response.Discussions
+	}
+
+	t.Run("flags pinned discussions", func(t *testing.T) {
+		discussions := list(t, map[string]any{"owner": "owner", "repo": "repo", "includePinned": true})
+		assert.Equal(t, false, discussions[0]["isPinned"])
+		assert.Equal(t, true, discussions[1]["isPinned"])
+	})
+
+	t.Run("omitted unless requested", func(t *testing.T) {
+		discussions := list(t, map[string]any{"owner": "owner", "repo": "repo"})
+		assert.NotContains(t, discussions[0], "isPinned")
+	})
+}
+
+func Test_RemoveDiscussionLabelBulk(t *testing.T) {
+	toolDef := RemoveDiscussionLabelBulk(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_discussion_label_bulk", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint, "remove_discussion_label_bulk tool should not be read-only")
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "discussionNumbers", "label"})
+
+	qDiscussionLabels := struct {
+		Repository struct {
+			Discussion struct {
+				ID     githubv4.ID
+				Labels struct {
+					Nodes []struct {
+						Name githubv4.String
+					}
+				} `graphql:"labels(first: 100)"`
+			} `graphql:"discussion(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	qLabel := struct {
+		Repository struct {
+			Label struct {
+				ID   githubv4.ID
+				Name githubv4.String
+			} `graphql:"label(name: $name)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	mRemoveLabels := struct {
+		RemoveLabelsFromLabelable struct {
+			ClientMutationID githubv4.String
+		} `graphql:"removeLabelsFromLabelable(input: $input)"`
+	}{}
+
+	discussionLabels := func(number int, labels ...string) githubv4mock.Matcher {
+		nodes := []map[string]any{}
+		for _, l := range labels {
+			nodes = append(nodes, map[string]any{"name": l})
+		}
+		return githubv4mock.NewQueryMatcher(
+			qDiscussionLabels,
+			map[string]any{
+				"owner":            githubv4.String("owner"),
+				"repo":             githubv4.String("repo"),
+				"discussionNumber": githubv4.Int(number),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussion": map[string]any{
+						"id":     fmt.Sprintf("DISC_%d", number),
+						"labels": map[string]any{"nodes": nodes},
+					},
+				},
+			}),
+		)
+	}
+	removeLabel := func(discussionID string) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			mRemoveLabels,
+			githubv4.RemoveLabelsFromLabelableInput{
+				LabelableID: githubv4.ID(discussionID),
+				LabelIDs:    []githubv4.ID{githubv4.ID("LA_TRIAGE")},
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"removeLabelsFromLabelable": map[string]any{"clientMutationId": "mut"},
+			}),
+		)
+	}
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			qLabel,
+			map[string]any{
+				"owner": githubv4.String("owner"),
+				"repo":  githubv4.String("repo"),
+				"name":  githubv4.String("triage"),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"label": map[string]any{
+						"id":   "LA_TRIAGE",
+						"name": "triage",
+					},
+				},
+			}),
+		),
+		discussionLabels(1, "triage", "bug"),
+		discussionLabels(2, "bug"),
+		discussionLabels(3, "Triage"),
+		removeLabel("DISC_1"),
+		removeLabel("DISC_3"),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{
+		"owner":             "owner",
+		"repo":              "repo",
+		"discussionNumbers": []any{float64(1), float64(2), float64(3)},
+		"label":             "triage",
+	})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var out struct {
+		Results []struct {
+			Number int            `json:"number"`
+			Status string         `json:"status"`
+			Data   map[string]any `json:"data"`
+		} `json:"results"`
+		Succeeded int `json:"succeeded"`
+		Failed    int `json:"failed"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	require.Len(t, out.Results, 3)
+	assert.Equal(t, 3, out.Succeeded)
+	assert.Equal(t, 0, out.Failed)
+	assert.Equal(t, true, out.Results[0].Data["removed"])
+	assert.Equal(t, false, out.Results[1].Data["removed"], "discussion without the label should be skipped")
+	assert.Equal(t, true, out.Results[2].Data["removed"])
+}
+
+func Test_ListDiscussionsGroupByCategory(t *testing.T) {
+	qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	node := func(number int, category string) map[string]any {
+		return map[string]any{
+			"number":     number,
+			"title":      fmt.Sprintf("Discussion %d", number),
+			"createdAt":  "2023-01-01T00:00:00Z",
+			"updatedAt":  "2023-01-02T00:00:00Z",
+			"closed":     false,
+			"isAnswered": false,
+			"author":     map[string]any{"login": "user1"},
+			"url":        fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+			"category":   map[string]any{"name": category},
+		}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qBasicNoOrder,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(30), "after": (*string)(nil), "includeBody": false},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes": []map[string]any{node(1, "Ideas"), node(2, "Q&A"), node(3, "Ideas")},
+						"pageInfo": map[string]any{
+							"hasNextPage":     false,
+							"hasPreviousPage": false,
+							"startCursor":     "",
+							"endCursor":       "",
+						},
+						"totalCount": 3,
+					},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := ListDiscussions(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "groupByCategory": true})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+	assert.NotContains(t, response, "discussions")
+	assert.Equal(t, float64(3), response["totalCount"])
+
+	var grouped struct {
+		DiscussionsByCategory map[string][]struct {
+			Number int `json:"number"`
+		} `json:"discussionsByCategory"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &grouped))
+	require.Len(t, grouped.DiscussionsByCategory, 2)
+	require.Len(t, grouped.DiscussionsByCategory["Ideas"], 2)
+	assert.Equal(t, 1, grouped.DiscussionsByCategory["Ideas"][0].Number)
+	assert.Equal(t, 3, grouped.DiscussionsByCategory["Ideas"][1].Number)
+	require.Len(t, grouped.DiscussionsByCategory["Q&A"], 1)
+	assert.Equal(t, 2, grouped.DiscussionsByCategory["Q&A"][0].Number)
+}
+
+func Test_ListDiscussionsAnsweredFilter(t *testing.T) {
+	qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	node := func(number int, isAnswered bool) map[string]any {
+		return map[string]any{
+			"number":     number,
+			"title":      fmt.Sprintf("Discussion %d", number),
+			"createdAt":  "2023-01-01T00:00:00Z",
+			"updatedAt":  "2023-01-02T00:00:00Z",
+			"closed":     false,
+			"isAnswered": isAnswered,
+			"author":     map[string]any{"login": "user1"},
+			"url":        fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+			"category":   map[string]any{"name": "Q&A"},
+		}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qBasicNoOrder,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(30), "after": (*string)(nil), "includeBody": false},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes": []map[string]any{node(1, true), node(2, false), node(3, false)},
+						"pageInfo": map[string]any{
+							"hasNextPage":     true,
+							"hasPreviousPage": false,
+							"startCursor":     "start",
+							"endCursor":       "end",
+						},
+						"totalCount": 10,
+					},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := ListDiscussions(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	list := func(t *testing.T, args map[string]any) map[string]any {
+		req := createMCPRequest(args)
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		return response
+	}
+	numbers := func(response map[string]any) []float64 {
+		var out []float64
+		for _, d := range response["discussions"].([]any) {
+			out = append(out, d.(map[string]any)["number"].(float64))
+		}
+		return out
+	}
+
+	t.Run("unanswered only", func(t *testing.T) {
+		response := list(t, map[string]any{"owner": "owner", "repo": "repo", "answered": false})
+		assert.Equal(t, []float64{2, 3}, numbers(response))
+		assert.Equal(t, float64(2), response["filteredCount"])
+		assert.Equal(t, float64(10), response["totalCount"])
+		assert.Equal(t, "end", response["pageInfo"].(map[string]any)["endCursor"])
+	})
+
+	t.Run("answered only", func(t *testing.T) {
+		response := list(t, map[string]any{"owner": "owner", "repo": "repo", "answered": true})
+		assert.Equal(t, []float64{1}, numbers(response))
+		assert.Equal(t, float64(1), response["filteredCount"])
+	})
+
+	t.Run("not filtered", func(t *testing.T) {
+		response := list(t, map[string]any{"owner": "owner", "repo": "repo"})
+		assert.Equal(t, []float64{1, 2, 3}, numbers(response))
+		assert.NotContains(t, response, "filteredCount")
+	})
+}
+
+func Test_ListDiscussionsStateFilter(t *testing.T) {
+	qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	node := func(number int, closed bool) map[string]any {
+		return map[string]any{
+			"number":    number,
+			"title":     fmt.Sprintf("Discussion %d", number),
+			"createdAt": "2023-01-01T00:00:00Z",
+			"updatedAt": "2023-01-02T00:00:00Z",
+			"closed":    closed,
+			"author":    map[string]any{"login": "user1"},
+			"url":       fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+		}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qBasicNoOrder,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(30), "after": (*string)(nil), "includeBody": false},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes":      []map[string]any{node(1, false), node(2, true), node(3, false)},
+						"totalCount": 3,
+					},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := ListDiscussions(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	call := func(t *testing.T, args map[string]any) *mcp.CallToolResult {
+		req := createMCPRequest(args)
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		return res
+	}
+	numbers := func(t *testing.T, res *mcp.CallToolResult) ([]float64, map[string]any) {
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		var out []float64
+		for _, d := range response["discussions"].([]any) {
+			out = append(out, d.(map[string]any)["number"].(float64))
+		}
+		return out, response
+	}
+
+	tests := []struct {
+		state           string
+		expectedNumbers []float64
+	}{
+		{state: "open", expectedNumbers: []float64{1, 3}},
+		{state: "closed", expectedNumbers: []float64{2}},
+		{state: "all", expectedNumbers: []float64{1, 2, 3}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.state, func(t *testing.T) {
+			got, response := numbers(t, call(t, map[string]any{"owner": "owner", "repo": "repo", "state": tc.state}))
+			assert.Equal(t, tc.expectedNumbers, got)
+			if tc.state == "all" {
+				assert.NotContains(t, response, "filteredCount")
+			} else {
+				assert.Equal(t, float64(len(tc.expectedNumbers)), response["filteredCount"])
+			}
+		})
+	}
+
+	t.Run("invalid state", func(t *testing.T) {
+		res := call(t, map[string]any{"owner": "owner", "repo": "repo", "state": "locked"})
+		require.True(t, res.IsError)
+		assert.Contains(t, getTextResult(t, res).Text, `invalid state "locked"`)
+	})
+}
+
+func Test_ListDiscussionsCreatedFilter(t *testing.T) {
+	qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	node := func(number int, createdAt string) map[string]any {
+		return map[string]any{
+			"number":     number,
+			"title":      fmt.Sprintf("Discussion %d", number),
+			"createdAt":  createdAt,
+			"updatedAt":  createdAt,
+			"closed":     false,
+			"isAnswered": false,
+			"author":     map[string]any{"login": "user1"},
+			"url":        fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+			"category":   map[string]any{"name": "General"},
+		}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qBasicNoOrder,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(30), "after": (*string)(nil), "includeBody": false},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes": []map[string]any{
+							node(1, "2024-01-01T00:00:00Z"),
+							node(2, "2024-02-01T00:00:00Z"),
+							node(3, "2024-03-01T00:00:00Z"),
+						},
+						"pageInfo": map[string]any{
+							"hasNextPage":     true,
+							"hasPreviousPage": false,
+							"startCursor":     "start",
+							"endCursor":       "end",
+						},
+						"totalCount": 10,
+					},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := ListDiscussions(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		req := createMCPRequest(args)
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		return res
+	}
+
+	tests := []struct {
+		name            string
+		args            map[string]any
+		expectedNumbers []float64
 	}{
 		{
-			name: "list repository-level discussion categories",
-			reqParams: map[string]interface{}{
-				"owner": "owner",
-				"repo":  "repo",
+			name:            "createdAfter is inclusive",
+			args:            map[string]any{"createdAfter": "2024-02-01T00:00:00Z"},
+			expectedNumbers: []float64{2, 3},
+		},
+		{
+			name:            "createdBefore is exclusive",
+			args:            map[string]any{"createdBefore": "2024-02-01T00:00:00Z"},
+			expectedNumbers: []float64{1},
+		},
+		{
+			name:            "window",
+			args:            map[string]any{"createdAfter": "2024-01-15T00:00:00+01:00", "createdBefore": "2024-02-15T00:00:00Z"},
+			expectedNumbers: []float64{2},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.args["owner"] = "owner"
+			tc.args["repo"] = "repo"
+			res := call(tc.args)
+			require.False(t, res.IsError, getTextResult(t, res).Text)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+			var numbers []float64
+			for _, d := range response["discussions"].([]any) {
+				numbers = append(numbers, d.(map[string]any)["number"].(float64))
+			}
+			assert.Equal(t, tc.expectedNumbers, numbers)
+			assert.Equal(t, float64(len(tc.expectedNumbers)), response["filteredCount"])
+			assert.Equal(t, float64(10), response["totalCount"])
+		})
+	}
+
+	t.Run("invalid timestamp", func(t *testing.T) {
+		res := call(map[string]any{"owner": "owner", "repo": "repo", "createdAfter": "2024-01-01"})
+		require.True(t, res.IsError)
+		assert.Equal(t, `invalid createdAfter: "2024-01-01" is not an RFC3339 timestamp`, getErrorResult(t, res).Text)
+	})
+
+	t.Run("empty window", func(t *testing.T) {
+		res := call(map[string]any{"owner": "owner", "repo": "repo", "createdAfter": "2024-02-01T00:00:00Z", "createdBefore": "2024-01-01T00:00:00Z"})
+		require.True(t, res.IsError)
+		assert.Equal(t, "createdAfter must be before createdBefore", getErrorResult(t, res).Text)
+	})
+}
+
+func Test_ListDiscussionsSortBy(t *testing.T) {
+	qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	node := func(number, comments, upvotes, reactions int) map[string]any {
+		return map[string]any{
+			"number":      number,
+			"title":       fmt.Sprintf("Discussion %d", number),
+			"createdAt":   "2023-01-01T00:00:00Z",
+			"updatedAt":   "2023-01-02T00:00:00Z",
+			"closed":      false,
+			"isAnswered":  false,
+			"author":      map[string]any{"login": "user1"},
+			"url":         fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+			"category":    map[string]any{"name": "General"},
+			"comments":    map[string]any{"totalCount": comments},
+			"upvoteCount": upvotes,
+			"reactions":   map[string]any{"totalCount": reactions},
+		}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qBasicNoOrder,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(30), "after": (*string)(nil), "includeBody": false},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes": []map[string]any{node(1, 2, 0, 9), node(2, 7, 3, 1), node(3, 2, 8, 4)},
+						"pageInfo": map[string]any{
+							"hasNextPage":     false,
+							"hasPreviousPage": false,
+							"startCursor":     "",
+							"endCursor":       "",
+						},
+						"totalCount": 3,
+					},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := ListDiscussions(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	tests := []struct {
+		sortBy          string
+		expectedNumbers []float64
+	}{
+		// Ties keep the order the server returned them in
+		{sortBy: "COMMENTS", expectedNumbers: []float64{2, 1, 3}},
+		{sortBy: "UPVOTES", expectedNumbers: []float64{3, 2, 1}},
+		{sortBy: "REACTIONS", expectedNumbers: []float64{1, 3, 2}},
+		{sortBy: "", expectedNumbers: []float64{1, 2, 3}},
+	}
+	for _, tc := range tests {
+		t.Run("sortBy "+tc.sortBy, func(t *testing.T) {
+			args := map[string]any{"owner": "owner", "repo": "repo"}
+			if tc.sortBy != "" {
+				args["sortBy"] = tc.sortBy
+			}
+			req := createMCPRequest(args)
+			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			require.False(t, res.IsError, getTextResult(t, res).Text)
+
+			var response struct {
+				Discussions []map[string]any `json:"discussions"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+			var numbers []float64
+			for _, d := range response.Discussions {
+				numbers = append(numbers, d["number"].(float64))
+			}
+			assert.Equal(t, tc.expectedNumbers, numbers)
+		})
+	}
+
+	t.Run("upvoteCount is returned", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		var response struct {
+			Discussions []map[string]any `json:"discussions"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		assert.Equal(t, float64(8), response.Discussions[2]["upvoteCount"])
+	})
+
+	t.Run("invalid sortBy", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "sortBy": "VIEWS"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getErrorResult(t, res).Text, `invalid sortBy "VIEWS"`)
+	})
+}
+
+func Test_ListDiscussionsMaxCost(t *testing.T) {
+	qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	page := func(number int, endCursor string) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussions": map[string]any{
+					"nodes": []map[string]any{{
+						"number":    number,
+						"title":     fmt.Sprintf("Discussion %d", number),
+						"createdAt": "2023-01-01T00:00:00Z",
+						"updatedAt": "2023-01-02T00:00:00Z",
+						"author":    map[string]any{"login": "user1"},
+						"url":       fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+					}},
+					"pageInfo": map[string]any{
+						"hasNextPage":     true,
+						"hasPreviousPage": false,
+						"startCursor":     "",
+						"endCursor":       endCursor,
+					},
+					"totalCount": 3,
+				},
 			},
-			vars:          varsRepo,
-			mockResponse:  mockRespRepo,
-			expectError:   false,
-			expectedCount: 2,
-			expectedCategories: []map[string]string{
-				{"id": "123", "name": "CategoryOne"},
-				{"id": "456", "name": "CategoryTwo"},
+			"rateLimit": map[string]any{"cost": 3},
+		})
+	}
+	vars := func(after any) map[string]any {
+		return map[string]any{"owner": "owner", "repo": "repo", "first": float64(100), "after": after, "includeBody": false}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qBasicNoOrder, vars((*string)(nil)), page(1, "cursor1")),
+		githubv4mock.NewQueryMatcher(qBasicNoOrder, vars("cursor1"), page(2, "cursor2")),
+		githubv4mock.NewQueryMatcher(qBasicNoOrder, vars("cursor2"), page(3, "cursor3")),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := ListDiscussions(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	t.Run("budget halts paging", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "fetchAll": true, "maxCost": float64(5)})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var response struct {
+			Discussions []map[string]any `json:"discussions"`
+			Truncated   bool             `json:"truncated"`
+			CostUsed    int              `json:"costUsed"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		assert.Len(t, response.Discussions, 2)
+		assert.True(t, response.Truncated)
+		assert.Equal(t, 6, response.CostUsed)
+	})
+
+	t.Run("requires fetchAll", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "maxCost": float64(5)})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getErrorResult(t, res).Text, "maxCost requires fetchAll")
+	})
+}
+
+func Test_SearchDiscussions(t *testing.T) {
+	toolDef := SearchDiscussions(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "search_discussions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "search_discussions tool should be read-only")
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+	assert.ElementsMatch(t, schema.Required, []string{"query"})
+
+	qSearch := "query($after:String$first:Int!$includeBody:Boolean!$query:String!){search(query: $query, type: DISCUSSION, first: $first, after: $after){discussionCount,nodes{... on Discussion{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor}}}"
+	response := githubv4mock.DataResponse(map[string]any{
+		"search": map[string]any{
+			"discussionCount": 1,
+			"nodes": []map[string]any{{
+				"number":     7,
+				"title":      "Flaky test on CI",
+				"createdAt":  "2023-01-01T00:00:00Z",
+				"updatedAt":  "2023-01-02T00:00:00Z",
+				"closed":     false,
+				"isAnswered": false,
+				"author":     map[string]any{"login": "user1"},
+				"url":        "https://github.com/owner/repo/discussions/7",
+				"category":   map[string]any{"name": "Q&A"},
+				"reactions":  map[string]any{"totalCount": 2},
+				"comments":   map[string]any{"totalCount": 4},
+			}},
+			"pageInfo": map[string]any{
+				"hasNextPage":     false,
+				"hasPreviousPage": false,
+				"startCursor":     "",
+				"endCursor":       "",
 			},
 		},
+	})
+
+	tests := []struct {
+		name          string
+		reqParams     map[string]any
+		expectedQuery string
+	}{
 		{
-			name: "list org-level discussion categories (no repo provided)",
-			reqParams: map[string]interface{}{
-				"owner": "owner",
-				// repo is not provided, it will default to ".github"
-			},
-			vars:          varsOrg,
-			mockResponse:  mockRespOrg,
-			expectError:   false,
-			expectedCount: 3,
-			expectedCategories: []map[string]string{
-				{"id": "789", "name": "Announcements"},
-				{"id": "101", "name": "General"},
-				{"id": "112", "name": "Ideas"},
-			},
+			name:          "unscoped",
+			reqParams:     map[string]any{"query": "flaky in:title"},
+			expectedQuery: "flaky in:title",
+		},
+		{
+			name:          "scoped to a repository",
+			reqParams:     map[string]any{"query": "flaky in:title", "owner": "owner", "repo": "repo"},
+			expectedQuery: "repo:owner/repo flaky in:title",
+		},
+		{
+			name:          "query already has a repository",
+			reqParams:     map[string]any{"query": "repo:other/repo flaky", "owner": "owner", "repo": "repo"},
+			expectedQuery: "repo:other/repo flaky",
+		},
+		{
+			name:          "all of the labels",
+			reqParams:     map[string]any{"query": "flaky", "labels": []any{"bug", "help wanted"}},
+			expectedQuery: `flaky label:"bug" label:"help wanted"`,
+		},
+		{
+			name:          "any of the labels",
+			reqParams:     map[string]any{"query": "flaky", "labels": []any{"bug", "help wanted"}, "labelMatch": "any"},
+			expectedQuery: `flaky label:"bug","help wanted"`,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			matcher := githubv4mock.NewQueryMatcher(qListCategories, tc.vars, tc.mockResponse)
-			httpClient := githubv4mock.NewMockedHTTPClient(matcher)
-			gqlClient := githubv4.NewClient(httpClient)
-
-			deps := BaseDeps{GQLClient: gqlClient}
+			mockClient := githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(qSearch,
+					map[string]any{"query": tc.expectedQuery, "first": float64(30), "after": (*string)(nil), "includeBody": false},
+					response,
+				),
+			)
+			deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
 			handler := toolDef.Handler(deps)
 
 			req := createMCPRequest(tc.reqParams)
 			res, err := handler(ContextWithDeps(context.Background(), deps), &req)
-			text := getTextResult(t, res).Text
-
-			if tc.expectError {
-				require.True(t, res.IsError)
-				return
-			}
 			require.NoError(t, err)
+			require.False(t, res.IsError, getTextResult(t, res).Text)
 
-			var response struct {
-				Categories []map[string]string `json:"categories"`
-				PageInfo   struct {
-					HasNextPage     bool   `json:"hasNextPage"`
-					HasPreviousPage bool   `json:"hasPreviousPage"`
-					StartCursor     string `json:"startCursor"`
-					EndCursor       string `json:"endCursor"`
-				} `json:"pageInfo"`
-				TotalCount int `json:"totalCount"`
+			var out struct {
+				Discussions []map[string]any `json:"discussions"`
+				TotalCount  int              `json:"totalCount"`
 			}
-			require.NoError(t, json.Unmarshal([]byte(text), &response))
-			assert.Equal(t, tc.expectedCategories, response.Categories)
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+			assert.Equal(t, 1, out.TotalCount)
+			require.Len(t, out.Discussions, 1)
+			assert.Equal(t, float64(7), out.Discussions[0]["number"])
+			assert.Equal(t, "Flaky test on CI", out.Discussions[0]["title"])
+			assert.Equal(t, float64(4), out.Discussions[0]["commentCount"])
 		})
 	}
-}
-
-func Test_CreateDiscussion(t *testing.T) {
-	toolDef := CreateDiscussion(translations.NullTranslationHelper)
-	tool := toolDef.Tool
-	require.NoError(t, toolsnaps.Test(tool.Name, tool))
-
-	assert.Equal(t, "create_discussion", tool.Name)
-	assert.NotEmpty(t, tool.Description)
-	assert.False(t, tool.Annotations.ReadOnlyHint, "create_discussion tool should not be read-only")
 
-	schema, ok := tool.InputSchema.(*jsonschema.Schema)
-	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
-	assert.Contains(t, schema.Properties, "owner")
-	assert.Contains(t, schema.Properties, "repo")
-	assert.Contains(t, schema.Properties, "title")
-	assert.Contains(t, schema.Properties, "body")
-	assert.Contains(t, schema.Properties, "category_id")
-	assert.Contains(t, schema.Properties, "category_name")
-	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "title", "body"})
+	for _, tc := range []struct {
+		name        string
+		reqParams   map[string]any
+		errContains string
+	}{
+		{
+			name:        "invalid labelMatch",
+			reqParams:   map[string]any{"query": "flaky", "labels": []any{"bug"}, "labelMatch": "none"},
+			errContains: `invalid labelMatch "none"`,
+		},
+		{
+			name:        "label with a double quote",
+			reqParams:   map[string]any{"query": "flaky", "labels": []any{`say "hi"`}},
+			errContains: "can't contain double quotes",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{}
+			req := createMCPRequest(tc.reqParams)
+			res, err := toolDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			require.True(t, res.IsError)
+			assert.Contains(t, getErrorResult(t, res).Text, tc.errContains)
+		})
+	}
+}
 
-	t.Run("create with category_id", func(t *testing.T) {
+func Test_CreateDiscussionCategoryRequirementHint(t *testing.T) {
+	create := func(t *testing.T, createErr string) string {
 		mockedClient := githubv4mock.NewMockedHTTPClient(
 			githubv4mock.NewQueryMatcher(
 				struct {
@@ -867,389 +6799,687 @@ func Test_CreateDiscussion(t *testing.T) {
 				struct {
 					CreateDiscussion struct {
 						Discussion struct {
-							ID     githubv4.ID
-							Number githubv4.Int
-							URL    githubv4.String `graphql:"url"`
+							ID       githubv4.ID
+							Number   githubv4.Int
+							URL      githubv4.String `graphql:"url"`
+							Category *struct {
+								ID           githubv4.ID
+								Name         githubv4.String
+								IsAnswerable githubv4.Boolean
+							}
 						}
 					} `graphql:"createDiscussion(input: $input)"`
 				}{},
 				githubv4.CreateDiscussionInput{
 					RepositoryID: githubv4.ID("repo-id"),
-					Title:        githubv4.String("My title"),
-					Body:         githubv4.String("My body"),
-					CategoryID:   githubv4.ID("DIC_1"),
+					Title:        githubv4.String("Which CI provider?"),
+					Body:         githubv4.String("Vote below"),
+					CategoryID:   githubv4.ID("DIC_POLLS"),
 				},
 				nil,
+				githubv4mock.ErrorResponse(createErr),
+			),
+			githubv4mock.NewQueryMatcher(
+				"query($id:ID!){node(id: $id){... on DiscussionCategory{name,isAnswerable}}}",
+				map[string]any{"id": "DIC_POLLS"},
 				githubv4mock.DataResponse(map[string]any{
-					"createDiscussion": map[string]any{
-						"discussion": map[string]any{
-							"id":     githubv4.ID("DISC_1"),
-							"number": githubv4.Int(1),
-							"url":    githubv4.String("https://github.com/owner/repo/discussions/1"),
-						},
-					},
+					"node": map[string]any{"name": "Polls", "isAnswerable": false},
 				}),
 			),
 		)
-
 		deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+		toolDef := CreateDiscussion(translations.NullTranslationHelper)
 		handler := toolDef.Handler(deps)
 
-		req := createMCPRequest(map[string]any{
-			"owner":       "owner",
-			"repo":        "repo",
-			"title":       "My title",
-			"body":        "My body",
-			"category_id": "DIC_1",
-		})
-
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "title": "Which CI provider?", "body": "Vote below", "category_id": "DIC_POLLS"})
 		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
 		require.NoError(t, err)
-		assert.False(t, res.IsError)
+		require.True(t, res.IsError)
+		return getErrorResult(t, res).Text
+	}
 
-		var out map[string]any
-		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
-		assert.Equal(t, "DISC_1", out["id"])
-		assert.Equal(t, float64(1), out["number"])
-		assert.Equal(t, "https://github.com/owner/repo/discussions/1", out["url"])
+	t.Run("category requirement", func(t *testing.T) {
+		text := create(t, "A poll is required to create a discussion in this category")
+		assert.Contains(t, text, "A poll is required")
+		assert.Contains(t, text, `category "Polls" can't be used as given, because discussions in it must have a poll`)
 	})
 
-	t.Run("create with category_name", func(t *testing.T) {
-		mockedClient := githubv4mock.NewMockedHTTPClient(
-			githubv4mock.NewQueryMatcher(
-				struct {
-					Repository struct {
-						ID githubv4.ID
-					} `graphql:"repository(owner: $owner, name: $repo)"`
-				}{},
-				map[string]any{
-					"owner": githubv4.String("owner"),
-					"repo":  githubv4.String("repo"),
+	for _, createErr := range []string{
+		"Resource not accessible by integration",
+		"DIC_POLLS is not authorized to create discussions with this token's permissions",
+		"Polling for the mutation result timed out",
+	} {
+		t.Run("unrelated error: "+createErr, func(t *testing.T) {
+			assert.Equal(t, createErr, create(t, createErr))
+		})
+	}
+}
+
+func Test_ListRecentlyAnsweredDiscussions(t *testing.T) {
+	toolDef := ListRecentlyAnsweredDiscussions(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_recently_answered_discussions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "list_recently_answered_discussions tool should be read-only")
+
+	qDiscussions := "query($after:String$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after, orderBy: {field: UPDATED_AT, direction: DESC}){nodes{number,title,url,isAnswered,answerChosenAt,answer{url,author{login}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor}}}}"
+	answered := func(number int, answerChosenAt, author string) map[string]any {
+		return map[string]any{
+			"number":         number,
+			"title":          fmt.Sprintf("Question %d", number),
+			"url":            fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+			"isAnswered":     true,
+			"answerChosenAt": answerChosenAt,
+			"answer": map[string]any{
+				"url":    fmt.Sprintf("https://github.com/owner/repo/discussions/%d#discussioncomment-%d", number, number),
+				"author": map[string]any{"login": author},
+			},
+		}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qDiscussions,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(100), "after": (*string)(nil)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes": []map[string]any{
+							answered(1, "2025-03-01T00:00:00Z", "alice"),
+							{"number": 2, "title": "Open question", "url": "https://github.com/owner/repo/discussions/2", "isAnswered": false},
+							answered(3, "2025-05-01T00:00:00Z", "bob"),
+							answered(4, "2025-04-01T00:00:00Z", "carol"),
+						},
+						"pageInfo": map[string]any{
+							"hasNextPage":     false,
+							"hasPreviousPage": false,
+							"startCursor":     "",
+							"endCursor":       "",
+						},
+					},
 				},
-				githubv4mock.DataResponse(map[string]any{
-					"repository": map[string]any{
-						"id": githubv4.ID("repo-id"),
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "limit": float64(2)})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
+
+	var out struct {
+		Discussions []map[string]any `json:"discussions"`
+		Truncated   bool             `json:"truncated"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	require.Len(t, out.Discussions, 2)
+	assert.Equal(t, float64(3), out.Discussions[0]["number"])
+	assert.Equal(t, "bob", out.Discussions[0]["answerAuthor"])
+	assert.Equal(t, "https://github.com/owner/repo/discussions/3#discussioncomment-3", out.Discussions[0]["answerUrl"])
+	assert.Equal(t, float64(4), out.Discussions[1]["number"])
+	assert.False(t, out.Truncated)
+}
+
+func Test_ListDiscussionsIncludeBody(t *testing.T) {
+	qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	node := func(number int, body string) map[string]any {
+		n := map[string]any{
+			"number":    number,
+			"title":     fmt.Sprintf("Discussion %d", number),
+			"createdAt": "2023-01-01T00:00:00Z",
+			"updatedAt": "2023-01-02T00:00:00Z",
+			"author":    map[string]any{"login": "user1"},
+			"url":       fmt.Sprintf("https://github.com/owner/repo/discussions/%d", number),
+		}
+		if body != "" {
+			n["body"] = body
+		}
+		return n
+	}
+	page := func(nodes ...map[string]any) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussions": map[string]any{
+					"nodes": nodes,
+					"pageInfo": map[string]any{
+						"hasNextPage":     false,
+						"hasPreviousPage": false,
+						"startCursor":     "",
+						"endCursor":       "",
 					},
-				}),
-			),
-			githubv4mock.NewQueryMatcher(
-				struct {
-					Repository struct {
-						DiscussionCategories struct {
-							Nodes []struct {
-								ID   githubv4.ID
-								Name githubv4.String
-							}
-						} `graphql:"discussionCategories(first: $first)"`
-					} `graphql:"repository(owner: $owner, name: $repo)"`
-				}{},
-				map[string]any{
-					"owner": githubv4.String("owner"),
-					"repo":  githubv4.String("repo"),
-					"first": githubv4.Int(100),
+					"totalCount": 2,
 				},
-				githubv4mock.DataResponse(map[string]any{
-					"repository": map[string]any{
-						"discussionCategories": map[string]any{
+			},
+		})
+	}
+	// The body is only served when the query asks for it, so a request
+	// without includeBody that still selected it would fail to match.
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qBasicNoOrder,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(30), "after": (*string)(nil), "includeBody": false},
+			page(node(1, ""), node(2, "")),
+		),
+		githubv4mock.NewQueryMatcher(qBasicNoOrder,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(30), "after": (*string)(nil), "includeBody": true},
+			page(node(1, "Short body"), node(2, "A much longer body that goes on")),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := ListDiscussions(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	list := func(t *testing.T, args map[string]any) []map[string]any {
+		req := createMCPRequest(args)
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+		var response struct {
+			Discussions []map[string]any `json:"discussions"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		require.Len(t, response.Discussions, 2)
+		return response.Discussions
+	}
+
+	t.Run("omitted by default", func(t *testing.T) {
+		discussions := list(t, map[string]any{"owner": "owner", "repo": "repo"})
+		assert.NotContains(t, discussions[0], "body")
+		assert.NotContains(t, discussions[1], "body")
+	})
+
+	t.Run("included in full", func(t *testing.T) {
+		discussions := list(t, map[string]any{"owner": "owner", "repo": "repo", "includeBody": true})
+		assert.Equal(t, "Short body", discussions[0]["body"])
+		assert.Equal(t, "A much longer body that goes on", discussions[1]["body"])
+		assert.NotContains(t, discussions[1], "bodyTruncated")
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		discussions := list(t, map[string]any{"owner": "owner", "repo": "repo", "includeBody": true, "bodyMaxLength": float64(13)})
+		assert.Equal(t, "Short body", discussions[0]["body"])
+		assert.NotContains(t, discussions[0], "bodyTruncated")
+		assert.Equal(t, "A much longer", discussions[1]["body"])
+		assert.Equal(t, true, discussions[1]["bodyTruncated"])
+	})
+}
+
+func Test_SearchDiscussionComments(t *testing.T) {
+	toolDef := SearchDiscussionComments(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "search_discussion_comments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "search_discussion_comments tool should be read-only")
+
+	qComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{id,body,url,author{login}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor}}}}}"
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qComments,
+			map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1), "first": float64(100), "after": (*string)(nil)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussion": map[string]any{
+						"comments": map[string]any{
 							"nodes": []map[string]any{
-								{"id": githubv4.ID("CAT_GENERAL"), "name": githubv4.String("General")},
+								{"id": "DC_1", "body": "The Timeout is set too low", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-1", "author": map[string]any{"login": "alice"}},
+								{"id": "DC_2", "body": "Works for me", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-2", "author": map[string]any{"login": "bob"}},
+								{"id": "DC_3", "body": "Raising the timeouts fixed it", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-3", "author": nil},
+							},
+							"pageInfo": map[string]any{
+								"hasNextPage":     false,
+								"hasPreviousPage": false,
+								"startCursor":     "",
+								"endCursor":       "",
 							},
 						},
 					},
-				}),
-			),
-			githubv4mock.NewMutationMatcher(
-				struct {
-					CreateDiscussion struct {
-						Discussion struct {
-							ID     githubv4.ID
-							Number githubv4.Int
-							URL    githubv4.String `graphql:"url"`
-						}
-					} `graphql:"createDiscussion(input: $input)"`
-				}{},
-				githubv4.CreateDiscussionInput{
-					RepositoryID: githubv4.ID("repo-id"),
-					Title:        githubv4.String("My title"),
-					Body:         githubv4.String("My body"),
-					CategoryID:   githubv4.ID("CAT_GENERAL"),
 				},
-				nil,
-				githubv4mock.DataResponse(map[string]any{
-					"createDiscussion": map[string]any{
-						"discussion": map[string]any{
-							"id":     githubv4.ID("DISC_2"),
-							"number": githubv4.Int(2),
-							"url":    githubv4.String("https://github.com/owner/repo/discussions/2"),
-						},
-					},
-				}),
-			),
-		)
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
 
-		deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
-		handler := toolDef.Handler(deps)
+	req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1), "query": "timeout"})
+	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	require.NoError(t, err)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
 
-		req := createMCPRequest(map[string]any{
-			"owner":         "owner",
-			"repo":          "repo",
-			"title":         "My title",
-			"body":          "My body",
-			"category_name": "General",
-		})
+	var out struct {
+		Comments  []map[string]any `json:"comments"`
+		Scanned   int              `json:"scanned"`
+		Truncated bool             `json:"truncated"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+	require.Len(t, out.Comments, 2)
+	assert.Equal(t, "https://github.com/owner/repo/discussions/1#discussioncomment-1", out.Comments[0]["url"])
+	assert.Equal(t, "alice", out.Comments[0]["author"])
+	assert.Equal(t, "https://github.com/owner/repo/discussions/1#discussioncomment-3", out.Comments[1]["url"])
+	assert.Nil(t, out.Comments[1]["author"])
+	assert.Equal(t, 3, out.Scanned)
+	assert.False(t, out.Truncated)
+}
+
+func Test_GetRepositoryDiscussionSettings(t *testing.T) {
+	toolDef := GetRepositoryDiscussionSettings(translations.NullTranslationHelper)
+	tool := toolDef.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_discussion_settings", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "get_repository_discussion_settings tool should be read-only")
+	assert.ElementsMatch(t, tool.InputSchema.(*jsonschema.Schema).Required, []string{"owner", "repo"})
+
+	qSettings := "query($first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){hasDiscussionsEnabled,discussionCategories(first: $first){nodes{id,name,isAnswerable},totalCount}}}"
+	vars := func(repo string) map[string]any {
+		return map[string]any{"owner": "owner", "repo": repo, "first": float64(50)}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qSettings, vars("repo"), githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"hasDiscussionsEnabled": true,
+				"discussionCategories": map[string]any{
+					"nodes": []map[string]any{
+						{"id": "DIC_1", "name": "General", "isAnswerable": false},
+						{"id": "DIC_2", "name": "Q&A", "isAnswerable": true},
+					},
+					"totalCount": 2,
+				},
+			},
+		})),
+		githubv4mock.NewQueryMatcher(qSettings, vars("disabled"), githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"hasDiscussionsEnabled": false,
+				"discussionCategories":  map[string]any{"nodes": []map[string]any{}, "totalCount": 0},
+			},
+		})),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
 
+	t.Run("discussions enabled", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"})
 		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
 		require.NoError(t, err)
-		assert.False(t, res.IsError)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
 
 		var out map[string]any
 		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
-		assert.Equal(t, "DISC_2", out["id"])
-		assert.Equal(t, float64(2), out["number"])
-		assert.Equal(t, "https://github.com/owner/repo/discussions/2", out["url"])
+		assert.Equal(t, true, out["hasDiscussionsEnabled"])
+		assert.Equal(t, []any{
+			map[string]any{"id": "DIC_1", "name": "General", "isAnswerable": false},
+			map[string]any{"id": "DIC_2", "name": "Q&A", "isAnswerable": true},
+		}, out["categories"])
+		assert.NotContains(t, out, "truncated")
+	})
+
+	t.Run("discussions disabled", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "disabled"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, false, out["hasDiscussionsEnabled"])
+		assert.Equal(t, []any{}, out["categories"])
 	})
 }
 
-func Test_UpdateDiscussion(t *testing.T) {
-	toolDef := UpdateDiscussion(translations.NullTranslationHelper)
+func Test_MinimizeDiscussionComment(t *testing.T) {
+	toolDef := MinimizeDiscussionComment(translations.NullTranslationHelper)
 	tool := toolDef.Tool
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "update_discussion", tool.Name)
+	assert.Equal(t, "minimize_discussion_comment", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.False(t, tool.Annotations.ReadOnlyHint, "update_discussion tool should not be read-only")
+	assert.False(t, tool.Annotations.ReadOnlyHint, "minimize_discussion_comment tool should not be read-only")
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+	assert.ElementsMatch(t, schema.Required, []string{"comment_id", "classifier"})
 
-	mockedClient := githubv4mock.NewMockedHTTPClient(
-		githubv4mock.NewQueryMatcher(
-			struct {
-				Repository struct {
-					Discussion struct {
-						ID githubv4.ID
-					} `graphql:"discussion(number: $discussionNumber)"`
-				} `graphql:"repository(owner: $owner, name: $repo)"`
-			}{},
-			map[string]any{
-				"owner":            githubv4.String("owner"),
-				"repo":             githubv4.String("repo"),
-				"discussionNumber": githubv4.Int(1),
-			},
-			githubv4mock.DataResponse(map[string]any{
-				"repository": map[string]any{
-					"discussion": map[string]any{
-						"id": githubv4.ID("DISC_ID"),
-					},
-				},
-			}),
-		),
+	mockClient := githubv4mock.NewMockedHTTPClient(
 		githubv4mock.NewMutationMatcher(
 			struct {
-				UpdateDiscussion struct {
-					Discussion struct {
-						ID     githubv4.ID
-						Number githubv4.Int
-						URL    githubv4.String `graphql:"url"`
+				MinimizeComment struct {
+					MinimizedComment struct {
+						IsMinimized     githubv4.Boolean
+						MinimizedReason *githubv4.String
 					}
-				} `graphql:"updateDiscussion(input: $input)"`
+				} `graphql:"minimizeComment(input: $input)"`
 			}{},
-			githubv4.UpdateDiscussionInput{
-				DiscussionID: githubv4.ID("DISC_ID"),
-				Title:        func() *githubv4.String { s := githubv4.String("New title"); return &s }(),
-			},
+			githubv4.MinimizeCommentInput{SubjectID: githubv4.ID("DC_1"), Classifier: githubv4.ReportedContentClassifiersSpam},
 			nil,
 			githubv4mock.DataResponse(map[string]any{
-				"updateDiscussion": map[string]any{
-					"discussion": map[string]any{
-						"id":     githubv4.ID("DISC_ID"),
-						"number": githubv4.Int(1),
-						"url":    githubv4.String("https://github.com/owner/repo/discussions/1"),
-					},
-				},
+				"minimizeComment": map[string]any{"minimizedComment": map[string]any{"isMinimized": true, "minimizedReason": "spam"}},
 			}),
 		),
 	)
-
-	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
 	handler := toolDef.Handler(deps)
 
-	req := createMCPRequest(map[string]any{
-		"owner":            "owner",
-		"repo":             "repo",
-		"discussionNumber": int32(1),
-		"title":            "New title",
+	t.Run("minimizes the comment", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"comment_id": "DC_1", "classifier": "SPAM"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, "DC_1", out["commentId"])
+		assert.Equal(t, true, out["isMinimized"])
+		assert.Equal(t, "spam", out["minimizedReason"])
 	})
-	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
-	require.NoError(t, err)
-	assert.False(t, res.IsError)
 
-	var out map[string]any
-	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
-	assert.Equal(t, "DISC_ID", out["id"])
-	assert.Equal(t, float64(1), out["number"])
-	assert.Equal(t, "https://github.com/owner/repo/discussions/1", out["url"])
+	t.Run("rejects an invalid classifier", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"comment_id": "DC_1", "classifier": "RUDE"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Contains(t, getTextResult(t, res).Text, `invalid classifier "RUDE"`)
+	})
 }
 
-func Test_AddDiscussionComment(t *testing.T) {
-	toolDef := AddDiscussionComment(translations.NullTranslationHelper)
+func Test_UnminimizeDiscussionComment(t *testing.T) {
+	toolDef := UnminimizeDiscussionComment(translations.NullTranslationHelper)
 	tool := toolDef.Tool
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "add_discussion_comment", tool.Name)
+	assert.Equal(t, "unminimize_discussion_comment", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.False(t, tool.Annotations.ReadOnlyHint, "add_discussion_comment tool should not be read-only")
+	assert.False(t, tool.Annotations.ReadOnlyHint, "unminimize_discussion_comment tool should not be read-only")
 
-	mockedClient := githubv4mock.NewMockedHTTPClient(
-		githubv4mock.NewQueryMatcher(
-			struct {
-				Repository struct {
-					Discussion struct {
-						ID githubv4.ID
-					} `graphql:"discussion(number: $discussionNumber)"`
-				} `graphql:"repository(owner: $owner, name: $repo)"`
-			}{},
-			map[string]any{
-				"owner":            githubv4.String("owner"),
-				"repo":             githubv4.String("repo"),
-				"discussionNumber": githubv4.Int(1),
-			},
-			githubv4mock.DataResponse(map[string]any{
-				"repository": map[string]any{
-					"discussion": map[string]any{
-						"id": githubv4.ID("DISC_ID"),
-					},
-				},
-			}),
-		),
+	mockClient := githubv4mock.NewMockedHTTPClient(
 		githubv4mock.NewMutationMatcher(
 			struct {
-				AddDiscussionComment struct {
-					Comment struct {
-						ID  githubv4.ID
-						URL githubv4.String `graphql:"url"`
+				UnminimizeComment struct {
+					UnminimizedComment struct {
+						IsMinimized     githubv4.Boolean
+						MinimizedReason *githubv4.String
 					}
-				} `graphql:"addDiscussionComment(input: $input)"`
+				} `graphql:"unminimizeComment(input: $input)"`
 			}{},
-			githubv4.AddDiscussionCommentInput{
-				DiscussionID: githubv4.ID("DISC_ID"),
-				Body:         githubv4.String("Hello"),
-			},
+			githubv4.UnminimizeCommentInput{SubjectID: githubv4.ID("DC_1")},
 			nil,
 			githubv4mock.DataResponse(map[string]any{
-				"addDiscussionComment": map[string]any{
-					"comment": map[string]any{
-						"id":  githubv4.ID("DC_1"),
-						"url": githubv4.String("https://github.com/owner/repo/discussions/1#discussioncomment-1"),
-					},
-				},
+				"unminimizeComment": map[string]any{"unminimizedComment": map[string]any{"isMinimized": false, "minimizedReason": nil}},
 			}),
 		),
 	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
 
-	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
-	handler := toolDef.Handler(deps)
-
-	req := createMCPRequest(map[string]any{
-		"owner":            "owner",
-		"repo":             "repo",
-		"discussionNumber": int32(1),
-		"body":             "Hello",
-	})
-	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+	req := createMCPRequest(map[string]any{"comment_id": "DC_1"})
+	res, err := toolDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
 	require.NoError(t, err)
-	assert.False(t, res.IsError)
+	require.False(t, res.IsError, getTextResult(t, res).Text)
 
 	var out map[string]any
 	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
-	assert.Equal(t, "DC_1", out["id"])
-	assert.Equal(t, "https://github.com/owner/repo/discussions/1#discussioncomment-1", out["url"])
+	assert.Equal(t, false, out["isMinimized"])
+	assert.Nil(t, out["minimizedReason"])
 }
 
-func Test_UpdateDiscussionComment(t *testing.T) {
-	toolDef := UpdateDiscussionComment(translations.NullTranslationHelper)
-	tool := toolDef.Tool
-	require.NoError(t, toolsnaps.Test(tool.Name, tool))
-
-	assert.Equal(t, "update_discussion_comment", tool.Name)
-	assert.NotEmpty(t, tool.Description)
-	assert.False(t, tool.Annotations.ReadOnlyHint, "update_discussion_comment tool should not be read-only")
-
-	mockedClient := githubv4mock.NewMockedHTTPClient(
-		githubv4mock.NewMutationMatcher(
-			struct {
-				UpdateDiscussionComment struct {
-					Comment struct {
-						ID  githubv4.ID
-						URL githubv4.String `graphql:"url"`
-					}
-				} `graphql:"updateDiscussionComment(input: $input)"`
-			}{},
-			githubv4.UpdateDiscussionCommentInput{
-				CommentID: githubv4.ID("DC_1"),
-				Body:      githubv4.String("Updated"),
+func Test_GetDiscussionCommentsMinimized(t *testing.T) {
+	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$includeReplies:Boolean!$includeReplyContext:Boolean!$owner:String!$replyCount:Int!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{id,body,url,isAnswer,author{login},createdAt,updatedAt,isMinimized,minimizedReason,reactionGroups{content,users{totalCount}},replyTo @include(if: $includeReplyContext){id,author{login}},replies(first: $replyCount) @include(if: $includeReplies){nodes{id,body,url},pageInfo{hasNextPage}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},answerChosenAt,answerChosenBy{login}}}}"
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qGetComments,
+			map[string]any{
+				"owner":               "owner",
+				"repo":                "repo",
+				"discussionNumber":    float64(1),
+				"first":               float64(30),
+				"after":               (*string)(nil),
+				"includeReplyContext": false,
+				"includeReplies":      false,
+				"replyCount":          float64(5),
 			},
-			nil,
 			githubv4mock.DataResponse(map[string]any{
-				"updateDiscussionComment": map[string]any{
-					"comment": map[string]any{
-						"id":  githubv4.ID("DC_1"),
-						"url": githubv4.String("https://github.com/owner/repo/discussions/1#discussioncomment-1"),
+				"repository": map[string]any{
+					"discussion": map[string]any{
+						"comments": map[string]any{
+							"nodes": []map[string]any{
+								{"id": "DC_1", "body": "Buy now", "isMinimized": true, "minimizedReason": "spam"},
+								{"id": "DC_2", "body": "Useful"},
+							},
+							"totalCount": 2,
+						},
 					},
 				},
 			}),
 		),
 	)
-
-	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := GetDiscussionComments(translations.NullTranslationHelper)
 	handler := toolDef.Handler(deps)
+	call := func(t *testing.T, args map[string]any) []map[string]any {
+		req := createMCPRequest(args)
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+		var out struct {
+			Comments []map[string]any `json:"comments"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		return out.Comments
+	}
 
-	req := createMCPRequest(map[string]any{
-		"comment_id": "DC_1",
-		"body":       "Updated",
+	t.Run("marks minimized comments", func(t *testing.T) {
+		comments := call(t, map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1)})
+		require.Len(t, comments, 2)
+		assert.Equal(t, true, comments[0]["isMinimized"])
+		assert.Equal(t, "spam", comments[0]["minimizedReason"])
+		assert.Equal(t, false, comments[1]["isMinimized"])
+		assert.Nil(t, comments[1]["minimizedReason"])
 	})
-	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
-	require.NoError(t, err)
-	assert.False(t, res.IsError)
 
-	var out map[string]any
-	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
-	assert.Equal(t, "DC_1", out["id"])
+	t.Run("leaves out minimized comments", func(t *testing.T) {
+		comments := call(t, map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1), "includeMinimized": false})
+		require.Len(t, comments, 1)
+		assert.Equal(t, "DC_2", comments[0]["id"])
+	})
 }
 
-func Test_DeleteDiscussionComment(t *testing.T) {
-	toolDef := DeleteDiscussionComment(translations.NullTranslationHelper)
+func Test_VoteOnDiscussionPoll(t *testing.T) {
+	toolDef := VoteOnDiscussionPoll(translations.NullTranslationHelper)
 	tool := toolDef.Tool
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "delete_discussion_comment", tool.Name)
+	assert.Equal(t, "vote_on_discussion_poll", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.False(t, tool.Annotations.ReadOnlyHint, "delete_discussion_comment tool should not be read-only")
+	assert.False(t, tool.Annotations.ReadOnlyHint, "vote_on_discussion_poll tool should not be read-only")
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+	assert.ElementsMatch(t, schema.Required, []string{"poll_option_id"})
 
-	mockedClient := githubv4mock.NewMockedHTTPClient(
-		githubv4mock.NewMutationMatcher(
+	voteMutation := func(optionID string, response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
 			struct {
-				DeleteDiscussionComment struct {
-					ClientMutationID githubv4.String
-				} `graphql:"deleteDiscussionComment(input: $input)"`
+				AddDiscussionPollVote struct {
+					PollOption struct {
+						ID   githubv4.ID
+						Poll discussionPollFragment
+					}
+				} `graphql:"addDiscussionPollVote(input: $input)"`
 			}{},
-			githubv4.DeleteDiscussionCommentInput{
-				ID: githubv4.ID("DC_1"),
-			},
+			githubv4.AddDiscussionPollVoteInput{PollOptionID: githubv4.ID(optionID)},
 			nil,
+			response,
+		)
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		voteMutation("DPO_2", githubv4mock.DataResponse(map[string]any{
+			"addDiscussionPollVote": map[string]any{"pollOption": map[string]any{
+				"id": "DPO_2",
+				"poll": map[string]any{
+					"question":       "Which day?",
+					"totalVoteCount": 6,
+					"viewerHasVoted": true,
+					"options": map[string]any{"nodes": []map[string]any{
+						{"id": "DPO_1", "option": "Monday", "totalVoteCount": 2},
+						{"id": "DPO_2", "option": "Friday", "totalVoteCount": 4, "viewerHasVoted": true},
+					}},
+				},
+			}},
+		})),
+		voteMutation("DPO_1", githubv4mock.ErrorResponse("You have already voted on this poll")),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	handler := toolDef.Handler(deps)
+
+	t.Run("votes for the option", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"poll_option_id": "DPO_2"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+
+		var out struct {
+			PollOptionID string `json:"pollOptionId"`
+			Poll         struct {
+				TotalVoteCount int              `json:"totalVoteCount"`
+				Options        []map[string]any `json:"options"`
+			} `json:"poll"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &out))
+		assert.Equal(t, "DPO_2", out.PollOptionID)
+		assert.Equal(t, 6, out.Poll.TotalVoteCount)
+		require.Len(t, out.Poll.Options, 2)
+		assert.Equal(t, float64(4), out.Poll.Options[1]["totalVoteCount"])
+		assert.Equal(t, true, out.Poll.Options[1]["viewerHasVoted"])
+	})
+
+	t.Run("already voted", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"poll_option_id": "DPO_1"})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Equal(t, "you have already voted on this poll: GitHub doesn't allow changing or adding a vote", getErrorResult(t, res).Text)
+	})
+}
+
+func Test_DiscussionsMarkdownFormat(t *testing.T) {
+	qBasicNoOrder := "query($after:String$first:Int!$includeBody:Boolean!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,body @include(if: $includeBody),createdAt,updatedAt,closed,closedAt,isAnswered,answerChosenAt,author{login},category{name},url,reactions{totalCount},comments{totalCount},upvoteCount,labels(first: 10){nodes{name,color}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},rateLimit{cost}}"
+	qGetDiscussion := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,body,createdAt,author{login},closed,closedAt,isAnswered,answerChosenAt,url,category{name},upvoteCount,viewerHasUpvoted,labels(first: 10){nodes{name,color}},comments{totalCount},reactionGroups{content,users{totalCount},viewerHasReacted}}}}"
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qBasicNoOrder,
+			map[string]any{"owner": "owner", "repo": "repo", "first": float64(30), "after": (*string)(nil), "includeBody": true},
 			githubv4mock.DataResponse(map[string]any{
-				"deleteDiscussionComment": map[string]any{
-					"clientMutationId": githubv4.String("mut"),
+				"repository": map[string]any{
+					"discussions": map[string]any{
+						"nodes": []map[string]any{
+							{"number": 1, "title": "First", "body": "Hello", "author": map[string]any{"login": "user1"}, "category": map[string]any{"name": "General"}, "url": "https://github.com/owner/repo/discussions/1", "createdAt": "2023-01-01T00:00:00Z", "updatedAt": "2023-01-01T00:00:00Z"},
+							{"number": 2, "title": "Second", "author": map[string]any{"login": "user2"}, "url": "https://github.com/owner/repo/discussions/2", "createdAt": "2023-01-01T00:00:00Z", "updatedAt": "2023-01-01T00:00:00Z"},
+						},
+						"pageInfo":   map[string]any{"hasNextPage": true, "endCursor": "cursor2"},
+						"totalCount": 3,
+					},
 				},
 			}),
 		),
+		githubv4mock.NewQueryMatcher(qGetDiscussion,
+			map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"discussion": map[string]any{
+					"number":    1,
+					"title":     "First",
+					"body":      "Hello",
+					"author":    map[string]any{"login": "user1"},
+					"category":  map[string]any{"name": "General"},
+					"url":       "https://github.com/owner/repo/discussions/1",
+					"createdAt": "2023-01-01T00:00:00Z",
+				}},
+			}),
+		),
 	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	call := func(t *testing.T, toolDef inventory.ServerTool, args map[string]any) *mcp.CallToolResult {
+		req := createMCPRequest(args)
+		res, err := toolDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		return res
+	}
 
-	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+	t.Run("list_discussions", func(t *testing.T) {
+		res := call(t, ListDiscussions(translations.NullTranslationHelper), map[string]any{"owner": "owner", "repo": "repo", "includeBody": true, "format": "markdown"})
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+		assert.Equal(t, "## #1 First\n\n"+
+			"- Author: @user1\n"+
+			"- Category: General\n"+
+			"- URL: https://github.com/owner/repo/discussions/1\n"+
+			"\nHello\n"+
+			"\n## #2 Second\n\n"+
+			"- Author: @user2\n"+
+			"- URL: https://github.com/owner/repo/discussions/2\n"+
+			"\nMore discussions: call again with after \"cursor2\"\n", getTextResult(t, res).Text)
+	})
+
+	t.Run("get_discussion", func(t *testing.T) {
+		res := call(t, GetDiscussion(translations.NullTranslationHelper), map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1), "format": "markdown"})
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+		assert.Equal(t, "# #1 First\n\n"+
+			"- Author: @user1\n"+
+			"- Category: General\n"+
+			"- URL: https://github.com/owner/repo/discussions/1\n"+
+			"\nHello\n", getTextResult(t, res).Text)
+	})
+
+	t.Run("get_discussion json reports the author", func(t *testing.T) {
+		res := call(t, GetDiscussion(translations.NullTranslationHelper), map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)})
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+		assert.Equal(t, "user1", response["author"])
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		res := call(t, ListDiscussions(translations.NullTranslationHelper), map[string]any{"owner": "owner", "repo": "repo", "format": "html"})
+		require.True(t, res.IsError)
+		assert.Equal(t, `invalid format "html": must be one of json, markdown`, getErrorResult(t, res).Text)
+	})
+}
+
+func Test_ListDiscussionsCountOnly(t *testing.T) {
+	qCount := "query($categoryId:ID$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: 1, categoryId: $categoryId){totalCount}}}"
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qCount,
+			map[string]any{"owner": "owner", "repo": "repo", "categoryId": (*string)(nil)},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"discussions": map[string]any{"totalCount": 42}},
+			}),
+		),
+		githubv4mock.NewQueryMatcher(qCount,
+			map[string]any{"owner": "owner", "repo": "repo", "categoryId": "DIC_1"},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"discussions": map[string]any{"totalCount": 7}},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockClient)}
+	toolDef := ListDiscussions(translations.NullTranslationHelper)
 	handler := toolDef.Handler(deps)
+	call := func(t *testing.T, args map[string]any) *mcp.CallToolResult {
+		req := createMCPRequest(args)
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		return res
+	}
 
-	req := createMCPRequest(map[string]any{
-		"comment_id": "DC_1",
+	t.Run("counts all discussions", func(t *testing.T) {
+		res := call(t, map[string]any{"owner": "owner", "repo": "repo", "countOnly": true})
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+		assert.JSONEq(t, `{"totalCount":42}`, getTextResult(t, res).Text)
+	})
+
+	t.Run("counts a category", func(t *testing.T) {
+		res := call(t, map[string]any{"owner": "owner", "repo": "repo", "category": "DIC_1", "countOnly": true})
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+		assert.JSONEq(t, `{"totalCount":7}`, getTextResult(t, res).Text)
+	})
+
+	t.Run("rejects client-side filters", func(t *testing.T) {
+		res := call(t, map[string]any{"owner": "owner", "repo": "repo", "countOnly": true, "answered": true})
+		require.True(t, res.IsError)
+		assert.Contains(t, getErrorResult(t, res).Text, "countOnly cannot be combined with answered")
 	})
-	res, err := handler(ContextWithDeps(context.Background(), deps), &req)
-	require.NoError(t, err)
-	assert.False(t, res.IsError)
-	assert.Equal(t, "discussion comment deleted successfully", getTextResult(t, res).Text)
 }
@@ -0,0 +1,172 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+)
+
+// DefaultGraphQLRetryPolicy is how transient GraphQL failures are retried when no policy is configured.
+var DefaultGraphQLRetryPolicy = GraphQLRetryPolicy{MaxAttempts: 3, BaseDelay: time.Second}
+
+// maxGraphQLRetryAfter is the longest Retry-After that is waited for. Longer ones are returned as
+// errors, as a tool call shouldn't block for minutes.
+const maxGraphQLRetryAfter = time.Minute
+
+// GraphQLRetryPolicy is how queries that fail with a 5xx status or a secondary rate limit are retried.
+type GraphQLRetryPolicy struct {
+	// MaxAttempts is the number of times a query is made, including the first, or 0 for the default
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry, doubled for each further retry, or 0 for the
+	// default. A Retry-After header on the failed response is waited for instead.
+	BaseDelay time.Duration
+}
+
+func (p GraphQLRetryPolicy) withDefaults() GraphQLRetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultGraphQLRetryPolicy.MaxAttempts
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = DefaultGraphQLRetryPolicy.BaseDelay
+	}
+	return p
+}
+
+type retryAfterRecorderKey struct{}
+
+// retryAfterRecorder holds the Retry-After delay of the latest GitHub response made with its context.
+type retryAfterRecorder struct {
+	mu    sync.Mutex
+	delay time.Duration
+	ok    bool
+}
+
+func (r *retryAfterRecorder) set(delay time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.delay, r.ok = delay, true
+}
+
+// take returns the recorded delay, if any, and clears it for the next attempt.
+func (r *retryAfterRecorder) take() (time.Duration, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delay, ok := r.delay, r.ok
+	r.delay, r.ok = 0, false
+	return delay, ok
+}
+
+type retryAfterTransport struct {
+	transport http.RoundTripper
+}
+
+// NewRetryAfterTransport wraps transport to record the Retry-After header of each response, so
+// that retried queries wait as long as GitHub asks. Like the request ID, the header can't be read
+// from the GraphQL client's errors.
+func NewRetryAfterTransport(transport http.RoundTripper) http.RoundTripper {
+	return &retryAfterTransport{transport: transport}
+}
+
+func (t *retryAfterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if resp != nil {
+		if recorder, ok := req.Context().Value(retryAfterRecorderKey{}).(*retryAfterRecorder); ok {
+			if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				recorder.set(delay)
+			}
+		}
+	}
+	return resp, err
+}
+
+// parseRetryAfter parses a Retry-After header, given either as seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// queryWithRetry runs a GraphQL query, retrying it with exponential backoff while it fails
+// transiently, as told by classifyGraphQLError. It stops waiting when ctx is done.
+func queryWithRetry(ctx context.Context, client *githubv4.Client, policy GraphQLRetryPolicy, q any, vars map[string]any) error {
+	policy = policy.withDefaults()
+	recorder := &retryAfterRecorder{}
+	ctx = context.WithValue(ctx, retryAfterRecorderKey{}, recorder)
+
+	delay := policy.BaseDelay
+	for attempt := 1; ; attempt++ {
+		err := queryWithRequestID(ctx, client, q, vars)
+		if err == nil || attempt >= policy.MaxAttempts || classifyGraphQLError(err) != graphQLErrorTransient {
+			return err
+		}
+
+		wait := delay
+		if retryAfter, ok := recorder.take(); ok {
+			if retryAfter > maxGraphQLRetryAfter {
+				return err
+			}
+			wait = retryAfter
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// queryTimeoutSchema is the timeoutSeconds parameter of tools whose queries can be slow, such as
+// large organisation-level listings.
+func queryTimeoutSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:             "number",
+		Description:      "Give up after this many seconds, returning an error instead of waiting for slow GitHub queries. By default there is no limit.",
+		ExclusiveMinimum: jsonschema.Ptr(0.0),
+	}
+}
+
+// optionalQueryTimeout returns the timeoutSeconds argument, or 0 when it is not given.
+func optionalQueryTimeout(args map[string]any) (time.Duration, error) {
+	seconds, err := OptionalParam[float64](args, "timeoutSeconds")
+	if err != nil {
+		return 0, err
+	}
+	if seconds < 0 {
+		return 0, errors.New("timeoutSeconds must be positive")
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// withQueryTimeout bounds ctx by timeout, unless timeout is 0.
+func withQueryTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// queryTimeoutError replaces err with one saying the timeout ran out, when it did, as the GraphQL
+// client's own error only reports the cancelled request.
+func queryTimeoutError(ctx context.Context, timeout time.Duration, err error) error {
+	if timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("the query exceeded the timeout of %s: retry with a larger timeoutSeconds or a smaller perPage", timeout)
+	}
+	return err
+}
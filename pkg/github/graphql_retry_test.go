@@ -0,0 +1,182 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingTransport fails the first failures requests with status and headers, then passes the
+// rest to transport.
+type failingTransport struct {
+	transport http.RoundTripper
+	failures  int32
+	status    int
+	body      string
+	header    http.Header
+	count     atomic.Int32
+}
+
+func (t *failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.count.Add(1) > t.failures {
+		return t.transport.RoundTrip(req)
+	}
+	header := http.Header{}
+	for k, v := range t.header {
+		header[k] = v
+	}
+	return &http.Response{
+		StatusCode: t.status,
+		Status:     fmt.Sprintf("%d %s", t.status, http.StatusText(t.status)),
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(t.body)),
+		Request:    req,
+	}, nil
+}
+
+func Test_QueryWithRetry(t *testing.T) {
+	qGetDiscussion := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,body,createdAt,author{login},closed,closedAt,isAnswered,answerChosenAt,url,category{name},upvoteCount,viewerHasUpvoted,labels(first: 10){nodes{name,color}},comments{totalCount},reactionGroups{content,users{totalCount},viewerHasReacted}}}}"
+	newTransport := func(failures int32, status int, body string, header http.Header) *failingTransport {
+		mockClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(qGetDiscussion,
+				map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1)},
+				githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{"discussion": map[string]any{"number": 1, "title": "Retried"}},
+				}),
+			),
+		)
+		return &failingTransport{transport: mockClient.Transport, failures: failures, status: status, body: body, header: header}
+	}
+	policy := GraphQLRetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	toolDef := GetDiscussion(translations.NullTranslationHelper)
+	call := func(ctx context.Context, transport http.RoundTripper) (string, bool) {
+		deps := BaseDeps{
+			GQLClient:          githubv4.NewClient(&http.Client{Transport: NewRetryAfterTransport(transport)}),
+			GraphQLRetryPolicy: policy,
+		}
+		handler := toolDef.Handler(deps)
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1)})
+		res, err := handler(ContextWithDeps(ctx, deps), &req)
+		require.NoError(t, err)
+		return getTextResult(t, res).Text, res.IsError
+	}
+
+	t.Run("retries a 502", func(t *testing.T) {
+		transport := newTransport(2, http.StatusBadGateway, "", nil)
+		text, isError := call(context.Background(), transport)
+		require.False(t, isError, text)
+		assert.Contains(t, text, "Retried")
+		assert.Equal(t, int32(3), transport.count.Load())
+	})
+
+	t.Run("retries a secondary rate limit after Retry-After", func(t *testing.T) {
+		transport := newTransport(1, http.StatusForbidden, `{"message":"You have exceeded a secondary rate limit."}`, http.Header{"Retry-After": {"0"}})
+		text, isError := call(context.Background(), transport)
+		require.False(t, isError, text)
+		assert.Equal(t, int32(2), transport.count.Load())
+	})
+
+	t.Run("gives up after MaxAttempts", func(t *testing.T) {
+		transport := newTransport(5, http.StatusServiceUnavailable, "", nil)
+		_, isError := call(context.Background(), transport)
+		assert.True(t, isError)
+		assert.Equal(t, int32(3), transport.count.Load())
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		transport := newTransport(1, http.StatusUnauthorized, "", nil)
+		_, isError := call(context.Background(), transport)
+		assert.True(t, isError)
+		assert.Equal(t, int32(1), transport.count.Load())
+	})
+
+	t.Run("does not wait for a long Retry-After", func(t *testing.T) {
+		transport := newTransport(1, http.StatusForbidden, "secondary rate limit", http.Header{"Retry-After": {"3600"}})
+		_, isError := call(context.Background(), transport)
+		assert.True(t, isError)
+		assert.Equal(t, int32(1), transport.count.Load())
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		transport := newTransport(5, http.StatusBadGateway, "", nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, isError := call(ctx, transport)
+		assert.True(t, isError)
+		assert.LessOrEqual(t, transport.count.Load(), int32(1))
+	})
+}
+
+func Test_ParseRetryAfter(t *testing.T) {
+	delay, ok := parseRetryAfter("30")
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, delay)
+
+	delay, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), delay)
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
+
+// slowTransport never answers, failing each request once its context is done.
+type slowTransport struct{}
+
+func (slowTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func Test_QueryTimeout(t *testing.T) {
+	deps := BaseDeps{GQLClient: githubv4.NewClient(&http.Client{Transport: slowTransport{}})}
+	tests := []struct {
+		name    string
+		toolDef func(translations.TranslationHelperFunc) inventory.ServerTool
+		args    map[string]any
+	}{
+		{
+			name:    "list_discussions",
+			toolDef: ListDiscussions,
+			args:    map[string]any{"owner": "owner", "repo": "repo", "timeoutSeconds": 0.05},
+		},
+		{
+			name:    "get_discussion_comments",
+			toolDef: GetDiscussionComments,
+			args:    map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(1), "timeoutSeconds": 0.05},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			toolDef := tc.toolDef(translations.NullTranslationHelper)
+			req := createMCPRequest(tc.args)
+			res, err := toolDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+			require.NoError(t, err)
+			require.True(t, res.IsError)
+			assert.Equal(t, "the query exceeded the timeout of 50ms: retry with a larger timeoutSeconds or a smaller perPage", getErrorResult(t, res).Text)
+		})
+	}
+
+	t.Run("rejects a negative timeout", func(t *testing.T) {
+		toolDef := ListDiscussions(translations.NullTranslationHelper)
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "timeoutSeconds": -1.0})
+		res, err := toolDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Equal(t, "timeoutSeconds must be positive", getErrorResult(t, res).Text)
+	})
+}
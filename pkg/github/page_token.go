@@ -0,0 +1,86 @@
+package github
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// pageToken is the decoded form of an opaque page token: the cursor to page forward from. Page
+// tokens only page forward, so tools reject them together with last or before.
+type pageToken struct {
+	Cursor string `json:"c"`
+}
+
+// encodePageToken returns the opaque page token for paging forward from cursor.
+func encodePageToken(cursor string) string {
+	// Marshalling a string can't fail
+	b, _ := json.Marshal(pageToken{Cursor: cursor})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodePageToken parses a page token produced by encodePageToken.
+func decodePageToken(token string) (pageToken, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return pageToken{}, errors.New("invalid pageToken: not a token returned by this tool")
+	}
+	var pt pageToken
+	if err := json.Unmarshal(b, &pt); err != nil || pt.Cursor == "" {
+		return pageToken{}, errors.New("invalid pageToken: not a token returned by this tool")
+	}
+	return pt, nil
+}
+
+// WithPageTokens adds the opaque page token parameters to a tool with cursor pagination.
+func WithPageTokens(schema *jsonschema.Schema) *jsonschema.Schema {
+	schema.Properties["usePageTokens"] = &jsonschema.Schema{
+		Type:        "boolean",
+		Description: "Return a single opaque nextPageToken (null on the last page) instead of the pageInfo block.",
+	}
+
+	schema.Properties["pageToken"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "nextPageToken from the previous page, as an alternative to 'after'. Implies usePageTokens.",
+	}
+
+	return schema
+}
+
+// OptionalPageTokenParams reads the page token parameters added with WithPageTokens. A pageToken is
+// decoded into args["after"], so it is picked up by OptionalCursorPaginationParams. It returns
+// whether the response should carry a nextPageToken instead of pageInfo.
+func OptionalPageTokenParams(args map[string]any) (bool, error) {
+	usePageTokens, err := OptionalParam[bool](args, "usePageTokens")
+	if err != nil {
+		return false, err
+	}
+	token, err := OptionalParam[string](args, "pageToken")
+	if err != nil {
+		return false, err
+	}
+	if token == "" {
+		return usePageTokens, nil
+	}
+	if after, _ := args["after"].(string); after != "" {
+		return false, errors.New("pageToken and after are mutually exclusive")
+	}
+	pt, err := decodePageToken(token)
+	if err != nil {
+		return false, err
+	}
+	args["after"] = pt.Cursor
+	return true, nil
+}
+
+// replacePageInfoWithToken replaces the pageInfo block of a response with a nextPageToken, which
+// is null when there is no next page.
+func replacePageInfoWithToken(response map[string]any, hasNextPage bool, endCursor string) {
+	delete(response, "pageInfo")
+	response["nextPageToken"] = nil
+	if hasNextPage {
+		response["nextPageToken"] = encodePageToken(endCursor)
+	}
+}
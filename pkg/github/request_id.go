@@ -0,0 +1,111 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// githubRequestIDHeader identifies a request to GitHub, for correlating a failure with GitHub support.
+const githubRequestIDHeader = "X-GitHub-Request-Id"
+
+type requestIDRecorderKey struct{}
+
+// requestIDRecorder holds the request ID of the latest GitHub response made with its context.
+type requestIDRecorder struct {
+	mu sync.Mutex
+	id string
+}
+
+func (r *requestIDRecorder) set(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.id = id
+}
+
+func (r *requestIDRecorder) get() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.id
+}
+
+// contextWithRequestIDRecorder returns a context whose GitHub responses have their request IDs
+// recorded by a transport created with NewRequestIDTransport.
+func contextWithRequestIDRecorder(ctx context.Context) (context.Context, *requestIDRecorder) {
+	recorder := &requestIDRecorder{}
+	return context.WithValue(ctx, requestIDRecorderKey{}, recorder), recorder
+}
+
+type requestIDTransport struct {
+	transport http.RoundTripper
+}
+
+// NewRequestIDTransport wraps transport to record the X-GitHub-Request-Id header of each response,
+// so that errors of the discussion tools' GraphQL calls can report it. GraphQL errors are returned with a 200 status and the client
+// doesn't expose response headers, so they can't be read from the error itself.
+func NewRequestIDTransport(transport http.RoundTripper) http.RoundTripper {
+	return &requestIDTransport{transport: transport}
+}
+
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if resp != nil {
+		if recorder, ok := req.Context().Value(requestIDRecorderKey{}).(*requestIDRecorder); ok {
+			if id := resp.Header.Get(githubRequestIDHeader); id != "" {
+				recorder.set(id)
+			}
+		}
+	}
+	return resp, err
+}
+
+// githubRequestError is an error from a GitHub API call, with the request ID of the response it
+// came from so that the failure can be correlated with GitHub support.
+type githubRequestError struct {
+	err       error
+	requestID string
+}
+
+func (e *githubRequestError) Error() string {
+	return fmt.Sprintf("%s (requestId: %s)", e.err, e.requestID)
+}
+
+func (e *githubRequestError) Unwrap() error { return e.err }
+
+// requestIDOf returns the request ID carried by err, or an empty string if it has none.
+func requestIDOf(err error) string {
+	var requestErr *githubRequestError
+	if errors.As(err, &requestErr) {
+		return requestErr.requestID
+	}
+	return ""
+}
+
+// withRequestID returns err with the request ID of the response it came from, if one was recorded.
+func withRequestID(err error, recorder *requestIDRecorder) error {
+	if err == nil {
+		return nil
+	}
+	if id := recorder.get(); id != "" {
+		return &githubRequestError{err: err, requestID: id}
+	}
+	return err
+}
+
+// queryWithRequestID runs a GraphQL query, adding the request ID of the failed response to its
+// error.
+func queryWithRequestID(ctx context.Context, client *githubv4.Client, q any, vars map[string]any) error {
+	ctx, recorder := contextWithRequestIDRecorder(ctx)
+	return withRequestID(client.Query(ctx, q, vars), recorder)
+}
+
+// mutateWithRequestID runs a GraphQL mutation, adding the request ID of the failed response to its
+// error.
+func mutateWithRequestID(ctx context.Context, client *githubv4.Client, m any, input githubv4.Input, vars map[string]any) error {
+	ctx, recorder := contextWithRequestIDRecorder(ctx)
+	return withRequestID(client.Mutate(ctx, m, input, vars), recorder)
+}
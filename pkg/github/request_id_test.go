@@ -0,0 +1,97 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requestIDStampingTransport sets a request ID on every response, as GitHub does.
+type requestIDStampingTransport struct {
+	transport http.RoundTripper
+	id        string
+}
+
+func (t requestIDStampingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if resp != nil {
+		resp.Header.Set(githubRequestIDHeader, t.id)
+	}
+	return resp, err
+}
+
+func Test_RequestIDOnError(t *testing.T) {
+	qGetDiscussion := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,body,createdAt,author{login},closed,closedAt,isAnswered,answerChosenAt,url,category{name},upvoteCount,viewerHasUpvoted,labels(first: 10){nodes{name,color}},comments{totalCount},reactionGroups{content,users{totalCount},viewerHasReacted}}}}"
+	vars := func(number int) map[string]any {
+		return map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": float64(number)}
+	}
+	mockClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(qGetDiscussion, vars(1), githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussion": map[string]any{
+					"number":         1,
+					"title":          "Test Discussion Title",
+					"body":           "This is a test discussion",
+					"url":            "https://github.com/owner/repo/discussions/1",
+					"createdAt":      "2025-04-25T12:00:00Z",
+					"closed":         false,
+					"isAnswered":     false,
+					"answerChosenAt": nil,
+					"category":       map[string]any{"name": "General"},
+				},
+			},
+		})),
+		githubv4mock.NewQueryMatcher(qGetDiscussion, vars(2), githubv4mock.ErrorResponse("Could not resolve to a Discussion with the number of 2.")),
+	)
+	httpClient := &http.Client{Transport: NewRequestIDTransport(requestIDStampingTransport{transport: mockClient.Transport, id: "C0DE:1234:5678"})}
+	deps := BaseDeps{GQLClient: githubv4.NewClient(httpClient)}
+	toolDef := GetDiscussion(translations.NullTranslationHelper)
+	handler := toolDef.Handler(deps)
+
+	t.Run("surfaced on error", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(2)})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Equal(t, "discussion #2 not found in owner/repo (requestId: C0DE:1234:5678)", getErrorResult(t, res).Text)
+	})
+
+	t.Run("not added on success", func(t *testing.T) {
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1)})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.False(t, res.IsError, getTextResult(t, res).Text)
+		assert.NotContains(t, getTextResult(t, res).Text, "requestId")
+	})
+
+	t.Run("not added to a tool's own error after a successful query", func(t *testing.T) {
+		qCandidates := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){isAnswered,comments(first: 100){nodes{id,body,url,upvoteCount,author{login}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor}}}}}"
+		answeredClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(qCandidates, vars(1), githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"discussion": map[string]any{
+					"isAnswered": true,
+					"comments": map[string]any{
+						"nodes":    []map[string]any{},
+						"pageInfo": map[string]any{"hasNextPage": false, "hasPreviousPage": false, "startCursor": "", "endCursor": ""},
+					},
+				}},
+			})),
+		)
+		httpClient := &http.Client{Transport: NewRequestIDTransport(requestIDStampingTransport{transport: answeredClient.Transport, id: "C0DE:1234:5678"})}
+		deps := BaseDeps{GQLClient: githubv4.NewClient(httpClient)}
+		candidatesTool := GetDiscussionAnswerCandidates(translations.NullTranslationHelper)
+		handler := candidatesTool.Handler(deps)
+
+		req := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "discussionNumber": int32(1)})
+		res, err := handler(ContextWithDeps(context.Background(), deps), &req)
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+		assert.Equal(t, "discussion #1 in owner/repo is already answered", getErrorResult(t, res).Text)
+	})
+}
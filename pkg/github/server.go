@@ -341,6 +341,79 @@ func WithCursorPagination(schema *jsonschema.Schema) *jsonschema.Schema {
 	return schema
 }
 
+// WithBackwardCursorPagination adds the "last" and "before" parameters to a tool with cursor
+// pagination, for tools that can also page backward from the end of a list.
+func WithBackwardCursorPagination(schema *jsonschema.Schema) *jsonschema.Schema {
+	schema.Properties["last"] = &jsonschema.Schema{
+		Type:        "number",
+		Description: "Return the last N results instead of the first (min 1, max 100). Takes precedence over perPage. Cannot be combined with 'after'.",
+		Minimum:     jsonschema.Ptr(1.0),
+		Maximum:     jsonschema.Ptr(100.0),
+	}
+
+	schema.Properties["before"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Cursor for backward pagination. Use the startCursor from the previous page's PageInfo, and hasPreviousPage to tell whether there is one. Cannot be combined with 'after'.",
+	}
+
+	return schema
+}
+
+// Output formats accepted by the "output" parameter added with WithOutputFormat.
+const (
+	OutputFormatJSON   = "json"
+	OutputFormatPretty = "pretty"
+)
+
+// WithOutputFormat adds the "output" formatting parameter to a tool.
+func WithOutputFormat(schema *jsonschema.Schema) *jsonschema.Schema {
+	schema.Properties["output"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Output formatting. 'json' (default) returns compact JSON, 'pretty' returns indented JSON for human-readable debugging.",
+		Enum:        []any{OutputFormatJSON, OutputFormatPretty},
+	}
+
+	return schema
+}
+
+// OptionalOutputFormat returns the "output" parameter from the request, defaulting to OutputFormatJSON.
+func OptionalOutputFormat(args map[string]any) (string, error) {
+	output, err := OptionalParam[string](args, "output")
+	if err != nil {
+		return "", err
+	}
+	switch output {
+	case "":
+		return OutputFormatJSON, nil
+	case OutputFormatJSON, OutputFormatPretty:
+		return output, nil
+	default:
+		return "", fmt.Errorf("output must be one of %q or %q, got %q", OutputFormatJSON, OutputFormatPretty, output)
+	}
+}
+
+// MarshalOutput marshals v according to an output format returned by OptionalOutputFormat.
+func MarshalOutput(v any, output string) ([]byte, error) {
+	if output == OutputFormatPretty {
+		return json.MarshalIndent(v, "", "  ")
+	}
+	return json.Marshal(v)
+}
+
+// resourceLinksProtocolVersion is the first MCP protocol version with resource_link content.
+const resourceLinksProtocolVersion = "2025-06-18"
+
+// ClientSupportsResourceLinks reports whether the client that sent req negotiated a protocol
+// version that supports resource links in tool results.
+func ClientSupportsResourceLinks(req *mcp.CallToolRequest) bool {
+	if req == nil || req.Session == nil {
+		return false
+	}
+	params := req.Session.InitializeParams()
+	// Protocol versions are dates, so they compare correctly as strings
+	return params != nil && params.ProtocolVersion >= resourceLinksProtocolVersion
+}
+
 type PaginationParams struct {
 	Page    int
 	PerPage int
@@ -373,7 +446,8 @@ func OptionalPaginationParams(args map[string]any) (PaginationParams, error) {
 }
 
 // OptionalCursorPaginationParams returns the "perPage" and "after" parameters from the request,
-// without the "page" parameter, suitable for cursor-based pagination only.
+// without the "page" parameter, suitable for cursor-based pagination only. It also returns the
+// "last" and "before" parameters added with WithBackwardCursorPagination.
 func OptionalCursorPaginationParams(args map[string]any) (CursorPaginationParams, error) {
 	perPage, err := OptionalIntParamWithDefault(args, "perPage", 30)
 	if err != nil {
@@ -383,24 +457,36 @@ func OptionalCursorPaginationParams(args map[string]any) (CursorPaginationParams
 	if err != nil {
 		return CursorPaginationParams{}, err
 	}
+	last, err := OptionalIntParam(args, "last")
+	if err != nil {
+		return CursorPaginationParams{}, err
+	}
+	before, err := OptionalParam[string](args, "before")
+	if err != nil {
+		return CursorPaginationParams{}, err
+	}
 	return CursorPaginationParams{
 		PerPage: perPage,
 		After:   after,
+		Last:    last,
+		Before:  before,
 	}, nil
 }
 
 type CursorPaginationParams struct {
 	PerPage int
 	After   string
+	// Last and Before page backward. Before without Last pages backward by PerPage.
+	Last   int
+	Before string
 }
 
 // ToGraphQLParams converts cursor pagination parameters to GraphQL-specific parameters.
+// perPage is checked against GitHub's maximum of 100 here, as GitHub's own error for larger
+// pages doesn't name the parameter.
 func (p CursorPaginationParams) ToGraphQLParams() (*GraphQLPaginationParams, error) {
-	if p.PerPage > 100 {
-		return nil, fmt.Errorf("perPage value %d exceeds maximum of 100", p.PerPage)
-	}
-	if p.PerPage < 0 {
-		return nil, fmt.Errorf("perPage value %d cannot be negative", p.PerPage)
+	if p.PerPage < 1 || p.PerPage > 100 {
+		return nil, errors.New("perPage must be between 1 and 100")
 	}
 	first := int32(p.PerPage)
 
@@ -409,15 +495,46 @@ func (p CursorPaginationParams) ToGraphQLParams() (*GraphQLPaginationParams, err
 		after = &p.After
 	}
 
-	return &GraphQLPaginationParams{
+	params := &GraphQLPaginationParams{
 		First: &first,
 		After: after,
-	}, nil
+	}
+	if p.Last == 0 && p.Before == "" {
+		return params, nil
+	}
+
+	if p.After != "" {
+		return nil, errors.New("after cannot be combined with last or before")
+	}
+	if p.Last > 100 {
+		return nil, fmt.Errorf("last value %d exceeds maximum of 100", p.Last)
+	}
+	if p.Last < 0 {
+		return nil, fmt.Errorf("last value %d cannot be negative", p.Last)
+	}
+	last := first
+	if p.Last > 0 {
+		last = int32(p.Last)
+	}
+	params.Last = &last
+	if p.Before != "" {
+		params.Before = &p.Before
+	}
+	return params, nil
 }
 
+// GraphQLPaginationParams are the connection arguments of a GraphQL query. First is always set, so
+// tools that only page forward can ignore Last and Before; Last is set when paging backward.
 type GraphQLPaginationParams struct {
-	First *int32
-	After *string
+	First  *int32
+	After  *string
+	Last   *int32
+	Before *string
+}
+
+// Backward reports whether the parameters page backward, with Last and Before.
+func (p *GraphQLPaginationParams) Backward() bool {
+	return p.Last != nil
 }
 
 // ToGraphQLParams converts REST API pagination parameters to GraphQL-specific parameters.
@@ -15,6 +15,7 @@ import (
 	"github.com/google/go-github/v79/github"
 	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // stubDeps is a test helper that implements ToolDependencies with configurable behavior.
@@ -24,10 +25,14 @@ type stubDeps struct {
 	gqlClientFn func(context.Context) (*githubv4.Client, error)
 	rawClientFn func(context.Context) (*raw.Client, error)
 
-	repoAccessCache   *lockdown.RepoAccessCache
-	t                 translations.TranslationHelperFunc
-	flags             FeatureFlags
-	contentWindowSize int
+	repoAccessCache     *lockdown.RepoAccessCache
+	t                   translations.TranslationHelperFunc
+	flags               FeatureFlags
+	contentWindowSize   int
+	selectionBudget     int
+	mutationAttribution string
+	defaultPageSize     int
+	graphQLRetryPolicy  GraphQLRetryPolicy
 }
 
 func (s stubDeps) GetClient(ctx context.Context) (*github.Client, error) {
@@ -51,10 +56,15 @@ func (s stubDeps) GetRawClient(ctx context.Context) (*raw.Client, error) {
 	return nil, nil
 }
 
-func (s stubDeps) GetRepoAccessCache() *lockdown.RepoAccessCache { return s.repoAccessCache }
-func (s stubDeps) GetT() translations.TranslationHelperFunc      { return s.t }
-func (s stubDeps) GetFlags() FeatureFlags                        { return s.flags }
-func (s stubDeps) GetContentWindowSize() int                     { return s.contentWindowSize }
+func (s stubDeps) GetRepoAccessCache() *lockdown.RepoAccessCache        { return s.repoAccessCache }
+func (s stubDeps) GetT() translations.TranslationHelperFunc             { return s.t }
+func (s stubDeps) GetFlags() FeatureFlags                               { return s.flags }
+func (s stubDeps) GetContentWindowSize() int                            { return s.contentWindowSize }
+func (s stubDeps) GetSelectionBudget() int                              { return s.selectionBudget }
+func (s stubDeps) GetMutationAttribution() string                       { return s.mutationAttribution }
+func (s stubDeps) GetDiscussionCategoryCache() *DiscussionCategoryCache { return nil }
+func (s stubDeps) GetDefaultPageSize() int                              { return s.defaultPageSize }
+func (s stubDeps) GetGraphQLRetryPolicy() GraphQLRetryPolicy            { return s.graphQLRetryPolicy }
 
 // Helper functions to create stub client functions for error testing
 func stubClientFnFromHTTP(httpClient *http.Client) func(context.Context) (*github.Client, error) {
@@ -594,3 +604,97 @@ func TestOptionalPaginationParams(t *testing.T) {
 		})
 	}
 }
+
+func TestCursorPaginationParamsToGraphQLParams(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        CursorPaginationParams
+		expectedFirst int32
+		expectError   string
+	}{
+		{
+			name:          "perPage within range",
+			params:        CursorPaginationParams{PerPage: 100},
+			expectedFirst: 100,
+		},
+		{
+			name:        "perPage above GitHub's maximum",
+			params:      CursorPaginationParams{PerPage: 500},
+			expectError: "perPage must be between 1 and 100",
+		},
+		{
+			name:        "negative perPage",
+			params:      CursorPaginationParams{PerPage: -1},
+			expectError: "perPage must be between 1 and 100",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.params.ToGraphQLParams()
+
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedFirst, *result.First)
+			}
+		})
+	}
+}
+
+func TestOptionalOutputFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]any
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "defaults to json",
+			params:   map[string]any{},
+			expected: OutputFormatJSON,
+		},
+		{
+			name:     "pretty",
+			params:   map[string]any{"output": "pretty"},
+			expected: OutputFormatPretty,
+		},
+		{
+			name:        "unknown format",
+			params:      map[string]any{"output": "yaml"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := OptionalOutputFormat(tc.params)
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestMarshalOutput(t *testing.T) {
+	v := map[string]any{"number": 1, "title": "Discussion"}
+
+	compact, err := MarshalOutput(v, OutputFormatJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"number":1,"title":"Discussion"}`, string(compact))
+
+	pretty, err := MarshalOutput(v, OutputFormatPretty)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"number\": 1,\n  \"title\": \"Discussion\"\n}", string(pretty))
+
+	// Both formats carry the same data
+	var fromCompact, fromPretty map[string]any
+	assert.NoError(t, json.Unmarshal(compact, &fromCompact))
+	assert.NoError(t, json.Unmarshal(pretty, &fromPretty))
+	assert.Equal(t, fromCompact, fromPretty)
+}
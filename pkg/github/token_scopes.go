@@ -0,0 +1,57 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/google/go-github/v79/github"
+)
+
+// oauthScopesHeader lists the scopes granted to a classic token. It's absent for fine-grained
+// and GitHub App tokens, whose permissions can't be read from a response.
+const oauthScopesHeader = "X-OAuth-Scopes"
+
+// discussionWriteScopes are the classic token scopes that allow writing to discussions.
+var discussionWriteScopes = []string{"repo", "public_repo"}
+
+// hasDiscussionWriteScope reports whether a response's headers show a token that can write to
+// discussions. A missing scopes header means the token's permissions are unknown, so write access
+// is assumed and left for the API to enforce.
+func hasDiscussionWriteScope(header http.Header) bool {
+	if _, ok := header[http.CanonicalHeaderKey(oauthScopesHeader)]; !ok {
+		return true
+	}
+	for _, scope := range strings.Split(header.Get(oauthScopesHeader), ",") {
+		for _, writeScope := range discussionWriteScopes {
+			if strings.TrimSpace(scope) == writeScope {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ProbeDiscussionWriteAccess checks at startup whether the client's token can write to discussions,
+// by reading the scopes GitHub reports for it on a request for the authenticated user.
+func ProbeDiscussionWriteAccess(ctx context.Context, client *github.Client) (bool, error) {
+	_, resp, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return false, fmt.Errorf("failed to probe token scopes: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return hasDiscussionWriteScope(resp.Header), nil
+}
+
+// DiscussionWriteToolsFilter returns a tool filter that leaves out the discussion tools that write,
+// unless canWrite is set. Read-only discussion tools and tools in other toolsets are unaffected.
+func DiscussionWriteToolsFilter(canWrite bool) inventory.ToolFilter {
+	return func(_ context.Context, tool *inventory.ServerTool) (bool, error) {
+		if canWrite || tool.Toolset.ID != ToolsetMetadataDiscussions.ID {
+			return true, nil
+		}
+		return tool.IsReadOnly(), nil
+	}
+}
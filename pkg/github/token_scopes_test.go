@@ -0,0 +1,86 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProbeDiscussionWriteAccess(t *testing.T) {
+	tests := []struct {
+		name      string
+		scopes    *string
+		wantWrite bool
+	}{
+		{name: "repo scope", scopes: github.Ptr("read:org, repo"), wantWrite: true},
+		{name: "public_repo scope", scopes: github.Ptr("public_repo"), wantWrite: true},
+		{name: "read-only scopes", scopes: github.Ptr("read:org, read:user"), wantWrite: false},
+		{name: "no scopes", scopes: github.Ptr(""), wantWrite: false},
+		{name: "fine-grained token", scopes: nil, wantWrite: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetUser: func(w http.ResponseWriter, _ *http.Request) {
+					if tc.scopes != nil {
+						w.Header().Set(oauthScopesHeader, *tc.scopes)
+					}
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{"login":"octocat"}`))
+				},
+			}))
+
+			canWrite, err := ProbeDiscussionWriteAccess(context.Background(), client)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantWrite, canWrite)
+		})
+	}
+
+	t.Run("probe failure", func(t *testing.T) {
+		client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetUser: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"message":"Bad credentials"}`))
+			},
+		}))
+
+		_, err := ProbeDiscussionWriteAccess(context.Background(), client)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to probe token scopes")
+	})
+}
+
+func Test_DiscussionWriteToolsFilter(t *testing.T) {
+	toolNames := func(canWrite bool) []string {
+		inv := NewInventory(translations.NullTranslationHelper).
+			WithToolsets([]string{string(ToolsetMetadataDiscussions.ID)}).
+			WithFilter(DiscussionWriteToolsFilter(canWrite)).
+			Build()
+		var names []string
+		for _, tool := range inv.AvailableTools(context.Background()) {
+			names = append(names, tool.Tool.Name)
+		}
+		return names
+	}
+
+	t.Run("read-only token omits write tools", func(t *testing.T) {
+		names := toolNames(false)
+		assert.Contains(t, names, "list_discussions")
+		assert.Contains(t, names, "get_discussion")
+		assert.NotContains(t, names, "create_discussion")
+		assert.NotContains(t, names, "add_discussion_comment")
+		assert.NotContains(t, names, "close_discussion")
+	})
+
+	t.Run("write token keeps write tools", func(t *testing.T) {
+		names := toolNames(true)
+		assert.Contains(t, names, "list_discussions")
+		assert.Contains(t, names, "create_discussion")
+	})
+}
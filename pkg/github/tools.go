@@ -234,11 +234,49 @@ func AllTools(t translations.TranslationHelperFunc) []inventory.ServerTool {
 		GetDiscussion(t),
 		GetDiscussionComments(t),
 		ListDiscussionCategories(t),
+		GetDiscussionCategory(t),
+		GetRepositoryDiscussionSettings(t),
 		CreateDiscussion(t),
 		UpdateDiscussion(t),
 		AddDiscussionComment(t),
 		UpdateDiscussionComment(t),
 		DeleteDiscussionComment(t),
+		ReactToDiscussionAnswer(t),
+		AddDiscussionLabels(t),
+		RemoveDiscussionLabels(t),
+		DiscussionActivityHistogram(t),
+		ExportDiscussionCategories(t),
+		GetDiscussionReferences(t),
+		ListDiscussionsWithRecentCommentBy(t),
+		GetDiscussionAnswerCandidates(t),
+		VerifyDiscussionComment(t),
+		ListLockedDiscussions(t),
+		GetDiscussions(t),
+		GetOldestUnansweredDiscussion(t),
+		AddDiscussionReaction(t),
+		RemoveDiscussionReaction(t),
+		GetDiscussionSignals(t),
+		AddDiscussionComments(t),
+		ListPinnedDiscussions(t),
+		PinDiscussion(t),
+		UnpinDiscussion(t),
+		GetDiscussionFirstResponseTimes(t),
+		DeleteDiscussion(t),
+		CloseDiscussion(t),
+		LockDiscussion(t),
+		UnlockDiscussion(t),
+		ListUncategorizedDiscussions(t),
+		CountDiscussions(t),
+		MarkDiscussionCommentAsAnswer(t),
+		UnmarkDiscussionCommentAsAnswer(t),
+		MinimizeDiscussionComment(t),
+		UnminimizeDiscussionComment(t),
+		VoteOnDiscussionPoll(t),
+		MarkDiscussionAnswerByText(t),
+		RemoveDiscussionLabelBulk(t),
+		SearchDiscussions(t),
+		ListRecentlyAnsweredDiscussions(t),
+		SearchDiscussionComments(t),
 
 		// Actions tools
 		ListWorkflows(t),
@@ -255,8 +255,9 @@ func (r *Inventory) HasToolset(toolsetID ToolsetID) bool {
 func (r *Inventory) AllTools() []ServerTool {
 	result := slices.Clone(r.tools)
 
-	// Sort deterministically: by toolset ID, then by tool name
-	sort.Slice(result, func(i, j int) bool {
+	// Sort deterministically: by toolset ID, then by tool name. The sort is stable so that tools
+	// sharing a name, such as the feature-flagged variants of a tool, keep their registration order.
+	sort.SliceStable(result, func(i, j int) bool {
 		if result[i].Toolset.ID != result[j].Toolset.ID {
 			return result[i].Toolset.ID < result[j].Toolset.ID
 		}